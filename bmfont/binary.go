@@ -0,0 +1,109 @@
+package bmfont
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io"
+	"io/ioutil"
+
+	"github.com/pkg/errors"
+)
+
+// binary block types
+const (
+	blockInfo     = 1
+	blockCommon   = 2
+	blockPages    = 3
+	blockChars    = 4
+	blockKernings = 5
+)
+
+// readBinary reads the version 3 binary descriptor format
+func readBinary(r io.Reader) (*Font, error) {
+	data, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	if len(data) < 4 || string(data[:3]) != "BMF" {
+		return nil, ErrFormatUnknown
+	}
+	if data[3] != 3 {
+		return nil, ErrVersionUnsupported
+	}
+
+	f := newFont()
+	le := binary.LittleEndian
+	data = data[4:]
+	for len(data) > 0 {
+		if len(data) < 5 {
+			return nil, io.ErrUnexpectedEOF
+		}
+		blockType := data[0]
+		size := int(le.Uint32(data[1:5]))
+		data = data[5:]
+		if size > len(data) {
+			return nil, errors.Wrapf(io.ErrUnexpectedEOF, "block %d", blockType)
+		}
+		b := data[:size]
+		data = data[size:]
+
+		switch blockType {
+		case blockInfo:
+			if len(b) < 14 {
+				return nil, errors.Wrap(io.ErrUnexpectedEOF, "info")
+			}
+			f.Info = Info{
+				Size:     int(int16(le.Uint16(b[0:]))),
+				IsBold:   b[2]&0x10 != 0,
+				IsItalic: b[2]&0x20 != 0,
+				Outline:  int(b[13]),
+				Face:     cString(b[14:]),
+			}
+		case blockCommon:
+			if len(b) < 10 {
+				return nil, errors.Wrap(io.ErrUnexpectedEOF, "common")
+			}
+			f.Common = Common{
+				LineHeight: int(le.Uint16(b[0:])),
+				Base:       int(le.Uint16(b[2:])),
+				ScaleW:     int(le.Uint16(b[4:])),
+				ScaleH:     int(le.Uint16(b[6:])),
+				Pages:      int(le.Uint16(b[8:])),
+			}
+		case blockPages:
+			for _, name := range bytes.Split(bytes.TrimRight(b, "\x00"), []byte{0}) {
+				f.Pages = append(f.Pages, string(name))
+			}
+		case blockChars:
+			for ; len(b) >= 20; b = b[20:] {
+				c := &Char{
+					ID:       rune(le.Uint32(b[0:])),
+					X:        int(le.Uint16(b[4:])),
+					Y:        int(le.Uint16(b[6:])),
+					Width:    int(le.Uint16(b[8:])),
+					Height:   int(le.Uint16(b[10:])),
+					XOffset:  int(int16(le.Uint16(b[12:]))),
+					YOffset:  int(int16(le.Uint16(b[14:]))),
+					XAdvance: int(int16(le.Uint16(b[16:]))),
+					Page:     int(b[18]),
+				}
+				f.Chars[c.ID] = c
+			}
+		case blockKernings:
+			for ; len(b) >= 10; b = b[10:] {
+				k := KerningPair{First: rune(le.Uint32(b[0:])), Second: rune(le.Uint32(b[4:]))}
+				f.Kernings[k] = int(int16(le.Uint16(b[8:])))
+			}
+		}
+	}
+	return f, nil
+}
+
+// cString returns the null terminated string at the start of b
+func cString(b []byte) string {
+	i := bytes.IndexByte(b, 0)
+	if i < 0 {
+		return string(b)
+	}
+	return string(b[:i])
+}
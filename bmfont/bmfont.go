@@ -0,0 +1,125 @@
+package bmfont
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+
+	"github.com/pkg/errors"
+)
+
+var (
+	// ErrFormatUnknown is returned when data is not a text, XML or binary BMFont descriptor
+	ErrFormatUnknown = fmt.Errorf("bmfont format unknown")
+	// ErrVersionUnsupported is returned when a binary descriptor is not version 3
+	ErrVersionUnsupported = fmt.Errorf("bmfont binary version unsupported")
+	// ErrPageNotFound is returned when a glyph refers to a page image that was not provided
+	ErrPageNotFound = fmt.Errorf("bmfont page not found")
+	// ErrPageInvalid is returned when a page id is negative or past the page count of a descriptor
+	ErrPageInvalid = fmt.Errorf("bmfont page id invalid")
+)
+
+// maxPages caps page ids of descriptors that do not state their page count
+const maxPages = 256
+
+// Font is an AngelCode BMFont descriptor
+type Font struct {
+	Info     Info
+	Common   Common
+	Pages    []string
+	Chars    map[rune]*Char
+	Kernings map[KerningPair]int
+}
+
+// Info describes how a font was generated
+type Info struct {
+	Face     string
+	Size     int
+	IsBold   bool
+	IsItalic bool
+	Outline  int
+}
+
+// Common contains metrics shared by every glyph
+type Common struct {
+	// LineHeight is the distance in pixels between each line of text
+	LineHeight int
+	// Base is the distance in pixels from the top of a line to the baseline
+	Base   int
+	ScaleW int
+	ScaleH int
+	Pages  int
+}
+
+// Char is a glyph within a page image
+type Char struct {
+	ID       rune
+	X        int
+	Y        int
+	Width    int
+	Height   int
+	XOffset  int
+	YOffset  int
+	XAdvance int
+	Page     int
+}
+
+// KerningPair is a pair of runes with adjusted spacing
+type KerningPair struct {
+	First  rune
+	Second rune
+}
+
+// setPage sets the file of page id, growing the page list. Ids must be below the page count, or maxPages if it is unknown
+func (f *Font) setPage(id int, file string) error {
+	count := f.Common.Pages
+	if count <= 0 || count > maxPages {
+		count = maxPages
+	}
+	if id < 0 || id >= count {
+		return errors.Wrapf(ErrPageInvalid, "id %d", id)
+	}
+	for len(f.Pages) <= id {
+		f.Pages = append(f.Pages, "")
+	}
+	f.Pages[id] = file
+	return nil
+}
+
+// A Reader reads a BMFont descriptor in text, XML or binary form
+type Reader struct {
+	r io.Reader
+}
+
+// NewReader returns a new Reader that reads from r.
+func NewReader(r io.Reader) *Reader {
+	return &Reader{
+		r: r,
+	}
+}
+
+// Read detects the descriptor format and reads it
+func (r *Reader) Read() (*Font, error) {
+	br := bufio.NewReader(r.r)
+	head, err := br.Peek(5)
+	if err != nil && err != io.EOF {
+		return nil, err
+	}
+	switch {
+	case bytes.HasPrefix(head, []byte("BMF")):
+		return readBinary(br)
+	case bytes.HasPrefix(bytes.TrimLeft(head, "\xef\xbb\xbf \t\r\n"), []byte("<")):
+		return readXML(br)
+	case bytes.HasPrefix(head, []byte("info")), bytes.HasPrefix(head, []byte("commo")):
+		return readText(br)
+	}
+	return nil, ErrFormatUnknown
+}
+
+func newFont() *Font {
+	return &Font{
+		Chars:    make(map[rune]*Char),
+		Kernings: make(map[KerningPair]int),
+	}
+}
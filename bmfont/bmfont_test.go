@@ -0,0 +1,212 @@
+package bmfont
+
+import (
+	"bytes"
+	"encoding/binary"
+	"image"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/pkg/errors"
+)
+
+const textDescriptor = `info face="Pixel Sans" size=16 bold=1 italic=0 outline=1
+common lineHeight=18 base=14 scaleW=256 scaleH=128 pages=2
+page id=0 file="pixel_0.png"
+page id=1 file="pixel_1.png"
+chars count=2
+char id=65 x=1 y=2 width=8 height=10 xoffset=0 yoffset=4 xadvance=9 page=0
+char id=66 x=10 y=2 width=7 height=10 xoffset=-1 yoffset=4 xadvance=8 page=1
+kernings count=1
+kerning first=65 second=66 amount=-1
+`
+
+const xmlDescriptor = `<?xml version="1.0"?>
+<font>
+	<info face="Pixel Sans" size="16" bold="1" italic="0" outline="1"/>
+	<common lineHeight="18" base="14" scaleW="256" scaleH="128" pages="2"/>
+	<pages>
+		<page id="0" file="pixel_0.png"/>
+		<page id="1" file="pixel_1.png"/>
+	</pages>
+	<chars count="2">
+		<char id="65" x="1" y="2" width="8" height="10" xoffset="0" yoffset="4" xadvance="9" page="0"/>
+		<char id="66" x="10" y="2" width="7" height="10" xoffset="-1" yoffset="4" xadvance="8" page="1"/>
+	</chars>
+	<kernings count="1">
+		<kerning first="65" second="66" amount="-1"/>
+	</kernings>
+</font>
+`
+
+// binaryDescriptor encodes the same font as textDescriptor in the version 3 binary format
+func binaryDescriptor() []byte {
+	le := binary.LittleEndian
+	buf := &bytes.Buffer{}
+	buf.WriteString("BMF\x03")
+	block := func(blockType byte, data []byte) {
+		buf.WriteByte(blockType)
+		binary.Write(buf, le, uint32(len(data)))
+		buf.Write(data)
+	}
+
+	info := &bytes.Buffer{}
+	binary.Write(info, le, int16(16))
+	info.WriteByte(0x10)
+	info.Write(make([]byte, 10))
+	info.WriteByte(1)
+	info.WriteString("Pixel Sans\x00")
+	block(blockInfo, info.Bytes())
+
+	common := &bytes.Buffer{}
+	for _, v := range []uint16{18, 14, 256, 128, 2} {
+		binary.Write(common, le, v)
+	}
+	common.Write(make([]byte, 5))
+	block(blockCommon, common.Bytes())
+
+	block(blockPages, []byte("pixel_0.png\x00pixel_1.png\x00"))
+
+	chars := &bytes.Buffer{}
+	for _, c := range []Char{
+		{ID: 65, X: 1, Y: 2, Width: 8, Height: 10, XOffset: 0, YOffset: 4, XAdvance: 9, Page: 0},
+		{ID: 66, X: 10, Y: 2, Width: 7, Height: 10, XOffset: -1, YOffset: 4, XAdvance: 8, Page: 1},
+	} {
+		binary.Write(chars, le, uint32(c.ID))
+		for _, v := range []int{c.X, c.Y, c.Width, c.Height, c.XOffset, c.YOffset, c.XAdvance} {
+			binary.Write(chars, le, int16(v))
+		}
+		chars.WriteByte(byte(c.Page))
+		chars.WriteByte(15)
+	}
+	block(blockChars, chars.Bytes())
+
+	kernings := &bytes.Buffer{}
+	binary.Write(kernings, le, uint32(65))
+	binary.Write(kernings, le, uint32(66))
+	binary.Write(kernings, le, int16(-1))
+	block(blockKernings, kernings.Bytes())
+	return buf.Bytes()
+}
+
+func TestRead(t *testing.T) {
+	tests := []struct {
+		name string
+		data []byte
+	}{
+		{"text", []byte(textDescriptor)},
+		{"xml", []byte(xmlDescriptor)},
+		{"binary", binaryDescriptor()},
+	}
+	for _, tt := range tests {
+		f, err := NewReader(bytes.NewReader(tt.data)).Read()
+		if err != nil {
+			t.Fatalf("%s: read: %v", tt.name, err)
+		}
+		if f.Info.Face != "Pixel Sans" || f.Info.Size != 16 || !f.Info.IsBold || f.Info.IsItalic || f.Info.Outline != 1 {
+			t.Errorf("%s: info %+v", tt.name, f.Info)
+		}
+		want := Common{LineHeight: 18, Base: 14, ScaleW: 256, ScaleH: 128, Pages: 2}
+		if f.Common != want {
+			t.Errorf("%s: common %+v, want %+v", tt.name, f.Common, want)
+		}
+		if len(f.Pages) != 2 || f.Pages[0] != "pixel_0.png" || f.Pages[1] != "pixel_1.png" {
+			t.Errorf("%s: pages %q", tt.name, f.Pages)
+		}
+		if len(f.Chars) != 2 {
+			t.Fatalf("%s: %d chars, want 2", tt.name, len(f.Chars))
+		}
+		wantChar := Char{ID: 66, X: 10, Y: 2, Width: 7, Height: 10, XOffset: -1, YOffset: 4, XAdvance: 8, Page: 1}
+		if c := f.Chars['B']; c == nil || *c != wantChar {
+			t.Errorf("%s: char B %+v, want %+v", tt.name, c, wantChar)
+		}
+		if amount := f.Kernings[KerningPair{First: 'A', Second: 'B'}]; amount != -1 {
+			t.Errorf("%s: kerning %d, want -1", tt.name, amount)
+		}
+	}
+}
+
+func TestReadMalformed(t *testing.T) {
+	valid := binaryDescriptor()
+	tests := []struct {
+		name string
+		data []byte
+		err  error
+	}{
+		{"empty", nil, ErrFormatUnknown},
+		{"unknown", []byte("hello world"), ErrFormatUnknown},
+		{"binary version", []byte("BMF\x02"), ErrVersionUnsupported},
+		{"binary block header", []byte("BMF\x03\x01\x00"), io.ErrUnexpectedEOF},
+		{"binary block size", valid[:len(valid)-4], io.ErrUnexpectedEOF},
+		{"binary short common", []byte("BMF\x03\x02\x02\x00\x00\x00\x12\x00"), io.ErrUnexpectedEOF},
+		{"text negative page", []byte("common lineHeight=18 pages=1\npage id=-1 file=\"a.png\"\n"), ErrPageInvalid},
+		{"text page past count", []byte("common lineHeight=18 pages=1\npage id=1 file=\"a.png\"\n"), ErrPageInvalid},
+		{"text huge page", []byte("common lineHeight=18\npage id=2000000000 file=\"a.png\"\n"), ErrPageInvalid},
+		{"xml negative page", []byte(`<font><common pages="1"/><pages><page id="-1" file="a.png"/></pages></font>`), ErrPageInvalid},
+		{"xml page past count", []byte(`<font><common pages="1"/><pages><page id="3" file="a.png"/></pages></font>`), ErrPageInvalid},
+	}
+	for _, tt := range tests {
+		_, err := NewReader(bytes.NewReader(tt.data)).Read()
+		if errors.Cause(err) != tt.err {
+			t.Errorf("%s: error %v, want %v", tt.name, err, tt.err)
+		}
+	}
+
+	_, err := NewReader(strings.NewReader("info size=big\n")).Read()
+	if err == nil {
+		t.Errorf("text bad number: no error")
+	}
+	_, err = NewReader(strings.NewReader("<font><info size=\"16\">")).Read()
+	if err == nil {
+		t.Errorf("xml unterminated: no error")
+	}
+}
+
+func TestParseTextLine(t *testing.T) {
+	tag, attrs := parseTextLine(`  info face="Pixel Sans" size=16 charset=""`)
+	if tag != "info" {
+		t.Errorf("tag %q, want info", tag)
+	}
+	want := map[string]string{"face": "Pixel Sans", "size": "16", "charset": ""}
+	for k, v := range want {
+		if attrs[k] != v {
+			t.Errorf("%s %q, want %q", k, attrs[k], v)
+		}
+	}
+	tag, attrs = parseTextLine("chars")
+	if tag != "chars" || len(attrs) != 0 {
+		t.Errorf("chars: tag %q attrs %v", tag, attrs)
+	}
+}
+
+func TestFaceHasChar(t *testing.T) {
+	f, err := NewReader(strings.NewReader(textDescriptor + "char id=-1 x=0 y=0 width=4 height=4 xoffset=0 yoffset=0 xadvance=5 page=0\n")).Read()
+	if err != nil {
+		t.Fatal(err)
+	}
+	page := image.NewAlpha(image.Rect(0, 0, 256, 128))
+	face, err := NewFace(f, []image.Image{page, page})
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		r       rune
+		hasChar bool
+		advance int
+	}{
+		{'A', true, 9},
+		{'B', true, 8},
+		// the missing glyph draws Z, but the font does not define it
+		{'Z', false, 5},
+	}
+	for _, tt := range tests {
+		if face.HasChar(tt.r) != tt.hasChar {
+			t.Errorf("%q: HasChar %v, want %v", tt.r, !tt.hasChar, tt.hasChar)
+		}
+		advance, ok := face.GlyphAdvance(tt.r)
+		if !ok || advance.Round() != tt.advance {
+			t.Errorf("%q: advance %v %v, want %d", tt.r, advance, ok, tt.advance)
+		}
+	}
+}
@@ -0,0 +1,105 @@
+package bmfont
+
+import (
+	"image"
+
+	"golang.org/x/image/font"
+	"golang.org/x/image/math/fixed"
+)
+
+// missingGlyph is the char id BMFont uses for the glyph drawn in place of missing runes
+const missingGlyph = -1
+
+// Face is a font.Face drawing glyphs from BMFont page images.
+// Glyphs are used as alpha masks, so text takes the color it is drawn with
+type Face struct {
+	font  *Font
+	pages []image.Image
+}
+
+// NewFace returns a face for f, pages are the page images in page id order
+func NewFace(f *Font, pages []image.Image) (*Face, error) {
+	for _, c := range f.Chars {
+		if c.Page < 0 || c.Page >= len(pages) || pages[c.Page] == nil {
+			return nil, ErrPageNotFound
+		}
+	}
+	return &Face{font: f, pages: pages}, nil
+}
+
+// Font returns the descriptor of the face
+func (fc *Face) Font() *Font {
+	return fc.font
+}
+
+// Close satisfies font.Face, page images are owned by the caller
+func (fc *Face) Close() error {
+	return nil
+}
+
+// char returns the glyph for r, or the missing glyph if the font has one
+func (fc *Face) char(r rune) (*Char, bool) {
+	c, ok := fc.font.Chars[r]
+	if !ok {
+		c, ok = fc.font.Chars[missingGlyph]
+	}
+	return c, ok
+}
+
+// HasChar returns true if the font defines a glyph for r. Unlike GlyphAdvance it does not count the missing glyph,
+// so callers can fall back to another font
+func (fc *Face) HasChar(r rune) bool {
+	_, ok := fc.font.Chars[r]
+	return ok
+}
+
+// Glyph returns the page mask of the glyph for r with dot on the baseline
+func (fc *Face) Glyph(dot fixed.Point26_6, r rune) (image.Rectangle, image.Image, image.Point, fixed.Int26_6, bool) {
+	c, ok := fc.char(r)
+	if !ok {
+		return image.Rectangle{}, nil, image.Point{}, 0, false
+	}
+	x := dot.X.Round() + c.XOffset
+	y := dot.Y.Round() - fc.font.Common.Base + c.YOffset
+	dr := image.Rect(x, y, x+c.Width, y+c.Height)
+	return dr, fc.pages[c.Page], image.Pt(c.X, c.Y), fixed.I(c.XAdvance), true
+}
+
+// GlyphBounds returns the bounds of the glyph for r relative to the dot
+func (fc *Face) GlyphBounds(r rune) (fixed.Rectangle26_6, fixed.Int26_6, bool) {
+	c, ok := fc.char(r)
+	if !ok {
+		return fixed.Rectangle26_6{}, 0, false
+	}
+	y := c.YOffset - fc.font.Common.Base
+	bounds := fixed.Rectangle26_6{
+		Min: fixed.P(c.XOffset, y),
+		Max: fixed.P(c.XOffset+c.Width, y+c.Height),
+	}
+	return bounds, fixed.I(c.XAdvance), true
+}
+
+// GlyphAdvance returns the advance width of the glyph for r
+func (fc *Face) GlyphAdvance(r rune) (fixed.Int26_6, bool) {
+	c, ok := fc.char(r)
+	if !ok {
+		return 0, false
+	}
+	return fixed.I(c.XAdvance), true
+}
+
+// Kern returns the spacing adjustment between r0 and r1
+func (fc *Face) Kern(r0, r1 rune) fixed.Int26_6 {
+	return fixed.I(fc.font.Kernings[KerningPair{First: r0, Second: r1}])
+}
+
+// Metrics returns the line metrics of the face
+func (fc *Face) Metrics() font.Metrics {
+	c := fc.font.Common
+	return font.Metrics{
+		Height:     fixed.I(c.LineHeight),
+		Ascent:     fixed.I(c.Base),
+		Descent:    fixed.I(c.LineHeight - c.Base),
+		CaretSlope: image.Pt(0, 1),
+	}
+}
@@ -0,0 +1,128 @@
+package bmfont
+
+import (
+	"bufio"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// readText reads the text descriptor format, one tag per line of key=value pairs
+func readText(r io.Reader) (*Font, error) {
+	f := newFont()
+	s := bufio.NewScanner(r)
+	line := 0
+	for s.Scan() {
+		line++
+		tag, attrs := parseTextLine(s.Text())
+		a := &attributes{values: attrs}
+		switch tag {
+		case "info":
+			f.Info = Info{
+				Face:     a.str("face"),
+				Size:     a.int("size"),
+				IsBold:   a.int("bold") == 1,
+				IsItalic: a.int("italic") == 1,
+				Outline:  a.int("outline"),
+			}
+		case "common":
+			f.Common = Common{
+				LineHeight: a.int("lineHeight"),
+				Base:       a.int("base"),
+				ScaleW:     a.int("scaleW"),
+				ScaleH:     a.int("scaleH"),
+				Pages:      a.int("pages"),
+			}
+		case "page":
+			err := f.setPage(a.int("id"), a.str("file"))
+			if err != nil && a.err == nil {
+				return nil, errors.Wrapf(err, "line %d %s", line, tag)
+			}
+		case "char":
+			c := &Char{
+				ID:       rune(a.int("id")),
+				X:        a.int("x"),
+				Y:        a.int("y"),
+				Width:    a.int("width"),
+				Height:   a.int("height"),
+				XOffset:  a.int("xoffset"),
+				YOffset:  a.int("yoffset"),
+				XAdvance: a.int("xadvance"),
+				Page:     a.int("page"),
+			}
+			f.Chars[c.ID] = c
+		case "kerning":
+			f.Kernings[KerningPair{First: rune(a.int("first")), Second: rune(a.int("second"))}] = a.int("amount")
+		}
+		if a.err != nil {
+			return nil, errors.Wrapf(a.err, "line %d %s", line, tag)
+		}
+	}
+	err := s.Err()
+	if err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+// parseTextLine splits a line into its tag and attributes. Quoted values may contain spaces
+func parseTextLine(line string) (string, map[string]string) {
+	line = strings.TrimSpace(line)
+	i := strings.IndexAny(line, " \t")
+	if i < 0 {
+		return line, nil
+	}
+	tag := line[:i]
+	attrs := make(map[string]string)
+	rest := line[i:]
+	for {
+		rest = strings.TrimLeft(rest, " \t")
+		eq := strings.IndexByte(rest, '=')
+		if eq < 0 {
+			break
+		}
+		key := rest[:eq]
+		rest = rest[eq+1:]
+		var value string
+		if strings.HasPrefix(rest, `"`) {
+			end := strings.IndexByte(rest[1:], '"')
+			if end < 0 {
+				value, rest = rest[1:], ""
+			} else {
+				value, rest = rest[1:end+1], rest[end+2:]
+			}
+		} else {
+			end := strings.IndexAny(rest, " \t")
+			if end < 0 {
+				end = len(rest)
+			}
+			value, rest = rest[:end], rest[end:]
+		}
+		attrs[key] = value
+	}
+	return tag, attrs
+}
+
+// attributes reads typed values, keeping the first error
+type attributes struct {
+	values map[string]string
+	err    error
+}
+
+func (a *attributes) str(key string) string {
+	return a.values[key]
+}
+
+func (a *attributes) int(key string) int {
+	v, ok := a.values[key]
+	if !ok {
+		return 0
+	}
+	n, err := strconv.Atoi(v)
+	if err != nil && a.err == nil {
+		a.err = errors.Wrap(err, key)
+	}
+	return n
+}
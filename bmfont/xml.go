@@ -0,0 +1,84 @@
+package bmfont
+
+import (
+	"encoding/xml"
+	"io"
+)
+
+// readXML reads the XML descriptor format
+func readXML(r io.Reader) (*Font, error) {
+	doc := struct {
+		Info struct {
+			Face    string `xml:"face,attr"`
+			Size    int    `xml:"size,attr"`
+			Bold    int    `xml:"bold,attr"`
+			Italic  int    `xml:"italic,attr"`
+			Outline int    `xml:"outline,attr"`
+		} `xml:"info"`
+		Common struct {
+			LineHeight int `xml:"lineHeight,attr"`
+			Base       int `xml:"base,attr"`
+			ScaleW     int `xml:"scaleW,attr"`
+			ScaleH     int `xml:"scaleH,attr"`
+			Pages      int `xml:"pages,attr"`
+		} `xml:"common"`
+		Pages []struct {
+			ID   int    `xml:"id,attr"`
+			File string `xml:"file,attr"`
+		} `xml:"pages>page"`
+		Chars []struct {
+			ID       int `xml:"id,attr"`
+			X        int `xml:"x,attr"`
+			Y        int `xml:"y,attr"`
+			Width    int `xml:"width,attr"`
+			Height   int `xml:"height,attr"`
+			XOffset  int `xml:"xoffset,attr"`
+			YOffset  int `xml:"yoffset,attr"`
+			XAdvance int `xml:"xadvance,attr"`
+			Page     int `xml:"page,attr"`
+		} `xml:"chars>char"`
+		Kernings []struct {
+			First  int `xml:"first,attr"`
+			Second int `xml:"second,attr"`
+			Amount int `xml:"amount,attr"`
+		} `xml:"kernings>kerning"`
+	}{}
+
+	err := xml.NewDecoder(r).Decode(&doc)
+	if err != nil {
+		return nil, err
+	}
+
+	f := newFont()
+	f.Info = Info{
+		Face:     doc.Info.Face,
+		Size:     doc.Info.Size,
+		IsBold:   doc.Info.Bold == 1,
+		IsItalic: doc.Info.Italic == 1,
+		Outline:  doc.Info.Outline,
+	}
+	f.Common = Common(doc.Common)
+	for _, p := range doc.Pages {
+		err = f.setPage(p.ID, p.File)
+		if err != nil {
+			return nil, err
+		}
+	}
+	for _, c := range doc.Chars {
+		f.Chars[rune(c.ID)] = &Char{
+			ID:       rune(c.ID),
+			X:        c.X,
+			Y:        c.Y,
+			Width:    c.Width,
+			Height:   c.Height,
+			XOffset:  c.XOffset,
+			YOffset:  c.YOffset,
+			XAdvance: c.XAdvance,
+			Page:     c.Page,
+		}
+	}
+	for _, k := range doc.Kernings {
+		f.Kernings[KerningPair{First: rune(k.First), Second: rune(k.Second)}] = k.Amount
+	}
+	return f, nil
+}
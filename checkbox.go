@@ -0,0 +1,31 @@
+package egui
+
+import (
+	"image/color"
+
+	"github.com/pkg/errors"
+	"github.com/xackery/egui/common"
+	"github.com/xackery/egui/element/checkbox"
+)
+
+// NewCheckbox creates a new checkbox instance, drawn with 9slices of the ui image.
+// indeterminateSliceName may be empty to draw the indeterminate state unchecked
+func (u *UI) NewCheckbox(name string, scene string, text string, x float64, y float64, width int, height int, textColor color.Color, uncheckedSliceName string, checkedSliceName string, indeterminateSliceName string) (*checkbox.Element, error) {
+	imageName := "ui"
+	img, err := u.Image(imageName)
+	if err != nil {
+		return nil, errors.Wrap(err, imageName)
+	}
+
+	s, err := u.Scene(scene)
+	if err != nil {
+		return nil, common.ErrSceneNotFound
+	}
+
+	e, err := checkbox.New(name, scene, text, x, y, width, height, u.defaultFont, textColor, img, uncheckedSliceName, checkedSliceName, indeterminateSliceName)
+	err = s.AddElement(e)
+	if err != nil {
+		return nil, err
+	}
+	return e, nil
+}
@@ -4,6 +4,8 @@ package common
 type Animation struct {
 	//Counter tracks what animation frame is currently being played
 	Counter int
+	//Elapsed is the game time in seconds the animation has played, Counter is derived from it
+	Elapsed float64
 	//Current sprite name being played
 	CurrentName string
 	//Speed to play animation
@@ -0,0 +1,38 @@
+package common
+
+import "github.com/hajimehoshi/ebiten"
+
+// Camera is a viewport into a layer. X and Y are the top left of the view, Zoom scales it
+type Camera struct {
+	X    float64
+	Y    float64
+	Zoom float64
+}
+
+// NewCamera returns a camera at the origin with no zoom
+func NewCamera() *Camera {
+	return &Camera{Zoom: 1}
+}
+
+// GeoM returns the transform applied when drawing through the camera
+func (c *Camera) GeoM() ebiten.GeoM {
+	g := ebiten.GeoM{}
+	g.Translate(-c.X, -c.Y)
+	zoom := c.Zoom
+	if zoom == 0 {
+		zoom = 1
+	}
+	g.Scale(zoom, zoom)
+	return g
+}
+
+// SetPosition moves the camera
+func (c *Camera) SetPosition(x float64, y float64) {
+	c.X = x
+	c.Y = y
+}
+
+// Position returns the camera position
+func (c *Camera) Position() (float64, float64) {
+	return c.X, c.Y
+}
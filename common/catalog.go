@@ -0,0 +1,368 @@
+package common
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+	"golang.org/x/text/feature/plural"
+	"golang.org/x/text/language"
+	"golang.org/x/text/message"
+	"golang.org/x/text/message/catalog"
+)
+
+// pluralForms are the CLDR plural categories in the order gettext numbers them
+var pluralForms = []string{"zero", "one", "two", "few", "many"}
+
+// Catalog contains translated messages keyed by id. Messages are printf style formats,
+// and may select a plural form from one of their arguments
+type Catalog struct {
+	builder  *catalog.Builder
+	fallback language.Tag
+	keys     map[language.Tag]map[string]bool
+	printers map[language.Tag]*message.Printer
+	forms    map[language.Tag][]string
+}
+
+// NewCatalog returns an empty catalog, falling back to messages of fallback when a language is missing
+func NewCatalog(fallback language.Tag) *Catalog {
+	return &Catalog{
+		builder:  catalog.NewBuilder(catalog.Fallback(fallback)),
+		fallback: fallback,
+		keys:     make(map[language.Tag]map[string]bool),
+		printers: make(map[language.Tag]*message.Printer),
+		forms:    make(map[language.Tag][]string),
+	}
+}
+
+// SetString sets the message for key in a language
+func (c *Catalog) SetString(tag language.Tag, key string, msg string) error {
+	c.addKey(tag, key)
+	return c.builder.SetString(tag, key, msg)
+}
+
+// SetPlural sets a message for key choosing from cases by the plural form of the arg-th argument, starting at 1.
+// Cases are keyed by "zero", "one", "two", "few", "many", "other" or an exact value such as "=0"
+func (c *Catalog) SetPlural(tag language.Tag, key string, arg int, cases map[string]string) error {
+	if arg < 1 {
+		arg = 1
+	}
+	selectors := make([]string, 0, len(cases))
+	for s := range cases {
+		selectors = append(selectors, s)
+	}
+	// exact values are checked before plural forms, and other is always last
+	sort.Slice(selectors, func(i, j int) bool {
+		return selectorRank(selectors[i]) < selectorRank(selectors[j])
+	})
+	args := make([]interface{}, 0, len(cases)*2)
+	for _, s := range selectors {
+		args = append(args, s, cases[s])
+	}
+	c.addKey(tag, key)
+	return c.builder.Set(tag, key, plural.Selectf(arg, "%d", args...))
+}
+
+// addKey records that a language has key
+func (c *Catalog) addKey(tag language.Tag, key string) {
+	keys, ok := c.keys[tag]
+	if !ok {
+		keys = make(map[string]bool)
+		c.keys[tag] = keys
+	}
+	keys[key] = true
+}
+
+// resolve returns the language key is found in, trying tag, its parents, then the fallback
+func (c *Catalog) resolve(tag language.Tag, key string) language.Tag {
+	for t := tag; ; t = t.Parent() {
+		if c.keys[t][key] {
+			return t
+		}
+		if t == language.Und {
+			break
+		}
+	}
+	return c.fallback
+}
+
+// selectorRank orders plural selectors from most to least specific
+func selectorRank(selector string) int {
+	if strings.HasPrefix(selector, "=") || strings.HasPrefix(selector, "<") {
+		return 0
+	}
+	if selector == "other" {
+		return len(pluralForms) + 1
+	}
+	for i, f := range pluralForms {
+		if f == selector {
+			return i + 1
+		}
+	}
+	return len(pluralForms)
+}
+
+// Languages returns every language with messages
+func (c *Catalog) Languages() []language.Tag {
+	return c.builder.Languages()
+}
+
+// Text returns the message for key in a language formatted with args, using the fallback language
+// if it is not translated. Missing keys are formatted as is, so untranslated ids stay visible
+func (c *Catalog) Text(tag language.Tag, key string, args ...interface{}) string {
+	tag = c.resolve(tag, key)
+	p, ok := c.printers[tag]
+	if !ok {
+		p = message.NewPrinter(tag, message.Catalog(c.builder))
+		c.printers[tag] = p
+	}
+	return p.Sprintf(key, args...)
+}
+
+// LoadJSON adds messages of a single language from JSON, e.g.
+//
+//	{
+//		"language": "fr",
+//		"messages": {
+//			"menu.start": "Commencer",
+//			"score": "Score : %d",
+//			"coins": {"arg": 1, "=0": "Aucune pièce", "one": "%d pièce", "other": "%d pièces"}
+//		}
+//	}
+func (c *Catalog) LoadJSON(r io.Reader) error {
+	doc := struct {
+		Language string
+		Messages map[string]json.RawMessage
+	}{}
+	err := json.NewDecoder(r).Decode(&doc)
+	if err != nil {
+		return err
+	}
+	tag, err := language.Parse(doc.Language)
+	if err != nil {
+		return errors.Wrap(err, "language")
+	}
+
+	for key, raw := range doc.Messages {
+		var msg string
+		if json.Unmarshal(raw, &msg) == nil {
+			err = c.SetString(tag, key, msg)
+			if err != nil {
+				return errors.Wrap(err, key)
+			}
+			continue
+		}
+
+		cases := make(map[string]json.RawMessage)
+		err = json.Unmarshal(raw, &cases)
+		if err != nil {
+			return errors.Wrap(err, key)
+		}
+		arg := 1
+		plurals := make(map[string]string)
+		for s, v := range cases {
+			if s == "arg" {
+				err = json.Unmarshal(v, &arg)
+			} else {
+				var m string
+				err = json.Unmarshal(v, &m)
+				plurals[s] = m
+			}
+			if err != nil {
+				return errors.Wrapf(err, "%s %s", key, s)
+			}
+		}
+		err = c.SetPlural(tag, key, arg, plurals)
+		if err != nil {
+			return errors.Wrap(err, key)
+		}
+	}
+	return nil
+}
+
+// LoadPO adds messages of a language from a gettext PO file. msgid is the key, and plural msgstr
+// entries map in order to the plural forms of the language, with the last used for other
+func (c *Catalog) LoadPO(r io.Reader, tag language.Tag) error {
+	entries, err := parsePO(r)
+	if err != nil {
+		return err
+	}
+	for _, e := range entries {
+		// the header entry has an empty id
+		if e.id == "" {
+			continue
+		}
+		if len(e.plurals) == 0 {
+			if e.str == "" {
+				continue
+			}
+			err = c.SetString(tag, e.id, e.str)
+			if err != nil {
+				return errors.Wrap(err, e.id)
+			}
+			continue
+		}
+
+		forms := c.pluralForms(tag)
+		cases := make(map[string]string)
+		for i, str := range e.plurals {
+			if str == "" {
+				continue
+			}
+			if i == len(e.plurals)-1 {
+				cases["other"] = str
+				continue
+			}
+			if i < len(forms) {
+				cases[forms[i]] = str
+			}
+		}
+		if len(cases) == 0 {
+			continue
+		}
+		err = c.SetPlural(tag, e.id, 1, cases)
+		if err != nil {
+			return errors.Wrap(err, e.id)
+		}
+	}
+	return nil
+}
+
+// pluralForms returns the plural forms a language uses besides other, in gettext order
+func (c *Catalog) pluralForms(tag language.Tag) []string {
+	forms, ok := c.forms[tag]
+	if ok {
+		return forms
+	}
+	// plural rules are not exported, so each form is probed against a scratch catalog
+	probe := catalog.NewBuilder()
+	for _, f := range pluralForms {
+		err := probe.Set(tag, f, plural.Selectf(1, "%d", f, "", "other", ""))
+		if err == nil {
+			forms = append(forms, f)
+		}
+	}
+	c.forms[tag] = forms
+	return forms
+}
+
+// poEntry is a message of a PO file
+type poEntry struct {
+	id      string
+	str     string
+	plurals []string
+}
+
+// parsePO reads msgid, msgid_plural, msgstr and msgstr[n] entries, ignoring comments and contexts
+func parsePO(r io.Reader) ([]*poEntry, error) {
+	var entries []*poEntry
+	var e *poEntry
+	// target is the string continuation lines append to
+	var target *string
+
+	s := bufio.NewScanner(r)
+	lineNumber := 0
+	for s.Scan() {
+		lineNumber++
+		line := strings.TrimSpace(s.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		if strings.HasPrefix(line, `"`) {
+			if target == nil {
+				return nil, fmt.Errorf("line %d: string without keyword", lineNumber)
+			}
+			v, err := strconv.Unquote(line)
+			if err != nil {
+				return nil, errors.Wrapf(err, "line %d", lineNumber)
+			}
+			*target += v
+			continue
+		}
+
+		i := strings.IndexAny(line, " \t")
+		if i < 0 {
+			return nil, fmt.Errorf("line %d: keyword without string", lineNumber)
+		}
+		keyword := line[:i]
+		v, err := strconv.Unquote(strings.TrimSpace(line[i:]))
+		if err != nil {
+			return nil, errors.Wrapf(err, "line %d", lineNumber)
+		}
+
+		switch {
+		case keyword == "msgctxt":
+			target = new(string)
+		case keyword == "msgid":
+			e = &poEntry{id: v}
+			entries = append(entries, e)
+			target = &e.id
+		case e == nil:
+			return nil, fmt.Errorf("line %d: %s before msgid", lineNumber, keyword)
+		case keyword == "msgid_plural":
+			target = new(string)
+		case keyword == "msgstr":
+			e.str = v
+			target = &e.str
+		case strings.HasPrefix(keyword, "msgstr["):
+			n, err := strconv.Atoi(strings.TrimSuffix(strings.TrimPrefix(keyword, "msgstr["), "]"))
+			if err != nil || n < 0 {
+				return nil, fmt.Errorf("line %d: invalid %s", lineNumber, keyword)
+			}
+			for len(e.plurals) <= n {
+				e.plurals = append(e.plurals, "")
+			}
+			e.plurals[n] = v
+			target = &e.plurals[n]
+		default:
+			return nil, fmt.Errorf("line %d: unknown keyword %s", lineNumber, keyword)
+		}
+	}
+	return entries, s.Err()
+}
+
+// PseudoLocalize accents letters and pads text by about a third, wrapped in brackets,
+// to find untranslated strings and text that truncates when translated. Rich text tags are kept
+func PseudoLocalize(text string) string {
+	var sb strings.Builder
+	sb.WriteString("[")
+	letters := 0
+	isTag := false
+	for _, r := range text {
+		switch {
+		case r == '[':
+			isTag = true
+		case r == ']':
+			isTag = false
+		case isTag:
+		default:
+			p, ok := pseudoRunes[r]
+			if ok {
+				r = p
+			}
+			if r != ' ' && r != '\n' {
+				letters++
+			}
+		}
+		sb.WriteRune(r)
+	}
+	sb.WriteString(" ")
+	sb.WriteString(strings.Repeat("~", (letters+2)/3))
+	sb.WriteString("]")
+	return sb.String()
+}
+
+var pseudoRunes = map[rune]rune{
+	'a': 'á', 'b': 'ƀ', 'c': 'ç', 'd': 'ð', 'e': 'é', 'f': 'ƒ', 'g': 'ĝ', 'h': 'ĥ', 'i': 'í', 'j': 'ĵ',
+	'k': 'ķ', 'l': 'ļ', 'm': 'ɱ', 'n': 'ñ', 'o': 'ó', 'p': 'þ', 'q': 'ǫ', 'r': 'ŕ', 's': 'š', 't': 'ţ',
+	'u': 'ú', 'v': 'ṽ', 'w': 'ŵ', 'x': 'ẋ', 'y': 'ý', 'z': 'ž',
+	'A': 'Á', 'B': 'Ɓ', 'C': 'Ç', 'D': 'Ð', 'E': 'É', 'F': 'Ƒ', 'G': 'Ĝ', 'H': 'Ĥ', 'I': 'Í', 'J': 'Ĵ',
+	'K': 'Ķ', 'L': 'Ļ', 'M': 'Ṁ', 'N': 'Ñ', 'O': 'Ó', 'P': 'Þ', 'Q': 'Ǫ', 'R': 'Ŕ', 'S': 'Š', 'T': 'Ţ',
+	'U': 'Ú', 'V': 'Ṽ', 'W': 'Ŵ', 'X': 'Ẋ', 'Y': 'Ý', 'Z': 'Ž',
+}
@@ -0,0 +1,83 @@
+package common
+
+import (
+	"strings"
+	"testing"
+
+	"golang.org/x/text/language"
+)
+
+func TestCatalogPlural(t *testing.T) {
+	c := NewCatalog(language.English)
+	err := c.SetPlural(language.English, "coins", 1, map[string]string{
+		"=0":    "no coins",
+		"one":   "%d coin",
+		"other": "%d coins",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = c.SetPlural(language.Russian, "coins", 1, map[string]string{
+		"one":   "%d монета",
+		"few":   "%d монеты",
+		"many":  "%d монет",
+		"other": "%d монеты",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		tag   language.Tag
+		count int
+		want  string
+	}{
+		{language.English, 0, "no coins"},
+		{language.English, 1, "1 coin"},
+		{language.English, 2, "2 coins"},
+		{language.Russian, 1, "1 монета"},
+		{language.Russian, 3, "3 монеты"},
+		{language.Russian, 5, "5 монет"},
+		{language.Russian, 21, "21 монета"},
+		// languages without the key use the fallback
+		{language.French, 2, "2 coins"},
+	}
+	for _, tt := range tests {
+		got := c.Text(tt.tag, "coins", tt.count)
+		if got != tt.want {
+			t.Errorf("%s %d: %q, want %q", tt.tag, tt.count, got, tt.want)
+		}
+	}
+}
+
+func TestCatalogLoadPOPlural(t *testing.T) {
+	po := `msgid ""
+msgstr "Plural-Forms: nplurals=3; plural=(n%10==1 && n%100!=11 ? 0 : n%10>=2 && n%10<=4 && (n%100<10 || n%100>=20) ? 1 : 2);\n"
+
+msgid "%d file"
+msgid_plural "%d files"
+msgstr[0] "%d plik"
+msgstr[1] "%d pliki"
+msgstr[2] "%d plików"
+`
+	c := NewCatalog(language.English)
+	err := c.LoadPO(strings.NewReader(po), language.Polish)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		count int
+		want  string
+	}{
+		{1, "1 plik"},
+		{2, "2 pliki"},
+		{5, "5 plików"},
+		{22, "22 pliki"},
+	}
+	for _, tt := range tests {
+		got := c.Text(language.Polish, "%d file", tt.count)
+		if got != tt.want {
+			t.Errorf("%d: %q, want %q", tt.count, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,32 @@
+package common
+
+// CheckState is the state of a checkbox or toggle
+type CheckState int
+
+const (
+	// CheckUnchecked is off
+	CheckUnchecked = CheckState(0)
+	// CheckChecked is on
+	CheckChecked = CheckState(1)
+	// CheckIndeterminate is neither, e.g. a parent of partly checked options
+	CheckIndeterminate = CheckState(2)
+)
+
+func (s CheckState) String() string {
+	switch s {
+	case CheckChecked:
+		return "checked"
+	case CheckIndeterminate:
+		return "indeterminate"
+	default:
+		return "unchecked"
+	}
+}
+
+// Toggled returns the state after activating, indeterminate becomes checked
+func (s CheckState) Toggled() CheckState {
+	if s == CheckChecked {
+		return CheckUnchecked
+	}
+	return CheckChecked
+}
@@ -0,0 +1,24 @@
+package common
+
+// Clipboard reads and writes text for cut, copy and paste. Platforms without a system
+// clipboard, or games wanting one, can supply their own
+type Clipboard interface {
+	ReadText() (string, error)
+	WriteText(text string) error
+}
+
+// MemoryClipboard is a clipboard shared only within the process, the default clipboard
+type MemoryClipboard struct {
+	text string
+}
+
+// ReadText returns the last written text
+func (c *MemoryClipboard) ReadText() (string, error) {
+	return c.text, nil
+}
+
+// WriteText stores text
+func (c *MemoryClipboard) WriteText(text string) error {
+	c.text = text
+	return nil
+}
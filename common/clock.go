@@ -0,0 +1,74 @@
+package common
+
+import "time"
+
+// Clock tracks game time. It only moves when advanced, so it can be paused,
+// scaled for slow motion and stepped deterministically in tests
+type Clock struct {
+	now       float64
+	frame     uint64
+	timeScale float64
+	isPaused  bool
+}
+
+// NewClock returns a clock at zero running at normal speed
+func NewClock() *Clock {
+	return &Clock{
+		timeScale: 1,
+	}
+}
+
+// Advance moves the clock forward by dt seconds and returns the scaled dt that was applied.
+// A paused clock returns 0
+func (c *Clock) Advance(dt float64) float64 {
+	c.frame++
+	if c.isPaused || dt <= 0 {
+		return 0
+	}
+	dt *= c.timeScale
+	c.now += dt
+	return dt
+}
+
+// Now returns how much game time has passed
+func (c *Clock) Now() time.Duration {
+	return time.Duration(c.now * float64(time.Second))
+}
+
+// Seconds returns how much game time has passed in seconds
+func (c *Clock) Seconds() float64 {
+	return c.now
+}
+
+// Since returns how much game time has passed since t
+func (c *Clock) Since(t time.Duration) time.Duration {
+	return c.Now() - t
+}
+
+// Frame returns how many times the clock has been advanced
+func (c *Clock) Frame() uint64 {
+	return c.frame
+}
+
+// IsPaused returns true if the clock is paused
+func (c *Clock) IsPaused() bool {
+	return c.isPaused
+}
+
+// SetPaused pauses or resumes the clock
+func (c *Clock) SetPaused(isPaused bool) {
+	c.isPaused = isPaused
+}
+
+// TimeScale returns the speed of the clock, 1 is normal speed
+func (c *Clock) TimeScale() float64 {
+	return c.timeScale
+}
+
+// SetTimeScale sets the speed of the clock, e.g. 0.5 for slow motion. floor(0)
+func (c *Clock) SetTimeScale(timeScale float64) {
+	if timeScale < 0 {
+		timeScale = 0
+	}
+	c.timeScale = timeScale
+}
@@ -0,0 +1,51 @@
+package common
+
+import (
+	"testing"
+	"time"
+)
+
+func TestClockAdvance(t *testing.T) {
+	tests := []struct {
+		name      string
+		isPaused  bool
+		timeScale float64
+		steps     []float64
+		applied   float64
+		now       time.Duration
+	}{
+		{"normal", false, 1, []float64{0.5, 0.25}, 0.25, 750 * time.Millisecond},
+		{"slow motion", false, 0.5, []float64{1, 1}, 0.5, time.Second},
+		{"paused", true, 1, []float64{1, 1}, 0, 0},
+		{"negative scale stops", false, -2, []float64{1}, 0, 0},
+		{"negative dt ignored", false, 1, []float64{1, -1}, 0, time.Second},
+	}
+	for _, tt := range tests {
+		c := NewClock()
+		c.SetPaused(tt.isPaused)
+		c.SetTimeScale(tt.timeScale)
+		applied := 0.0
+		for _, dt := range tt.steps {
+			applied = c.Advance(dt)
+		}
+		if applied != tt.applied {
+			t.Errorf("%s: last advance %v, want %v", tt.name, applied, tt.applied)
+		}
+		if c.Now() != tt.now {
+			t.Errorf("%s: now %v, want %v", tt.name, c.Now(), tt.now)
+		}
+		if c.Frame() != uint64(len(tt.steps)) {
+			t.Errorf("%s: frame %d, want %d", tt.name, c.Frame(), len(tt.steps))
+		}
+	}
+}
+
+func TestClockSince(t *testing.T) {
+	c := NewClock()
+	c.Advance(1)
+	start := c.Now()
+	c.Advance(0.5)
+	if since := c.Since(start); since != 500*time.Millisecond {
+		t.Errorf("since %v, want 500ms", since)
+	}
+}
@@ -17,3 +17,12 @@ func ColorToScale(clr color.Color) (float64, float64, float64, float64) {
 	}
 	return rf, gf, bf, af
 }
+
+// DisabledColor returns clr in greyscale at half brightness, matching the ColorM disabled elements draw with
+func DisabledColor(clr color.Color) color.Color {
+	r, g, b, a := clr.RGBA()
+	// luma weights, as ColorM.ChangeHSV uses for saturation
+	y := (0.299*float64(r) + 0.587*float64(g) + 0.114*float64(b)) / 2
+	v := uint16(y)
+	return color.RGBA64{R: v, G: v, B: v, A: uint16(a)}
+}
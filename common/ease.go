@@ -0,0 +1,131 @@
+package common
+
+import "math"
+
+// EaseFunc maps linear progress t, 0 to 1, to eased progress
+type EaseFunc func(t float64) float64
+
+const (
+	easeBack      = 1.70158
+	easeBackIO    = easeBack * 1.525
+	easeElastic   = (2 * math.Pi) / 3
+	easeElasticIO = (2 * math.Pi) / 4.5
+)
+
+// EaseLinear has no easing
+func EaseLinear(t float64) float64 {
+	return t
+}
+
+// EaseInQuad accelerates from zero velocity
+func EaseInQuad(t float64) float64 {
+	return t * t
+}
+
+// EaseOutQuad decelerates to zero velocity
+func EaseOutQuad(t float64) float64 {
+	return 1 - (1-t)*(1-t)
+}
+
+// EaseInOutQuad accelerates until halfway, then decelerates
+func EaseInOutQuad(t float64) float64 {
+	if t < 0.5 {
+		return 2 * t * t
+	}
+	return 1 - math.Pow(-2*t+2, 2)/2
+}
+
+// EaseInCubic accelerates from zero velocity
+func EaseInCubic(t float64) float64 {
+	return t * t * t
+}
+
+// EaseOutCubic decelerates to zero velocity
+func EaseOutCubic(t float64) float64 {
+	return 1 - math.Pow(1-t, 3)
+}
+
+// EaseInOutCubic accelerates until halfway, then decelerates
+func EaseInOutCubic(t float64) float64 {
+	if t < 0.5 {
+		return 4 * t * t * t
+	}
+	return 1 - math.Pow(-2*t+2, 3)/2
+}
+
+// EaseInBack pulls back slightly before moving forward
+func EaseInBack(t float64) float64 {
+	return (easeBack+1)*t*t*t - easeBack*t*t
+}
+
+// EaseOutBack overshoots the end slightly before settling
+func EaseOutBack(t float64) float64 {
+	return 1 + (easeBack+1)*math.Pow(t-1, 3) + easeBack*math.Pow(t-1, 2)
+}
+
+// EaseInOutBack pulls back at the start and overshoots the end
+func EaseInOutBack(t float64) float64 {
+	if t < 0.5 {
+		return (math.Pow(2*t, 2) * ((easeBackIO+1)*2*t - easeBackIO)) / 2
+	}
+	return (math.Pow(2*t-2, 2)*((easeBackIO+1)*(t*2-2)+easeBackIO) + 2) / 2
+}
+
+// EaseInElastic winds up like a spring before moving
+func EaseInElastic(t float64) float64 {
+	if t == 0 || t == 1 {
+		return t
+	}
+	return -math.Pow(2, 10*t-10) * math.Sin((t*10-10.75)*easeElastic)
+}
+
+// EaseOutElastic springs past the end and oscillates into place
+func EaseOutElastic(t float64) float64 {
+	if t == 0 || t == 1 {
+		return t
+	}
+	return math.Pow(2, -10*t)*math.Sin((t*10-0.75)*easeElastic) + 1
+}
+
+// EaseInOutElastic winds up at the start and oscillates at the end
+func EaseInOutElastic(t float64) float64 {
+	if t == 0 || t == 1 {
+		return t
+	}
+	if t < 0.5 {
+		return -(math.Pow(2, 20*t-10) * math.Sin((20*t-11.125)*easeElasticIO)) / 2
+	}
+	return (math.Pow(2, -20*t+10)*math.Sin((20*t-11.125)*easeElasticIO))/2 + 1
+}
+
+// EaseInBounce bounces at the start
+func EaseInBounce(t float64) float64 {
+	return 1 - EaseOutBounce(1-t)
+}
+
+// EaseOutBounce bounces at the end like a dropped ball
+func EaseOutBounce(t float64) float64 {
+	const n = 7.5625
+	const d = 2.75
+	switch {
+	case t < 1/d:
+		return n * t * t
+	case t < 2/d:
+		t -= 1.5 / d
+		return n*t*t + 0.75
+	case t < 2.5/d:
+		t -= 2.25 / d
+		return n*t*t + 0.9375
+	default:
+		t -= 2.625 / d
+		return n*t*t + 0.984375
+	}
+}
+
+// EaseInOutBounce bounces at the start and the end
+func EaseInOutBounce(t float64) float64 {
+	if t < 0.5 {
+		return (1 - EaseOutBounce(1-2*t)) / 2
+	}
+	return (1 + EaseOutBounce(2*t-1)) / 2
+}
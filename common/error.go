@@ -11,6 +11,10 @@ var (
 	ErrElementAlreadyExists = fmt.Errorf("element already exists")
 	// ErrElementNotFound is returned when a element is not loaded into the UI
 	ErrElementNotFound = fmt.Errorf("element not found")
+	// ErrElementNoBounds is returned when an element has no position or size to lay out or scroll to
+	ErrElementNoBounds = fmt.Errorf("element has no position or size")
+	// ErrRowHeightInvalid is returned when a list has no row height and its source does not size rows
+	ErrRowHeightInvalid = fmt.Errorf("row height invalid")
 	// ErrFontNameInvalid is returned when a font name has invalid characters or too short
 	ErrFontNameInvalid = fmt.Errorf("font name invalid")
 	// ErrFontAlreadyExists is returned when a font already exists
@@ -19,16 +23,30 @@ var (
 	ErrFontNotFound = fmt.Errorf("font not found")
 	// ErrFontCannotRemoveDefault is returned when you attempt to delete a font currently set as default
 	ErrFontCannotRemoveDefault = fmt.Errorf("font is default, cannot remove")
+	// ErrFontIndexOutOfRange is returned when a font collection does not contain the requested index
+	ErrFontIndexOutOfRange = fmt.Errorf("font index out of range")
+	// ErrColorInvalid is returned when a color is not in #rgb, #rrggbb or #rrggbbaa form
+	ErrColorInvalid = fmt.Errorf("color invalid")
+	// ErrFontNotScalable is returned when a font is not backed by TTF or OTF data and cannot be resized
+	ErrFontNotScalable = fmt.Errorf("font is not scalable")
 	// ErrImageNameInvalid is returned when a image name has invalid characters or too short
 	ErrImageNameInvalid = fmt.Errorf("image name invalid")
 	// ErrImageAlreadyExists is returned when a image already exists
 	ErrImageAlreadyExists = fmt.Errorf("image already exists")
 	// ErrImageNotFound is returned when a image was not found
 	ErrImageNotFound = fmt.Errorf("image not found")
+	// ErrLayerNameInvalid is returned when a layer name has invalid characters or too short
+	ErrLayerNameInvalid = fmt.Errorf("layer name invalid")
+	// ErrLayerAlreadyExists is returned when a layer already exists
+	ErrLayerAlreadyExists = fmt.Errorf("layer already exists")
+	// ErrLayerNotFound is returned when a layer is not part of a scene
+	ErrLayerNotFound = fmt.Errorf("layer not found")
 	// ErrSceneNameInvalid is returned when a scene name has invalid characters or too short
 	ErrSceneNameInvalid = fmt.Errorf("scene name invalid")
 	// ErrSceneAlreadyExists is returned when a Scene already exists
 	ErrSceneAlreadyExists = fmt.Errorf("scene already exists")
 	// ErrSceneNotFound is returned when a scene is not loaded into the UI
 	ErrSceneNotFound = fmt.Errorf("scene not found")
+	// ErrSceneCannotRemoveGlobal is returned when you attempt to remove the global scene
+	ErrSceneCannotRemoveGlobal = fmt.Errorf("scene is global, cannot remove")
 )
@@ -2,9 +2,14 @@ package common
 
 import (
 	"image/color"
+	"strings"
 
+	gotext "github.com/go-text/typesetting/font"
+	"github.com/go-text/typesetting/shaping"
 	"github.com/hajimehoshi/ebiten"
 	"golang.org/x/image/font"
+	"golang.org/x/image/font/opentype"
+	"golang.org/x/image/font/sfnt"
 	"golang.org/x/image/math/fixed"
 	"golang.org/x/text/language"
 )
@@ -15,93 +20,428 @@ type Font struct {
 	Height              int
 	Name                string
 	Language            language.Tag
-	BoundStringCache    map[font.Face]map[string]*BoundStringCacheEntry
 	RenderingLineHeight int
+	// Options are the unscaled face options the font was created with
+	Options FaceOptions
+	source  *FontSource
+	scale   float64
+	sizes   map[float64]*Font
+	// fallbacks draw runes the face has no glyph for
+	fallbacks    []*Font
+	shaper       *shaping.HarfbuzzShaper
+	segmenter    *shaping.Segmenter
+	shapingFaces map[*gotext.Face]*Font
+	glyphs       map[gotext.GID]*glyphImage
+	// cache holds measured and shaped lines of the current face
+	cache *textCache
+	// revision counts changes to the face and fallbacks
+	revision int
 }
 
-// BoundStringCacheEntry is used for font boundings
-type BoundStringCacheEntry struct {
-	bounds  *fixed.Rectangle26_6
-	advance fixed.Int26_6
+// FaceOptions describe how a face is rasterized
+type FaceOptions struct {
+	Size    float64
+	DPI     float64
+	Hinting font.Hinting
 }
 
-/*
-// MeasureSize returns the size of provided text
+// DefaultFaceOptions returns 12pt at 72 DPI with full hinting
+func DefaultFaceOptions() FaceOptions {
+	return FaceOptions{Size: 12, DPI: 72, Hinting: font.HintingFull}
+}
+
+// FontSource is parsed TTF or OTF data that creates faces at any size.
+// Faces are cached per size, DPI and hinting
+type FontSource struct {
+	font  *opentype.Font
+	faces map[FaceOptions]font.Face
+	data  []byte
+	index int
+	buf   sfnt.Buffer
+	// shaping is parsed from data the first time text needs shaping
+	shaping         *gotext.Face
+	isShapingParsed bool
+}
+
+// ParseFontSource parses TTF or OTF data. For font collections (TTC/OTC), index selects which font to use
+func ParseFontSource(data []byte, index int) (*FontSource, error) {
+	c, err := opentype.ParseCollection(data)
+	if err != nil {
+		return nil, err
+	}
+	if index < 0 || index >= c.NumFonts() {
+		return nil, ErrFontIndexOutOfRange
+	}
+	f, err := c.Font(index)
+	if err != nil {
+		return nil, err
+	}
+	return &FontSource{
+		font:  f,
+		faces: make(map[FaceOptions]font.Face),
+		data:  data,
+		index: index,
+	}, nil
+}
+
+// Face returns a cached face, creating it if needed
+func (fs *FontSource) Face(opts FaceOptions) (font.Face, error) {
+	face, ok := fs.faces[opts]
+	if ok {
+		return face, nil
+	}
+	face, err := opentype.NewFace(fs.font, &opentype.FaceOptions{Size: opts.Size, DPI: opts.DPI, Hinting: opts.Hinting})
+	if err != nil {
+		return nil, err
+	}
+	fs.faces[opts] = face
+	return face, nil
+}
+
+// NewFont creates a font from a source
+func NewFont(name string, source *FontSource, opts FaceOptions) (*Font, error) {
+	if opts.DPI == 0 {
+		opts.DPI = 72
+	}
+	f := &Font{
+		Name:    name,
+		Options: opts,
+		source:  source,
+		scale:   1,
+		sizes:   make(map[float64]*Font),
+		cache:   newTextCache(DefaultTextCacheLimit),
+	}
+	err := f.applyScale()
+	if err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+// NewFontFromFace wraps an existing face, e.g. a bitmap font. Faces that are not
+// created from a FontSource cannot be resized or rescaled
+func NewFontFromFace(name string, face font.Face) *Font {
+	f := &Font{
+		Name:  name,
+		Face:  face,
+		scale: 1,
+		sizes: make(map[float64]*Font),
+		cache: newTextCache(DefaultTextCacheLimit),
+	}
+	f.calibrate()
+	return f
+}
+
+// Size returns the font at size points, sharing the same source and scale.
+// Sized fonts are cached, so requesting the same size twice returns the same font
+func (f *Font) Size(size float64) (*Font, error) {
+	if f.Options.Size == size {
+		return f, nil
+	}
+	sf, ok := f.sizes[size]
+	if ok {
+		return sf, nil
+	}
+	if f.source == nil {
+		return nil, ErrFontNotScalable
+	}
+	opts := f.Options
+	opts.Size = size
+	sf, err := NewFont(f.Name, f.source, opts)
+	if err != nil {
+		return nil, err
+	}
+	sf.Language = f.Language
+	sf.fallbacks = sizedFallbacks(f.fallbacks, size)
+	if f.scale != 1 {
+		err = sf.SetScale(f.scale)
+		if err != nil {
+			return nil, err
+		}
+	}
+	f.sizes[size] = sf
+	return sf, nil
+}
+
+// Scale returns the scale faces are rasterized at
+func (f *Font) Scale() float64 {
+	return f.scale
+}
+
+// SetScale rasterizes the font and all of its sizes at scale, e.g. when the UI scale changes
+func (f *Font) SetScale(scale float64) error {
+	if scale <= 0 {
+		scale = 1
+	}
+	if f.scale == scale {
+		return nil
+	}
+	f.scale = scale
+	if f.source != nil {
+		err := f.applyScale()
+		if err != nil {
+			return err
+		}
+	}
+	for _, sf := range f.sizes {
+		err := sf.SetScale(scale)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// applyScale swaps the face to the current scale
+func (f *Font) applyScale() error {
+	opts := f.Options
+	opts.Size *= f.scale
+	face, err := f.source.Face(opts)
+	if err != nil {
+		return err
+	}
+	f.Face = face
+	f.revision++
+	f.calibrate()
+	f.clearGlyphs()
+	f.cache.clear()
+	return nil
+}
+
+// Revision returns a count that changes whenever the font or one of its fallbacks is rescaled,
+// or its fallbacks are set, so callers can tell when text they rendered looks different
+func (f *Font) Revision() int {
+	revision := f.revision
+	for _, fb := range f.fallbacks {
+		revision += fb.revision
+	}
+	return revision
+}
+
+// calibrate measures height and line height from face metrics
+func (f *Font) calibrate() {
+	m := f.Face.Metrics()
+	f.Height = (m.Ascent + m.Descent).Ceil()
+	f.RenderingLineHeight = m.Height.Ceil()
+	if f.RenderingLineHeight < f.Height {
+		f.RenderingLineHeight = f.Height
+	}
+}
+
+// MeasureString returns the advance width and bounds of a single line. Results are cached
+func (f *Font) MeasureString(str string) (fixed.Rectangle26_6, fixed.Int26_6) {
+	e := f.cache.entry(str)
+	f.cache.record(e.isMeasured)
+	if !e.isMeasured {
+		e.bounds, e.advance = font.BoundString(f.Face, str)
+		e.isMeasured = true
+	}
+	return e.bounds, e.advance
+}
+
+// MeasureSize returns the size of provided text, which may contain line breaks
 func (f *Font) MeasureSize(text string) (int, int) {
-	w := fixed.I(0)
-	h := fixed.I(0)
-	for _, l := range strings.Split(strings.TrimRight(text, "\n"), "\n") {
-		b, _ := f.boundString(l)
-		nw := b.Max.X - b.Min.X
-		if nw > w {
-			w = nw
-		}
-		h += fixed.I(f.renderingLineHeight)
-	}
-	return w.Ceil(), h.Ceil()
-}
-*/
-
-// DrawText draws a text
-func (f *Font) DrawText(dst *ebiten.Image, str string, ox, oy float64, scale float64, textAlign int, color color.Color, displayTextRuneCount int) {
-	//f := face(scale, lang)
-	/*
-		m := f.Metrics()
-		oy += (RenderingLineHeight*scale - m.Height.Round()) / 2
-
-		b, _, _ := f.GlyphBounds('.')
-		dotX := (-b.Min.X).Floor()
-
-		str = strings.Replace(str, "\r\n", "\n", -1)
-		lines := strings.Split(str, "\n")
-		linesToShow := strings.Split(string([]rune(str)[:displayTextRuneCount]), "\n")
-
-		for i, l := range linesToShow {
-			x := ox + dotX
-			y := oy + mplusDotY*scale
-			_, a := boundString(f, lines[i])
-			switch textAlign {
-			case data.TextAlignLeft:
-				// do nothing
-			case data.TextAlignCenter:
-				x -= a.Ceil() / 2
-			case data.TextAlignRight:
-				x -= a.Ceil()
-			default:
-				panic(fmt.Sprintf("font: invalid text align: %d", textAlign))
-			}
+	w := 0
+	lines := strings.Split(strings.TrimRight(text, "\n"), "\n")
+	for _, l := range lines {
+		lw := f.advance(l)
+		if lw > w {
+			w = lw
+		}
+	}
+	return w, len(lines) * f.RenderingLineHeight
+}
 
-			text.Draw(d, l, f, x, y, color)
-			oy += RenderingLineHeight * scale
+// DrawText draws text at x, y, the top left of the layout box.
+// Only the first displayTextRuneCount runes are drawn, line breaks excluded. A negative count draws everything
+func (f *Font) DrawText(dst *ebiten.Image, str string, x, y float64, layout *TextLayout, clr color.Color, displayTextRuneCount int) {
+	if layout == nil {
+		layout = &TextLayout{}
+	}
+	lines := f.LayoutText(str, layout)
+	if len(lines) == 0 {
+		return
+	}
+
+	m := f.Face.Metrics()
+	lineHeight := float64(f.RenderingLineHeight)
+	y += layout.alignY(lineHeight * float64(len(lines)))
+	// center the glyph height within the line, then move to the baseline
+	y += (lineHeight-float64(f.Height))/2 + float64(m.Ascent.Ceil())
+
+	remaining := displayTextRuneCount
+	for _, l := range lines {
+		if remaining == 0 {
+			return
+		}
+
+		lx := x
+		if layout.Align != TextAlignLeft {
+			// alignment is measured on the full line so revealed text does not shift
+			lx += layout.alignX(float64(f.advance(l)))
 		}
-	*/
+
+		if remaining > 0 {
+			runes := []rune(l)
+			if len(runes) > remaining {
+				runes = runes[:remaining]
+			}
+			remaining -= len(runes)
+			l = string(runes)
+		}
+		f.drawLine(dst, l, int(lx), int(y), clr)
+		y += lineHeight
+	}
 }
 
-/*
-func (f *Font) boundString(str string) (*fixed.Rectangle26_6, fixed.Int26_6) {
-	m, ok := f.boundStringCache[face]
-	if !ok {
-		m = map[string]*boundStringCacheEntry{}
-		f.boundStringCache[face] = m
+// LayoutText splits str into the lines DrawText renders for layout, applying wrapping,
+// then trimming lines past the box height and ellipsizing
+func (f *Font) LayoutText(str string, layout *TextLayout) []string {
+	if str == "" {
+		return nil
+	}
+	if layout == nil {
+		layout = &TextLayout{}
+	}
+	width := int(layout.Width)
+
+	var lines []string
+	for _, l := range strings.Split(str, "\n") {
+		if layout.IsWrapped && width > 0 {
+			lines = append(lines, f.WrapText(l, width)...)
+			continue
+		}
+		lines = append(lines, l)
 	}
 
-	entry, ok := m[str]
-	if !ok {
-		// Delete all entries if the capacity exceeds the limit.
-		if len(m) >= 256 {
-			for k := range m {
-				delete(m, k)
+	if layout.Height > 0 && f.RenderingLineHeight > 0 {
+		maxLines := int(layout.Height) / f.RenderingLineHeight
+		if maxLines < 1 {
+			maxLines = 1
+		}
+		if len(lines) > maxLines {
+			lines = lines[:maxLines]
+			if layout.IsEllipsis && width > 0 {
+				// force an ellipsis on the last visible line, as text was cut
+				lines[maxLines-1] = f.Ellipsis(lines[maxLines-1]+ellipsis, width)
 			}
 		}
+	}
+
+	if layout.IsEllipsis && width > 0 {
+		for i, l := range lines {
+			lines[i] = f.Ellipsis(l, width)
+		}
+	}
+	return lines
+}
+
+// WrapText breaks a single line into lines no wider than width pixels.
+// Lines break at spaces, words wider than width are broken between runes
+func (f *Font) WrapText(line string, width int) []string {
+	if f.advance(line) <= width {
+		return []string{line}
+	}
+
+	var lines []string
+	current := ""
+	for _, word := range strings.Split(line, " ") {
+		candidate := word
+		if current != "" {
+			candidate = current + " " + word
+		}
+		if f.advance(candidate) <= width {
+			current = candidate
+			continue
+		}
+		if current != "" {
+			lines = append(lines, current)
+			current = ""
+		}
+		for f.advance(word) > width {
+			n := f.fitRunes(word, width)
+			runes := []rune(word)
+			lines = append(lines, string(runes[:n]))
+			word = string(runes[n:])
+		}
+		current = word
+	}
+	return append(lines, current)
+}
+
+// WrapBreaks returns the rune offsets where lines start when a single line is wrapped to width pixels,
+// excluding the first line. Unlike WrapText, spaces stay at the end of lines, so every rune keeps its offset
+func (f *Font) WrapBreaks(line string, width int) []int {
+	if f.advance(line) <= width {
+		return nil
+	}
+	return wrapBreaks([]rune(line), width, f.advance)
+}
 
-		b, a := font.BoundString(face, str)
-		entry = &boundStringCacheEntry{
-			bounds:  &b,
-			advance: a,
+// wrapBreaks breaks runes at spaces, or between runes for words wider than width
+func wrapBreaks(runes []rune, width int, measure func(string) int) []int {
+	var breaks []int
+	start := 0
+	i := 0
+	for i < len(runes) {
+		wordEnd := i
+		for wordEnd < len(runes) && runes[wordEnd] != ' ' {
+			wordEnd++
+		}
+		next := wordEnd
+		for next < len(runes) && runes[next] == ' ' {
+			next++
 		}
-		m[str] = entry
+		if measure(string(runes[start:wordEnd])) <= width {
+			i = next
+			continue
+		}
+		if start < i {
+			// move the word to a new line and measure it again
+			breaks = append(breaks, i)
+			start = i
+			continue
+		}
+		for measure(string(runes[start:wordEnd])) > width {
+			k := start + 1
+			for k < wordEnd && measure(string(runes[start:k+1])) <= width {
+				k++
+			}
+			if k >= len(runes) {
+				break
+			}
+			breaks = append(breaks, k)
+			start = k
+		}
+		i = next
+	}
+	return breaks
+}
+
+// Ellipsis shortens a single line to fit width pixels, ending it with an ellipsis if cut
+func (f *Font) Ellipsis(line string, width int) string {
+	if f.advance(line) <= width {
+		return line
+	}
+	line = strings.TrimSuffix(line, ellipsis)
+	room := width - f.advance(ellipsis)
+	if room <= 0 {
+		return ""
 	}
+	runes := []rune(line)
+	n := f.fitRunes(line, room)
+	return strings.TrimRight(string(runes[:n]), " ") + ellipsis
+}
 
-	return entry.bounds, entry.advance
+// fitRunes returns how many leading runes of line fit in width pixels, at least one
+func (f *Font) fitRunes(line string, width int) int {
+	runes := []rune(line)
+	if len(runes) == 0 {
+		return 0
+	}
+	n := 1
+	for n < len(runes) && f.advance(string(runes[:n+1])) <= width {
+		n++
+	}
+	return n
 }
-*/
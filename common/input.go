@@ -0,0 +1,268 @@
+package common
+
+import (
+	"math"
+
+	"github.com/hajimehoshi/ebiten"
+	"github.com/hajimehoshi/ebiten/inpututil"
+)
+
+// Default key repeat timing, in seconds
+const (
+	DefaultKeyRepeatDelay    = 0.4
+	DefaultKeyRepeatInterval = 0.04
+)
+
+// KeyRepeat fires held keys once when pressed, then repeatedly after a delay.
+// Repeats are timed in seconds rather than frames, so they run at the same rate at any frame rate
+type KeyRepeat struct {
+	Delay    float64
+	Interval float64
+	// held is how long each key or other input has been held
+	held map[interface{}]float64
+}
+
+// NewKeyRepeat returns a key repeat with the default delay and interval
+func NewKeyRepeat() *KeyRepeat {
+	return &KeyRepeat{
+		Delay:    DefaultKeyRepeatDelay,
+		Interval: DefaultKeyRepeatInterval,
+		held:     make(map[interface{}]float64),
+	}
+}
+
+// Count returns how many times key fires during an update of dt seconds. Call it once per key each update
+func (kr *KeyRepeat) Count(key ebiten.Key, dt float64) int {
+	return kr.CountHeld(key, IsKeyPressed(key), dt)
+}
+
+// CountHeld is Count for any other input, e.g. a gamepad axis past a threshold. id tells inputs apart
+func (kr *KeyRepeat) CountHeld(id interface{}, isHeld bool, dt float64) int {
+	if !isHeld {
+		delete(kr.held, id)
+		return 0
+	}
+	elapsed, ok := kr.held[id]
+	if !ok {
+		kr.held[id] = 0
+		return 1
+	}
+	kr.held[id] = elapsed + dt
+	return kr.repeats(elapsed+dt) - kr.repeats(elapsed)
+}
+
+// Reset forgets held keys, e.g. when an input loses focus
+func (kr *KeyRepeat) Reset() {
+	kr.held = make(map[interface{}]float64)
+}
+
+// repeats returns how many repeats have fired after a key is held for elapsed seconds
+func (kr *KeyRepeat) repeats(elapsed float64) int {
+	if elapsed < kr.Delay {
+		return 0
+	}
+	if kr.Interval <= 0 {
+		return 1
+	}
+	return int((elapsed-kr.Delay)/kr.Interval) + 1
+}
+
+// IsShortcutPressed returns true if key was just pressed while control is held
+func IsShortcutPressed(key ebiten.Key) bool {
+	return IsKeyPressed(ebiten.KeyControl) && IsKeyJustPressed(key)
+}
+
+// inputView is a clipped region elements are updated inside, e.g. a scroll view
+type inputView struct {
+	// clip is the visible region in screen coordinates
+	clipX0, clipY0, clipX1, clipY1 float64
+	// originX, originY is where local coordinates start on screen
+	originX, originY float64
+}
+
+// inputViews is the stack of views being updated, innermost last
+var inputViews []inputView
+
+// PushInputView updates the following elements inside a w by h box at x, y of the current view.
+// Pointer positions become relative to originX, originY, e.g. x, y minus scrolling, and positions
+// outside the box miss every element. Each push must be followed by PopInputView
+func PushInputView(x, y, w, h, originX, originY float64) {
+	v := inputView{clipX0: math.Inf(-1), clipY0: math.Inf(-1), clipX1: math.Inf(1), clipY1: math.Inf(1)}
+	if len(inputViews) > 0 {
+		v = inputViews[len(inputViews)-1]
+	}
+	x += v.originX
+	y += v.originY
+	n := inputView{
+		clipX0:  math.Max(v.clipX0, x),
+		clipY0:  math.Max(v.clipY0, y),
+		clipX1:  math.Min(v.clipX1, x+w),
+		clipY1:  math.Min(v.clipY1, y+h),
+		originX: v.originX + originX,
+		originY: v.originY + originY,
+	}
+	inputViews = append(inputViews, n)
+}
+
+// PopInputView returns to the view outside the last PushInputView
+func PopInputView() {
+	if len(inputViews) == 0 {
+		return
+	}
+	inputViews = inputViews[:len(inputViews)-1]
+}
+
+// inputCaptors are the owners capturing input, innermost last. Only the innermost receives input
+var inputCaptors []interface{}
+
+// updatingCaptor is the owner between BeginCapturedInput and EndCapturedInput
+var updatingCaptor interface{}
+
+// CaptureInput sends pointer and key input only to owner until ReleaseInput, e.g. while a popup is open.
+// Captures stack, so a dialog opened over a popup takes input until it is released, then the popup has it again.
+// Everything else sees pointers at negative infinity, so presses miss it, and no keys
+func CaptureInput(owner interface{}) {
+	removeCaptor(owner)
+	inputCaptors = append(inputCaptors, owner)
+}
+
+// ReleaseInput stops owner capturing input, wherever it is in the stack of captures
+func ReleaseInput(owner interface{}) {
+	removeCaptor(owner)
+}
+
+// removeCaptor drops owner from the stack of captures
+func removeCaptor(owner interface{}) {
+	for i := 0; i < len(inputCaptors); i++ {
+		if inputCaptors[i] != owner {
+			continue
+		}
+		inputCaptors = append(inputCaptors[:i], inputCaptors[i+1:]...)
+		i--
+	}
+}
+
+// InputCaptor returns the owner receiving input, nil if input is not captured
+func InputCaptor() interface{} {
+	if len(inputCaptors) == 0 {
+		return nil
+	}
+	return inputCaptors[len(inputCaptors)-1]
+}
+
+// BeginCapturedInput lets owner read input while it is the innermost capture. Each begin must be followed by EndCapturedInput
+func BeginCapturedInput(owner interface{}) {
+	updatingCaptor = owner
+}
+
+// EndCapturedInput stops reading captured input
+func EndCapturedInput() {
+	updatingCaptor = nil
+}
+
+// isInputAllowed returns true if input is not captured, or is read by the innermost captor
+func isInputAllowed() bool {
+	return len(inputCaptors) == 0 || inputCaptors[len(inputCaptors)-1] == updatingCaptor
+}
+
+// IsKeyPressed returns true if key is held, false while input is captured by another owner
+func IsKeyPressed(key ebiten.Key) bool {
+	return isInputAllowed() && ebiten.IsKeyPressed(key)
+}
+
+// IsKeyJustPressed returns true if key was pressed this update, false while input is captured by another owner
+func IsKeyJustPressed(key ebiten.Key) bool {
+	return isInputAllowed() && inpututil.IsKeyJustPressed(key)
+}
+
+// InputChars returns the characters typed this update, none while input is captured by another owner
+func InputChars() []rune {
+	if !isInputAllowed() {
+		return nil
+	}
+	return ebiten.InputChars()
+}
+
+// GamepadIDs returns the connected gamepads, none while input is captured by another owner
+func GamepadIDs() []int {
+	if !isInputAllowed() {
+		return nil
+	}
+	return ebiten.GamepadIDs()
+}
+
+// toView returns screen x, y relative to the current view. Positions outside its clip, or read
+// while another owner captured input, are returned at negative infinity, so they miss every
+// element while still counting as a press
+func toView(x int, y int) (float64, float64) {
+	if !isInputAllowed() {
+		return math.Inf(-1), math.Inf(-1)
+	}
+	fx, fy := float64(x), float64(y)
+	if len(inputViews) == 0 {
+		return fx, fy
+	}
+	v := inputViews[len(inputViews)-1]
+	if fx < v.clipX0 || fx >= v.clipX1 || fy < v.clipY0 || fy >= v.clipY1 {
+		return math.Inf(-1), math.Inf(-1)
+	}
+	return fx - v.originX, fy - v.originY
+}
+
+// toViewUnclipped returns screen x, y relative to the current view, even outside its clip
+func toViewUnclipped(x int, y int) (float64, float64) {
+	fx, fy := float64(x), float64(y)
+	if len(inputViews) == 0 {
+		return fx, fy
+	}
+	v := inputViews[len(inputViews)-1]
+	return fx - v.originX, fy - v.originY
+}
+
+// CursorPosition returns the mouse position in the current view
+func CursorPosition() (float64, float64) {
+	return toView(ebiten.CursorPosition())
+}
+
+// TouchPosition returns the position of a touch in the current view
+func TouchPosition(id int) (float64, float64) {
+	return toView(ebiten.TouchPosition(id))
+}
+
+// PointerJustPressed returns the position of a mouse click or touch that started this update
+func PointerJustPressed() (float64, float64, bool) {
+	//mobile and desktop use differnet touch devices
+	for _, t := range inpututil.JustPressedTouchIDs() {
+		x, y := TouchPosition(t)
+		return x, y, true
+	}
+	if inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonLeft) {
+		x, y := CursorPosition()
+		return x, y, true
+	}
+	return 0, 0, false
+}
+
+// IsPointerCancelled returns true when another owner captured input, so a press ending now was cancelled
+// rather than released
+func IsPointerCancelled() bool {
+	return !isInputAllowed()
+}
+
+// PointerPressed returns the position of the held mouse button or the first touch, to follow a press that
+// started inside the view. Positions are not clipped, so a drag leaving the view keeps moving. A capture by
+// another owner ends the press, check IsPointerCancelled before acting on the release
+func PointerPressed() (float64, float64, bool) {
+	if !isInputAllowed() {
+		return 0, 0, false
+	}
+	for _, t := range ebiten.TouchIDs() {
+		x, y := toViewUnclipped(ebiten.TouchPosition(t))
+		return x, y, true
+	}
+	if ebiten.IsMouseButtonPressed(ebiten.MouseButtonLeft) {
+		x, y := toViewUnclipped(ebiten.CursorPosition())
+		return x, y, true
+	}
+	return 0, 0, false
+}
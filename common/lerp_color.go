@@ -7,7 +7,7 @@ import (
 
 // LerpColor handles color lerp interpolations
 type LerpColor struct {
-	start        time.Time
+	elapsed      float64
 	duration     time.Duration
 	startColor   color.Color
 	endColor     color.Color
@@ -17,20 +17,19 @@ type LerpColor struct {
 	isEndFuncSet bool
 }
 
-// Lerp returns a color
-func (lc *LerpColor) Lerp() (newColor color.Color) {
+// Lerp advances the lerp by dt seconds and returns a color
+func (lc *LerpColor) Lerp(dt float64) (newColor color.Color) {
 	if !lc.isEnabled {
 		newColor = color.White
 		return
 	}
-	if lc.start.Add(lc.duration).Before(time.Now()) {
+	lc.elapsed += dt
+	if lc.elapsed >= lc.duration.Seconds() {
 		lc.isEnabled = false
 		newColor = lc.endColor
 		return
 	}
-	elapsed := time.Since(lc.start).Nanoseconds()
-	destNano := lc.start.Add(lc.duration).Sub(lc.start).Nanoseconds()
-	t := float64(float64(elapsed) / float64(destNano))
+	t := lc.elapsed / lc.duration.Seconds()
 	aR, aB, aG, aA := lc.startColor.RGBA()
 	bR, bB, bG, bA := lc.endColor.RGBA()
 	newColor = color.RGBA64{
@@ -43,9 +42,9 @@ func (lc *LerpColor) Lerp() (newColor color.Color) {
 	return
 }
 
-// Init sets up a new lerp
-func (lc *LerpColor) Init(start time.Time, startColor color.Color, endColor color.Color, duration time.Duration, endFunc func(), isDestroyedAtEnd bool) {
-	lc.start = time.Now()
+// Init sets up a new lerp. Progress is driven by the dt passed to Lerp
+func (lc *LerpColor) Init(startColor color.Color, endColor color.Color, duration time.Duration, endFunc func(), isDestroyedAtEnd bool) {
+	lc.elapsed = 0
 	lc.startColor = startColor
 	lc.endColor = endColor
 	lc.duration = duration
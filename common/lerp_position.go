@@ -6,7 +6,7 @@ import (
 
 // LerpPosition handles vector lerp interpolations
 type LerpPosition struct {
-	start          time.Time
+	elapsed        float64
 	startPositionX float64
 	startPositionY float64
 	duration       time.Duration
@@ -18,20 +18,19 @@ type LerpPosition struct {
 	isEnabled      bool
 }
 
-// Lerp returns a position
-func (lc *LerpPosition) Lerp() (x float64, y float64) {
+// Lerp advances the lerp by dt seconds and returns a position
+func (lc *LerpPosition) Lerp(dt float64) (x float64, y float64) {
 
 	if !lc.isEnabled {
 		return lc.endPositionX, lc.endPositionY
 	}
-	if lc.start.Add(lc.duration).Before(time.Now()) {
+	lc.elapsed += dt
+	if lc.elapsed >= lc.duration.Seconds() {
 		lc.isEnabled = false
 		return lc.endPositionX, lc.endPositionY
 	}
 
-	elapsed := time.Since(lc.start).Nanoseconds()
-	destNano := lc.start.Add(lc.duration).Sub(lc.start).Nanoseconds()
-	t := float64(float64(elapsed) / float64(destNano))
+	t := lc.elapsed / lc.duration.Seconds()
 	x = (1-t)*lc.startPositionX + t*lc.endPositionX
 	y = (1-t)*lc.startPositionY + t*lc.endPositionY
 	return
@@ -73,9 +72,9 @@ func (lc *LerpPosition) IsEndFuncSet() bool {
 	return lc.isEndFuncSet
 }
 
-// Init sets up a new lerp
-func (lc *LerpPosition) Init(start time.Time, startPositionX, startPositionY, endPositionX, endPositionY float64, duration time.Duration, isEnabled bool, endFunc func(), isDestroyedAtEnd bool) {
-	lc.start = time.Now()
+// Init sets up a new lerp. Progress is driven by the dt passed to Lerp
+func (lc *LerpPosition) Init(startPositionX, startPositionY, endPositionX, endPositionY float64, duration time.Duration, isEnabled bool, endFunc func(), isDestroyedAtEnd bool) {
+	lc.elapsed = 0
 	lc.startPositionX = startPositionX
 	lc.startPositionY = startPositionY
 	lc.endPositionX = endPositionX
@@ -0,0 +1,22 @@
+package common
+
+// Orientation is the axis an element lays out or moves along
+type Orientation int
+
+const (
+	// Horizontal runs left to right
+	Horizontal = Orientation(0)
+	// Vertical runs top to bottom
+	Vertical = Orientation(1)
+)
+
+func (o Orientation) String() string {
+	switch o {
+	case 0:
+		return "horizontal"
+	case 1:
+		return "vertical"
+	default:
+		return "horizontal"
+	}
+}
@@ -0,0 +1,484 @@
+package common
+
+import (
+	"image"
+	"image/color"
+	"strconv"
+	"strings"
+
+	"github.com/hajimehoshi/ebiten"
+)
+
+// RichSpan is a run of text sharing one style
+type RichSpan struct {
+	Text string
+	// Color is nil to use the color text is drawn with
+	Color    color.Color
+	IsBold   bool
+	IsItalic bool
+	// Icon is the name of a slice to draw in place of text
+	Icon string
+	// Link is the id passed to link callbacks when the span is pressed
+	Link string
+}
+
+// RichText is text parsed from markup into styled spans
+type RichText struct {
+	Spans []*RichSpan
+}
+
+// ParseRichText parses markup into spans. Supported tags are
+//
+//	[color=#rgb], [color=#rrggbb] or [color=#rrggbbaa] ... [/color]
+//	[b] ... [/b] and [i] ... [/i]
+//	[icon=name]
+//	[link=id] ... [/link]
+//
+// Unknown tags are kept as text, and [[ escapes a bracket
+func ParseRichText(markup string) *RichText {
+	rt := &RichText{}
+	var colors []color.Color
+	var links []string
+	bold := 0
+	italic := 0
+	var sb strings.Builder
+
+	flush := func() {
+		if sb.Len() == 0 {
+			return
+		}
+		s := &RichSpan{Text: sb.String(), IsBold: bold > 0, IsItalic: italic > 0}
+		if len(colors) > 0 {
+			s.Color = colors[len(colors)-1]
+		}
+		if len(links) > 0 {
+			s.Link = links[len(links)-1]
+		}
+		rt.Spans = append(rt.Spans, s)
+		sb.Reset()
+	}
+
+	for len(markup) > 0 {
+		if strings.HasPrefix(markup, "[[") {
+			sb.WriteByte('[')
+			markup = markup[2:]
+			continue
+		}
+		end := strings.IndexByte(markup, ']')
+		if markup[0] != '[' || end < 0 {
+			next := strings.IndexByte(markup[1:], '[')
+			if next < 0 {
+				sb.WriteString(markup)
+				break
+			}
+			sb.WriteString(markup[:next+1])
+			markup = markup[next+1:]
+			continue
+		}
+
+		tag := markup[1:end]
+		name, value := tag, ""
+		if i := strings.IndexByte(tag, '='); i >= 0 {
+			name, value = tag[:i], tag[i+1:]
+		}
+		isTag := true
+		switch name {
+		case "color":
+			c, err := parseHexColor(value)
+			if err != nil {
+				isTag = false
+				break
+			}
+			flush()
+			colors = append(colors, c)
+		case "/color":
+			flush()
+			if len(colors) > 0 {
+				colors = colors[:len(colors)-1]
+			}
+		case "b":
+			flush()
+			bold++
+		case "/b":
+			flush()
+			if bold > 0 {
+				bold--
+			}
+		case "i":
+			flush()
+			italic++
+		case "/i":
+			flush()
+			if italic > 0 {
+				italic--
+			}
+		case "icon":
+			if value == "" {
+				isTag = false
+				break
+			}
+			flush()
+			s := &RichSpan{Icon: value}
+			if len(links) > 0 {
+				s.Link = links[len(links)-1]
+			}
+			rt.Spans = append(rt.Spans, s)
+		case "link":
+			flush()
+			links = append(links, value)
+		case "/link":
+			flush()
+			if len(links) > 0 {
+				links = links[:len(links)-1]
+			}
+		default:
+			isTag = false
+		}
+		if !isTag {
+			sb.WriteByte('[')
+			markup = markup[1:]
+			continue
+		}
+		markup = markup[end+1:]
+	}
+	flush()
+	return rt
+}
+
+// String returns the text without markup, icons are dropped
+func (rt *RichText) String() string {
+	var sb strings.Builder
+	for _, s := range rt.Spans {
+		sb.WriteString(s.Text)
+	}
+	return sb.String()
+}
+
+// parseHexColor parses #rgb, #rrggbb and #rrggbbaa
+func parseHexColor(value string) (color.Color, error) {
+	value = strings.TrimPrefix(value, "#")
+	if len(value) == 3 {
+		value = string([]byte{value[0], value[0], value[1], value[1], value[2], value[2]})
+	}
+	if len(value) == 6 {
+		value += "ff"
+	}
+	if len(value) != 8 {
+		return nil, ErrColorInvalid
+	}
+	v, err := strconv.ParseUint(value, 16, 32)
+	if err != nil {
+		return nil, ErrColorInvalid
+	}
+	return color.NRGBA{R: uint8(v >> 24), G: uint8(v >> 16), B: uint8(v >> 8), A: uint8(v)}, nil
+}
+
+// RichTextStyle contains the fonts and icons rich text is laid out with.
+// Missing bold or italic fonts fall back to Font
+type RichTextStyle struct {
+	Font           *Font
+	BoldFont       *Font
+	ItalicFont     *Font
+	BoldItalicFont *Font
+	// Icons contains the slices drawn by icon tags
+	Icons *Image
+}
+
+// font returns the font for a span
+func (st *RichTextStyle) font(s *RichSpan) *Font {
+	var f *Font
+	switch {
+	case s.IsBold && s.IsItalic:
+		f = st.BoldItalicFont
+		if f == nil {
+			f = st.BoldFont
+		}
+	case s.IsBold:
+		f = st.BoldFont
+	case s.IsItalic:
+		f = st.ItalicFont
+	}
+	if f == nil {
+		f = st.Font
+	}
+	return f
+}
+
+// RichLink is the area of a link within a laid out box
+type RichLink struct {
+	ID     string
+	Bounds Rectangle
+}
+
+// RichTextLayout is rich text positioned within a box. Keep it between draws, and lay out
+// again only when the text, style or box change
+type RichTextLayout struct {
+	runs []*richRun
+	// Links are the pressable areas, relative to the top left of the box
+	Links []RichLink
+	// Width and Height are the size of the laid out content
+	Width  float64
+	Height float64
+}
+
+// richRun is a positioned piece of text or an icon
+type richRun struct {
+	text  string
+	runes int
+	font  *Font
+	color color.Color
+	icon  *SliceKey
+	link  string
+	x     float64
+	y     float64
+	w     float64
+	h     float64
+	// baseline is the y text is drawn at
+	baseline float64
+	isSpace  bool
+}
+
+type richLine struct {
+	runs []*richRun
+	// isWrapped is true when the line continues the previous one
+	isWrapped bool
+	width     float64
+	height    float64
+}
+
+// Layout positions the text within layout. Ellipsis is not applied to rich text
+func (rt *RichText) Layout(style *RichTextStyle, layout *TextLayout) *RichTextLayout {
+	if layout == nil {
+		layout = &TextLayout{}
+	}
+	rl := &RichTextLayout{}
+	if style == nil || style.Font == nil {
+		return rl
+	}
+
+	lines := []*richLine{{}}
+	line := lines[0]
+	newLine := func(isWrapped bool) {
+		line = &richLine{isWrapped: isWrapped}
+		lines = append(lines, line)
+	}
+	isWrapped := layout.IsWrapped && layout.Width > 0
+	place := func(r *richRun) {
+		if isWrapped && !r.isSpace && line.width+r.w > layout.Width && line.width > 0 {
+			newLine(true)
+		}
+		if r.isSpace && len(line.runs) == 0 && line.isWrapped {
+			// wrapped lines do not start with a space
+			return
+		}
+		r.x = line.width
+		line.width += r.w
+		line.runs = append(line.runs, r)
+	}
+
+	for _, s := range rt.Spans {
+		if s.Icon != "" {
+			if style.Icons == nil {
+				continue
+			}
+			slice, err := style.Icons.Slice(s.Icon)
+			if err != nil || len(slice.Keys) == 0 {
+				continue
+			}
+			k := slice.Keys[0]
+			place(&richRun{icon: k, link: s.Link, runes: 1, w: float64(k.Bounds.W), h: float64(k.Bounds.H)})
+			continue
+		}
+
+		f := style.font(s)
+		for i, para := range strings.Split(s.Text, "\n") {
+			if i > 0 {
+				newLine(false)
+			}
+			for _, word := range splitWords(para) {
+				r := &richRun{text: word, font: f, color: s.Color, link: s.Link, isSpace: word[0] == ' '}
+				r.w = float64(f.advance(word))
+				// words wider than a line are broken between runes
+				for isWrapped && !r.isSpace && r.w > layout.Width {
+					if line.width > 0 {
+						newLine(true)
+					}
+					n := f.fitRunes(r.text, int(layout.Width))
+					runes := []rune(r.text)
+					head := *r
+					head.text = string(runes[:n])
+					head.w = float64(f.advance(head.text))
+					place(&head)
+					r.text = string(runes[n:])
+					r.w = float64(f.advance(r.text))
+					newLine(true)
+				}
+				place(r)
+			}
+		}
+	}
+
+	// measure lines, dropping any past the box height
+	total := 0.0
+	for i, l := range lines {
+		for len(l.runs) > 0 && l.runs[len(l.runs)-1].isSpace {
+			l.width -= l.runs[len(l.runs)-1].w
+			l.runs = l.runs[:len(l.runs)-1]
+		}
+		l.height = float64(style.Font.RenderingLineHeight)
+		for _, r := range l.runs {
+			h := r.h
+			if r.font != nil {
+				h = float64(r.font.RenderingLineHeight)
+			}
+			if h > l.height {
+				l.height = h
+			}
+		}
+		if layout.Height > 0 && i > 0 && total+l.height > layout.Height {
+			lines = lines[:i]
+			break
+		}
+		total += l.height
+		if l.width > rl.Width {
+			rl.Width = l.width
+		}
+	}
+	rl.Height = total
+
+	y := 0.0
+	switch layout.VAlign {
+	case TextAlignMiddle:
+		y = (layout.Height - total) / 2
+	case TextAlignBottom:
+		y = layout.Height - total
+	}
+	for _, l := range lines {
+		x := 0.0
+		switch layout.Align {
+		case TextAlignCenter:
+			x = (layout.Width - l.width) / 2
+		case TextAlignRight:
+			x = layout.Width - l.width
+		}
+		for _, r := range l.runs {
+			r.x += x
+			if r.icon != nil {
+				r.y = y + (l.height-r.h)/2
+			} else {
+				r.runes = len([]rune(r.text))
+				r.h = float64(r.font.RenderingLineHeight)
+				r.y = y + (l.height-r.h)/2
+				r.baseline = r.y + (r.h-float64(r.font.Height))/2 + float64(r.font.Face.Metrics().Ascent.Ceil())
+			}
+		}
+		rl.runs = append(rl.runs, mergeRuns(l.runs)...)
+		y += l.height
+	}
+
+	for _, r := range rl.runs {
+		if r.link == "" {
+			continue
+		}
+		rl.Links = append(rl.Links, RichLink{ID: r.link, Bounds: Rect(r.x, r.y, r.x+r.w, r.y+r.h)})
+	}
+	return rl
+}
+
+// splitWords splits a line into words and runs of spaces
+func splitWords(line string) []string {
+	var words []string
+	start := 0
+	for i := 1; i <= len(line); i++ {
+		if i == len(line) || (line[i] == ' ') != (line[start] == ' ') {
+			words = append(words, line[start:i])
+			start = i
+		}
+	}
+	return words
+}
+
+// mergeRuns joins neighbouring runs of the same style so each draws with one call
+func mergeRuns(runs []*richRun) []*richRun {
+	var merged []*richRun
+	for _, r := range runs {
+		if len(merged) > 0 {
+			p := merged[len(merged)-1]
+			if p.icon == nil && r.icon == nil && p.font == r.font && p.color == r.color && p.link == r.link {
+				p.text += r.text
+				p.runes += r.runes
+				p.w = r.x + r.w - p.x
+				continue
+			}
+		}
+		merged = append(merged, r)
+	}
+	return merged
+}
+
+// Draw draws the laid out text with x, y at the top left of the box. Spans without a color use clr.
+// Only the first displayRuneCount runes are drawn, icons count as one. A negative count draws everything
+func (rl *RichTextLayout) Draw(dst *ebiten.Image, icons *Image, x, y float64, clr color.Color, displayRuneCount int) {
+	remaining := displayRuneCount
+	for _, r := range rl.runs {
+		if remaining == 0 {
+			return
+		}
+		if r.icon != nil {
+			if icons != nil {
+				b := r.icon.Bounds
+				op := &ebiten.DrawImageOptions{}
+				op.GeoM.Translate(x+r.x, y+r.y)
+				sub := icons.EbitenImage.SubImage(image.Rect(b.X, b.Y, b.X+b.W, b.Y+b.H)).(*ebiten.Image)
+				dst.DrawImage(sub, op)
+			}
+			if remaining > 0 {
+				remaining--
+			}
+			continue
+		}
+
+		str := r.text
+		if remaining > 0 {
+			if r.runes > remaining {
+				str = string([]rune(str)[:remaining])
+			}
+			remaining -= len([]rune(str))
+		}
+		c := clr
+		if r.color != nil {
+			c = r.color
+		}
+		r.font.drawLine(dst, str, int(x+r.x), int(y+r.baseline), c)
+	}
+}
+
+// LinkAt returns the id of the link at x, y relative to the top left of the box
+func (rl *RichTextLayout) LinkAt(x, y float64) (string, bool) {
+	for _, l := range rl.Links {
+		if x >= l.Bounds.Min.X && x < l.Bounds.Max.X && y >= l.Bounds.Min.Y && y < l.Bounds.Max.Y {
+			return l.ID, true
+		}
+	}
+	return "", false
+}
+
+// RuneCount returns how many runes Draw can reveal, icons count as one
+func (rl *RichTextLayout) RuneCount() int {
+	count := 0
+	for _, r := range rl.runs {
+		count += r.runes
+	}
+	return count
+}
+
+// fontRevision returns the sum of the revisions of fonts the runs draw with, which changes when any is rescaled
+func (rl *RichTextLayout) fontRevision() int {
+	revision := 0
+	for _, r := range rl.runs {
+		if r.font != nil {
+			revision += r.font.Revision()
+		}
+	}
+	return revision
+}
@@ -0,0 +1,119 @@
+package common
+
+import (
+	"image/color"
+	"reflect"
+	"testing"
+)
+
+func TestParseRichText(t *testing.T) {
+	red := color.NRGBA{R: 0xff, A: 0xff}
+	tests := []struct {
+		name   string
+		markup string
+		want   []*RichSpan
+	}{
+		{"plain", "hello", []*RichSpan{{Text: "hello"}}},
+		{"bold", "a[b]b[/b]c", []*RichSpan{{Text: "a"}, {Text: "b", IsBold: true}, {Text: "c"}}},
+		{"nested", "[b][i]x[/i]y[/b]", []*RichSpan{{Text: "x", IsBold: true, IsItalic: true}, {Text: "y", IsBold: true}}},
+		{"short color", "[color=#f00]x[/color]", []*RichSpan{{Text: "x", Color: red}}},
+		{"long color", "[color=#ff000080]x", []*RichSpan{{Text: "x", Color: color.NRGBA{R: 0xff, A: 0x80}}}},
+		{"invalid color kept", "[color=red]x", []*RichSpan{{Text: "[color=red]x"}}},
+		{"icon", "a[icon=coin]b", []*RichSpan{{Text: "a"}, {Icon: "coin"}, {Text: "b"}}},
+		{"empty icon kept", "[icon=]", []*RichSpan{{Text: "[icon=]"}}},
+		{"link", "[link=shop]buy [icon=coin][/link]", []*RichSpan{{Text: "buy ", Link: "shop"}, {Icon: "coin", Link: "shop"}}},
+		{"unknown tag kept", "[u]x[/u]", []*RichSpan{{Text: "[u]x[/u]"}}},
+		{"escaped bracket", "[[b]", []*RichSpan{{Text: "[b]"}}},
+		{"unclosed bracket", "a[b", []*RichSpan{{Text: "a[b"}}},
+		{"extra close ignored", "x[/b][/color]y", []*RichSpan{{Text: "x"}, {Text: "y"}}},
+	}
+	for _, tt := range tests {
+		got := ParseRichText(tt.markup).Spans
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s: got %v, want %v", tt.name, spanStrings(got), spanStrings(tt.want))
+		}
+	}
+}
+
+func spanStrings(spans []*RichSpan) []RichSpan {
+	var s []RichSpan
+	for _, sp := range spans {
+		s = append(s, *sp)
+	}
+	return s
+}
+
+func TestRichTextString(t *testing.T) {
+	rt := ParseRichText("[b]gold[/b] [icon=coin] [[x]")
+	if got := rt.String(); got != "gold  [x]" {
+		t.Errorf("got %q, want %q", got, "gold  [x]")
+	}
+}
+
+func TestRichTextLayout(t *testing.T) {
+	style := &RichTextStyle{Font: newTestFont()}
+	tests := []struct {
+		name   string
+		markup string
+		layout *TextLayout
+		width  float64
+		height float64
+		links  []RichLink
+	}{
+		{"unbounded", "ab [link=a]cd[/link]", nil, 50, 10,
+			[]RichLink{{ID: "a", Bounds: Rect(30, 0, 50, 10)}}},
+		{"wrapped", "ab [link=a]cd ef[/link]", &TextLayout{Width: 50, IsWrapped: true}, 50, 20,
+			[]RichLink{{ID: "a", Bounds: Rect(30, 0, 50, 10)}, {ID: "a", Bounds: Rect(0, 10, 20, 20)}}},
+		{"line breaks", "ab\n[link=a]c[/link]", nil, 20, 20,
+			[]RichLink{{ID: "a", Bounds: Rect(0, 10, 10, 20)}}},
+		{"height trims", "a\nb\n[link=a]c[/link]", &TextLayout{Height: 25}, 10, 20, nil},
+		{"centered", "[link=a]ab[/link]", &TextLayout{Width: 100, Height: 50, Align: TextAlignCenter, VAlign: TextAlignMiddle}, 20, 10,
+			[]RichLink{{ID: "a", Bounds: Rect(40, 20, 60, 30)}}},
+		{"right bottom", "[link=a]ab[/link]", &TextLayout{Width: 100, Height: 50, Align: TextAlignRight, VAlign: TextAlignBottom}, 20, 10,
+			[]RichLink{{ID: "a", Bounds: Rect(80, 40, 100, 50)}}},
+	}
+	for _, tt := range tests {
+		rl := ParseRichText(tt.markup).Layout(style, tt.layout)
+		if rl.Width != tt.width || rl.Height != tt.height {
+			t.Errorf("%s: size %v x %v, want %v x %v", tt.name, rl.Width, rl.Height, tt.width, tt.height)
+		}
+		if !reflect.DeepEqual(rl.Links, tt.links) {
+			t.Errorf("%s: links %v, want %v", tt.name, rl.Links, tt.links)
+		}
+	}
+}
+
+func TestRichTextLayoutRuneCount(t *testing.T) {
+	style := &RichTextStyle{Font: newTestFont()}
+	rl := ParseRichText("ab [b]cd[/b]\nef").Layout(style, nil)
+	if rl.RuneCount() != 7 {
+		t.Errorf("rune count %d, want 7", rl.RuneCount())
+	}
+	if rl := ParseRichText("ab").Layout(nil, nil); rl.RuneCount() != 0 || len(rl.Links) != 0 {
+		t.Errorf("layout without style has %d runes", rl.RuneCount())
+	}
+}
+
+func TestRichTextLinkAt(t *testing.T) {
+	style := &RichTextStyle{Font: newTestFont()}
+	rl := ParseRichText("ab [link=shop]cd[/link] [link=quit]ef[/link]").Layout(style, nil)
+	tests := []struct {
+		x    float64
+		y    float64
+		id   string
+		isOk bool
+	}{
+		{5, 5, "", false},
+		{30, 0, "shop", true},
+		{49, 9, "shop", true},
+		{50, 5, "", false},
+		{65, 5, "quit", true},
+		{65, 10, "", false},
+	}
+	for _, tt := range tests {
+		id, ok := rl.LinkAt(tt.x, tt.y)
+		if id != tt.id || ok != tt.isOk {
+			t.Errorf("%v, %v: got %q %v, want %q %v", tt.x, tt.y, id, ok, tt.id, tt.isOk)
+		}
+	}
+}
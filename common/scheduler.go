@@ -0,0 +1,79 @@
+package common
+
+import "time"
+
+// Scheduler runs timers against game time
+type Scheduler struct {
+	timers []*Timer
+}
+
+// NewScheduler returns a new scheduler
+func NewScheduler() *Scheduler {
+	return &Scheduler{}
+}
+
+// After calls fn once after delay
+func (s *Scheduler) After(delay time.Duration, fn func()) *Timer {
+	return s.add(delay, false, func() bool {
+		fn()
+		return true
+	})
+}
+
+// Every calls fn each interval until cancelled
+func (s *Scheduler) Every(interval time.Duration, fn func()) *Timer {
+	return s.add(interval, true, func() bool {
+		fn()
+		return false
+	})
+}
+
+// Until calls fn each interval until it returns true or is cancelled
+func (s *Scheduler) Until(interval time.Duration, fn func() bool) *Timer {
+	return s.add(interval, true, fn)
+}
+
+func (s *Scheduler) add(interval time.Duration, isRepeating bool, fn func() bool) *Timer {
+	t := &Timer{
+		interval:    interval.Seconds(),
+		fn:          fn,
+		isRepeating: isRepeating,
+	}
+	s.timers = append(s.timers, t)
+	return t
+}
+
+// Update advances every timer by dt seconds
+func (s *Scheduler) Update(dt float64) {
+	// timers added while firing are first advanced next update
+	count := len(s.timers)
+	for i := 0; i < count && i < len(s.timers); i++ {
+		s.timers[i].update(dt)
+	}
+
+	n := 0
+	for _, t := range s.timers {
+		if t.IsDone() {
+			continue
+		}
+		s.timers[n] = t
+		n++
+	}
+	for i := n; i < len(s.timers); i++ {
+		s.timers[i] = nil
+	}
+	s.timers = s.timers[:n]
+}
+
+// CancelAll cancels every timer
+func (s *Scheduler) CancelAll() {
+	for _, t := range s.timers {
+		t.Cancel()
+	}
+	s.timers = nil
+}
+
+// Count returns how many timers are pending
+func (s *Scheduler) Count() int {
+	return len(s.timers)
+}
@@ -0,0 +1,121 @@
+package common
+
+import (
+	"testing"
+	"time"
+)
+
+type testOwner struct {
+	isDestroyed bool
+}
+
+func (o *testOwner) IsDestroyed() bool {
+	return o.isDestroyed
+}
+
+func TestSchedulerTimers(t *testing.T) {
+	tests := []struct {
+		name  string
+		add   func(s *Scheduler, fired *int) *Timer
+		steps []float64
+		fired int
+		done  bool
+	}{
+		{"after not yet", func(s *Scheduler, fired *int) *Timer {
+			return s.After(time.Second, func() { *fired++ })
+		}, []float64{0.5, 0.4}, 0, false},
+		{"after fires once", func(s *Scheduler, fired *int) *Timer {
+			return s.After(time.Second, func() { *fired++ })
+		}, []float64{0.5, 0.5, 5}, 1, true},
+		{"every catches up", func(s *Scheduler, fired *int) *Timer {
+			return s.Every(100*time.Millisecond, func() { *fired++ })
+		}, []float64{0.35}, 3, false},
+		{"every zero interval fires once per update", func(s *Scheduler, fired *int) *Timer {
+			return s.Every(0, func() { *fired++ })
+		}, []float64{1, 1, 1}, 3, false},
+		{"until stops", func(s *Scheduler, fired *int) *Timer {
+			return s.Until(time.Second, func() bool {
+				*fired++
+				return *fired == 2
+			})
+		}, []float64{1, 1, 1, 1}, 2, true},
+	}
+	for _, tt := range tests {
+		s := NewScheduler()
+		fired := 0
+		timer := tt.add(s, &fired)
+		for _, dt := range tt.steps {
+			s.Update(dt)
+		}
+		if fired != tt.fired {
+			t.Errorf("%s: fired %d, want %d", tt.name, fired, tt.fired)
+		}
+		if timer.IsDone() != tt.done {
+			t.Errorf("%s: done %v, want %v", tt.name, timer.IsDone(), tt.done)
+		}
+		wantCount := 1
+		if tt.done {
+			wantCount = 0
+		}
+		if s.Count() != wantCount {
+			t.Errorf("%s: %d pending, want %d", tt.name, s.Count(), wantCount)
+		}
+	}
+}
+
+func TestSchedulerCancel(t *testing.T) {
+	s := NewScheduler()
+	fired := 0
+	timer := s.Every(time.Second, func() { fired++ })
+	s.Update(1)
+	timer.Cancel()
+	s.Update(1)
+	if fired != 1 || s.Count() != 0 {
+		t.Errorf("cancel: fired %d with %d pending, want 1 and 0", fired, s.Count())
+	}
+
+	owner := &testOwner{}
+	timer = s.Every(time.Second, func() { fired++ })
+	timer.SetOwner(owner)
+	owner.isDestroyed = true
+	s.Update(1)
+	if !timer.IsCancelled() || fired != 1 {
+		t.Errorf("owner: cancelled %v fired %d, want true and 1", timer.IsCancelled(), fired)
+	}
+}
+
+func TestSchedulerAddWhileFiring(t *testing.T) {
+	s := NewScheduler()
+	fired := 0
+	s.After(0, func() {
+		s.After(0, func() { fired++ })
+	})
+	s.Update(0.1)
+	if fired != 0 {
+		t.Errorf("timer added while firing ran in the same update")
+	}
+	s.Update(0.1)
+	if fired != 1 {
+		t.Errorf("fired %d, want 1", fired)
+	}
+}
+
+func TestSchedulerPaused(t *testing.T) {
+	s := NewScheduler()
+	fired := 0
+	s.After(0, func() { fired++ })
+	s.Every(0, func() { fired++ })
+	s.Every(time.Second, func() { fired++ })
+
+	// a paused clock advances by 0
+	for i := 0; i < 3; i++ {
+		s.Update(0)
+	}
+	if fired != 0 {
+		t.Errorf("fired %d times while paused, want 0", fired)
+	}
+	s.Update(1)
+	if fired != 3 {
+		t.Errorf("fired %d times after resuming, want 3", fired)
+	}
+}
@@ -0,0 +1,246 @@
+package common
+
+import "time"
+
+// Step is a single action of a sequence, advanced every update until it returns true
+type Step interface {
+	// Update advances the step by dt seconds and returns true once complete
+	Update(dt float64) bool
+	// Skip completes the step immediately
+	Skip()
+}
+
+// Sequence runs steps one after another across frames, used to script cutscenes
+// without nesting end functions
+//
+//	seq := common.NewSequence().
+//		Tween(walk).
+//		Wait(time.Second).
+//		Do(func() { lbl.SetText("Who goes there?") }).
+//		WaitFor(func() bool { return isAdvancePressed })
+type Sequence struct {
+	steps       []Step
+	index       int
+	isCancelled bool
+	isFinished  bool
+	endFunc     func()
+	cancelFunc  func()
+}
+
+// NewSequence returns an empty sequence
+func NewSequence() *Sequence {
+	return &Sequence{}
+}
+
+// Add appends a custom step
+func (s *Sequence) Add(step Step) *Sequence {
+	s.steps = append(s.steps, step)
+	return s
+}
+
+// Do calls fn once, then moves on the same update
+func (s *Sequence) Do(fn func()) *Sequence {
+	return s.Add(&doStep{fn: fn})
+}
+
+// Run calls fn every update until it returns true. Skipping drops the remaining calls
+func (s *Sequence) Run(fn func(dt float64) bool) *Sequence {
+	return s.Add(&runStep{fn: fn})
+}
+
+// Wait pauses the sequence for duration of game time
+func (s *Sequence) Wait(duration time.Duration) *Sequence {
+	return s.Add(&waitStep{duration: duration.Seconds()})
+}
+
+// WaitFor pauses the sequence until condition returns true
+func (s *Sequence) WaitFor(condition func() bool) *Sequence {
+	return s.Add(&waitForStep{condition: condition})
+}
+
+// Tween plays a tween and waits for it to finish. Skipping jumps the tween to its end
+func (s *Sequence) Tween(t Tweener) *Sequence {
+	return s.Add(&tweenStep{tween: t})
+}
+
+// Parallel runs branches at the same time and waits for all of them to finish
+func (s *Sequence) Parallel(branches ...*Sequence) *Sequence {
+	return s.Add(&parallelStep{branches: branches})
+}
+
+// Update advances the sequence by dt seconds and returns true once finished or cancelled
+func (s *Sequence) Update(dt float64) bool {
+	if s.isCancelled || s.isFinished {
+		return true
+	}
+	for s.index < len(s.steps) {
+		if !s.steps[s.index].Update(dt) {
+			return false
+		}
+		s.index++
+		// leftover time is not carried into the next step
+		dt = 0
+		if s.isCancelled {
+			return true
+		}
+	}
+	s.finish()
+	return true
+}
+
+// Skip completes every remaining step immediately, e.g. when a player skips a cutscene
+func (s *Sequence) Skip() {
+	if s.isCancelled || s.isFinished {
+		return
+	}
+	for s.index < len(s.steps) {
+		s.steps[s.index].Skip()
+		s.index++
+		if s.isCancelled {
+			return
+		}
+	}
+	s.finish()
+}
+
+func (s *Sequence) finish() {
+	s.isFinished = true
+	if s.endFunc != nil {
+		s.endFunc()
+	}
+}
+
+// Cancel stops the sequence where it is without running remaining steps
+func (s *Sequence) Cancel() {
+	if s.isCancelled || s.isFinished {
+		return
+	}
+	s.isCancelled = true
+	for i := s.index; i < len(s.steps); i++ {
+		p, ok := s.steps[i].(*parallelStep)
+		if !ok {
+			continue
+		}
+		for _, b := range p.branches {
+			b.Cancel()
+		}
+	}
+	if s.cancelFunc != nil {
+		s.cancelFunc()
+	}
+}
+
+// IsCancelled returns true if the sequence was cancelled
+func (s *Sequence) IsCancelled() bool {
+	return s.isCancelled
+}
+
+// IsFinished returns true if every step completed
+func (s *Sequence) IsFinished() bool {
+	return s.isFinished
+}
+
+// SetEndFunc sets a function to call once every step completes
+func (s *Sequence) SetEndFunc(endFunc func()) {
+	s.endFunc = endFunc
+}
+
+// SetCancelFunc sets a function to call if the sequence is cancelled, e.g. to restore state
+func (s *Sequence) SetCancelFunc(cancelFunc func()) {
+	s.cancelFunc = cancelFunc
+}
+
+type doStep struct {
+	fn func()
+}
+
+func (st *doStep) Update(dt float64) bool {
+	if st.fn != nil {
+		st.fn()
+	}
+	return true
+}
+
+func (st *doStep) Skip() {
+	st.Update(0)
+}
+
+type runStep struct {
+	fn func(dt float64) bool
+}
+
+func (st *runStep) Update(dt float64) bool {
+	if st.fn == nil {
+		return true
+	}
+	return st.fn(dt)
+}
+
+func (st *runStep) Skip() {
+}
+
+type waitStep struct {
+	duration float64
+	elapsed  float64
+}
+
+func (st *waitStep) Update(dt float64) bool {
+	st.elapsed += dt
+	return st.elapsed >= st.duration
+}
+
+func (st *waitStep) Skip() {
+	st.elapsed = st.duration
+}
+
+type waitForStep struct {
+	condition func() bool
+}
+
+func (st *waitForStep) Update(dt float64) bool {
+	if st.condition == nil {
+		return true
+	}
+	return st.condition()
+}
+
+func (st *waitForStep) Skip() {
+}
+
+type tweenStep struct {
+	tween Tweener
+}
+
+func (st *tweenStep) Update(dt float64) bool {
+	if st.tween == nil {
+		return true
+	}
+	return st.tween.Update(dt)
+}
+
+func (st *tweenStep) Skip() {
+	if st.tween == nil {
+		return
+	}
+	st.tween.Finish()
+}
+
+type parallelStep struct {
+	branches []*Sequence
+}
+
+func (st *parallelStep) Update(dt float64) bool {
+	isDone := true
+	for _, b := range st.branches {
+		if !b.Update(dt) {
+			isDone = false
+		}
+	}
+	return isDone
+}
+
+func (st *parallelStep) Skip() {
+	for _, b := range st.branches {
+		b.Skip()
+	}
+}
@@ -0,0 +1,92 @@
+package common
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestSequenceUpdate(t *testing.T) {
+	var log []string
+	isReady := false
+	runs := 0
+	seq := NewSequence().
+		Do(func() { log = append(log, "start") }).
+		Wait(time.Second).
+		Do(func() { log = append(log, "waited") }).
+		WaitFor(func() bool { return isReady }).
+		Run(func(dt float64) bool {
+			runs++
+			return runs == 2
+		}).
+		Do(func() { log = append(log, "done") })
+	ended := 0
+	seq.SetEndFunc(func() { ended++ })
+
+	steps := []struct {
+		dt       float64
+		isReady  bool
+		log      []string
+		finished bool
+	}{
+		// time left after an instant step is not carried into the wait
+		{0.5, false, []string{"start"}, false},
+		{0.5, false, []string{"start"}, false},
+		{0.5, false, []string{"start", "waited"}, false},
+		{0, true, []string{"start", "waited"}, false},
+		{0, true, []string{"start", "waited", "done"}, true},
+	}
+	for i, st := range steps {
+		isReady = st.isReady
+		finished := seq.Update(st.dt)
+		if !reflect.DeepEqual(log, st.log) {
+			t.Errorf("step %d: log %v, want %v", i, log, st.log)
+		}
+		if finished != st.finished {
+			t.Errorf("step %d: finished %v, want %v", i, finished, st.finished)
+		}
+	}
+	if ended != 1 || !seq.IsFinished() {
+		t.Errorf("ended %d, want 1", ended)
+	}
+}
+
+func TestSequenceTweenAndParallel(t *testing.T) {
+	value := 0.0
+	tw := NewTweenFloat(nil, 0, 10, time.Second, nil, func(v float64) { value = v })
+	other := 0
+	seq := NewSequence().Parallel(
+		NewSequence().Tween(tw),
+		NewSequence().Wait(2*time.Second).Do(func() { other++ }),
+	)
+	seq.Update(1.5)
+	if !tw.IsFinished() || other != 0 || seq.IsFinished() {
+		t.Errorf("after 1.5s: tween finished %v, other %d, sequence finished %v", tw.IsFinished(), other, seq.IsFinished())
+	}
+	seq.Update(1)
+	if other != 1 || !seq.IsFinished() || value != 10 {
+		t.Errorf("after 2.5s: other %d, finished %v, value %v", other, seq.IsFinished(), value)
+	}
+}
+
+func TestSequenceSkipAndCancel(t *testing.T) {
+	value := 0.0
+	tw := NewTweenFloat(nil, 0, 10, time.Second, nil, func(v float64) { value = v })
+	done := 0
+	seq := NewSequence().Wait(time.Hour).Tween(tw).Do(func() { done++ })
+	seq.Update(1)
+	seq.Skip()
+	if !seq.IsFinished() || value != 10 || done != 1 {
+		t.Errorf("skip: finished %v, value %v, done %d", seq.IsFinished(), value, done)
+	}
+
+	cancelled := 0
+	branch := NewSequence().Wait(time.Hour)
+	seq = NewSequence().Parallel(branch).Do(func() { done++ })
+	seq.SetCancelFunc(func() { cancelled++ })
+	seq.Update(1)
+	seq.Cancel()
+	if !seq.Update(1) || !branch.IsCancelled() || cancelled != 1 || done != 1 {
+		t.Errorf("cancel: branch cancelled %v, cancel func %d, done %d", branch.IsCancelled(), cancelled, done)
+	}
+}
@@ -0,0 +1,415 @@
+package common
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/draw"
+	"unicode"
+
+	"github.com/go-text/typesetting/di"
+	gotext "github.com/go-text/typesetting/font"
+	gtlanguage "github.com/go-text/typesetting/language"
+	"github.com/go-text/typesetting/shaping"
+	"github.com/hajimehoshi/ebiten"
+	"github.com/hajimehoshi/ebiten/text"
+	"golang.org/x/image/font/sfnt"
+	"golang.org/x/image/math/fixed"
+	"golang.org/x/image/vector"
+	"golang.org/x/text/language"
+	"golang.org/x/text/unicode/bidi"
+)
+
+// complexScripts need shaping to join or reorder glyphs, or are written right to left
+var complexScripts = []*unicode.RangeTable{
+	unicode.Arabic, unicode.Hebrew, unicode.Syriac, unicode.Thaana, unicode.Nko,
+	unicode.Devanagari, unicode.Bengali, unicode.Gurmukhi, unicode.Gujarati, unicode.Oriya,
+	unicode.Tamil, unicode.Telugu, unicode.Kannada, unicode.Malayalam, unicode.Sinhala,
+	unicode.Thai, unicode.Lao, unicode.Tibetan, unicode.Myanmar, unicode.Khmer, unicode.Mongolian,
+}
+
+// needsShaping returns true if line contains a complex script
+func needsShaping(line string) bool {
+	for _, r := range line {
+		if r < 0x0590 {
+			continue
+		}
+		if unicode.In(r, complexScripts...) {
+			return true
+		}
+	}
+	return false
+}
+
+// isRightToLeft returns true if the first strong character of line is right to left
+func isRightToLeft(line string) bool {
+	for _, r := range line {
+		p, _ := bidi.LookupRune(r)
+		switch p.Class() {
+		case bidi.L:
+			return false
+		case bidi.R, bidi.AL:
+			return true
+		}
+	}
+	return false
+}
+
+// SetFallbacks sets fonts to draw runes this font has no glyph for, tried in order.
+// Sized variants of the font use the same size of each fallback
+func (f *Font) SetFallbacks(fallbacks ...*Font) {
+	f.fallbacks = fallbacks
+	f.revision++
+	f.cache.clear()
+	for size, sf := range f.sizes {
+		sf.SetFallbacks(sizedFallbacks(fallbacks, size)...)
+	}
+}
+
+// Fallbacks returns the fonts used for runes this font has no glyph for
+func (f *Font) Fallbacks() []*Font {
+	return f.fallbacks
+}
+
+// sizedFallbacks returns fallbacks at size, fonts that cannot be resized are kept as is
+func sizedFallbacks(fallbacks []*Font, size float64) []*Font {
+	sized := make([]*Font, len(fallbacks))
+	for i, fb := range fallbacks {
+		sf, err := fb.Size(size)
+		if err != nil {
+			sf = fb
+		}
+		sized[i] = sf
+	}
+	return sized
+}
+
+// charChecker is implemented by faces that draw a missing glyph for runes they lack, e.g. BMFont faces,
+// and can tell which runes they really define
+type charChecker interface {
+	HasChar(r rune) bool
+}
+
+// HasGlyph returns true if the font itself, not its fallbacks, can draw r
+func (f *Font) HasGlyph(r rune) bool {
+	if f.source != nil {
+		gid, err := f.source.font.GlyphIndex(&f.source.buf, r)
+		return err == nil && gid != 0
+	}
+	if c, ok := f.Face.(charChecker); ok {
+		return c.HasChar(r)
+	}
+	_, ok := f.Face.GlyphAdvance(r)
+	return ok
+}
+
+// fontFor returns the first font of the fallback chain that can draw r, or f if none can
+func (f *Font) fontFor(r rune) *Font {
+	if len(f.fallbacks) == 0 || f.HasGlyph(r) || unicode.IsSpace(r) {
+		return f
+	}
+	for _, fb := range f.fallbacks {
+		if fb.HasGlyph(r) {
+			return fb
+		}
+	}
+	return f
+}
+
+// fontRun is part of a line drawn with a single font of the fallback chain
+type fontRun struct {
+	font *Font
+	text string
+}
+
+// splitByFont splits line into runs of the fallback chain
+func (f *Font) splitByFont(line string) []fontRun {
+	var runs []fontRun
+	start := 0
+	var current *Font
+	for i, r := range line {
+		rf := f.fontFor(r)
+		if current != nil && rf != current {
+			runs = append(runs, fontRun{font: current, text: line[start:i]})
+			start = i
+		}
+		current = rf
+	}
+	if current != nil {
+		runs = append(runs, fontRun{font: current, text: line[start:]})
+	}
+	return runs
+}
+
+// isShapeable returns true if the font is backed by TTF or OTF data the shaper can read
+func (f *Font) isShapeable() bool {
+	return f.source != nil && f.source.shapingFace() != nil
+}
+
+// fontmap picks shaping faces from a fallback chain
+type fontmap struct {
+	font *Font
+}
+
+// ResolveFace returns the shaping face of the first font in the chain that can draw r
+func (m fontmap) ResolveFace(r rune) *gotext.Face {
+	rf := m.font.fontFor(r)
+	if !rf.isShapeable() {
+		rf = m.font
+	}
+	return rf.source.shapingFace()
+}
+
+// shapedRun is a run of glyphs shaped with a single font, in visual order
+type shapedRun struct {
+	font   *Font
+	output shaping.Output
+}
+
+// shape segments line by direction, script and font, and shapes each segment.
+// Runs are returned in visual order, and cached
+func (f *Font) shape(line string) []shapedRun {
+	e := f.cache.entry(line)
+	f.cache.record(e.isShaped)
+	if !e.isShaped {
+		e.shaped = f.shapeLine(line)
+		e.isShaped = true
+	}
+	return e.shaped
+}
+
+// shapeLine shapes a line without caching
+func (f *Font) shapeLine(line string) []shapedRun {
+	if f.shaper == nil {
+		f.shaper = &shaping.HarfbuzzShaper{}
+		f.segmenter = &shaping.Segmenter{}
+		f.shapingFaces = make(map[*gotext.Face]*Font)
+	}
+	for _, fb := range append([]*Font{f}, f.fallbacks...) {
+		if fb.isShapeable() {
+			f.shapingFaces[fb.source.shapingFace()] = fb
+		}
+	}
+
+	runes := []rune(line)
+	input := shaping.Input{
+		Text:      runes,
+		RunStart:  0,
+		RunEnd:    len(runes),
+		Direction: di.DirectionLTR,
+		Size:      fixed.Int26_6(f.ppem() * 64),
+	}
+	isRTL := isRightToLeft(line)
+	if isRTL {
+		input.Direction = di.DirectionRTL
+	}
+	if f.Language != language.Und {
+		input.Language = gtlanguage.NewLanguage(f.Language.String())
+	}
+
+	segments := f.segmenter.Split(input, fontmap{font: f})
+	runs := make([]shapedRun, 0, len(segments))
+	for _, seg := range segments {
+		sf := f.shapingFaces[seg.Face]
+		if sf == nil {
+			sf = f
+		}
+		seg.Size = fixed.Int26_6(sf.ppem() * 64)
+		runs = append(runs, shapedRun{font: sf, output: f.shaper.Shape(seg)})
+	}
+	return reorderRuns(runs, isRTL)
+}
+
+// reorderRuns puts logically ordered runs in visual order. Runs against the paragraph
+// direction keep their position as a group, with their order reversed
+func reorderRuns(runs []shapedRun, isRTL bool) []shapedRun {
+	if isRTL {
+		reverseRuns(runs)
+	}
+	for i := 0; i < len(runs); {
+		j := i
+		for j < len(runs) && (runs[j].output.Direction.Progression() == di.TowardTopLeft) != isRTL {
+			j++
+		}
+		if j > i {
+			reverseRuns(runs[i:j])
+			i = j
+			continue
+		}
+		i++
+	}
+	return runs
+}
+
+func reverseRuns(runs []shapedRun) {
+	for i, j := 0, len(runs)-1; i < j; i, j = i+1, j-1 {
+		runs[i], runs[j] = runs[j], runs[i]
+	}
+}
+
+// ppem returns the pixels per em the face is rasterized at
+func (f *Font) ppem() float64 {
+	return f.Options.Size * f.scale * f.Options.DPI / 72
+}
+
+// advance returns the width of a single line in pixels, shaping and using fallbacks as needed.
+// Results are cached
+func (f *Font) advance(line string) int {
+	e := f.cache.entry(line)
+	f.cache.record(e.isWidthSet)
+	if !e.isWidthSet {
+		e.width = f.measureLine(line)
+		e.isWidthSet = true
+	}
+	return e.width
+}
+
+// measureLine returns the width of a single line in pixels without caching
+func (f *Font) measureLine(line string) int {
+	if needsShaping(line) && f.isShapeable() {
+		total := fixed.Int26_6(0)
+		for _, r := range f.shape(line) {
+			total += r.output.Advance
+		}
+		return total.Ceil()
+	}
+	if len(f.fallbacks) == 0 {
+		_, a := f.MeasureString(line)
+		return a.Ceil()
+	}
+	total := 0
+	for _, r := range f.splitByFont(line) {
+		_, a := r.font.MeasureString(r.text)
+		total += a.Ceil()
+	}
+	return total
+}
+
+// drawLine draws a single line with its baseline at y, shaping and using fallbacks as needed
+func (f *Font) drawLine(dst *ebiten.Image, line string, x, y int, clr color.Color) {
+	if needsShaping(line) && f.isShapeable() {
+		f.drawShaped(dst, line, x, y, clr)
+		return
+	}
+	if len(f.fallbacks) == 0 {
+		text.Draw(dst, line, f.Face, x, y, clr)
+		return
+	}
+	for _, r := range f.splitByFont(line) {
+		text.Draw(dst, r.text, r.font.Face, x, y, clr)
+		_, a := r.font.MeasureString(r.text)
+		x += a.Ceil()
+	}
+}
+
+// drawShaped draws shaped glyphs with the baseline at y
+func (f *Font) drawShaped(dst *ebiten.Image, line string, x, y int, clr color.Color) {
+	op := &ebiten.DrawImageOptions{}
+	dot := fixed.I(x)
+	for _, run := range f.shape(line) {
+		for _, g := range run.output.Glyphs {
+			img := run.font.glyphImage(g.GlyphID)
+			if img != nil {
+				op.GeoM.Reset()
+				op.GeoM.Translate(float64((dot+g.XOffset).Floor()+img.bounds.Min.X), float64(y-g.YOffset.Floor()+img.bounds.Min.Y))
+				op.ColorM.Reset()
+				op.ColorM.Scale(ColorToScale(clr))
+				dst.DrawImage(img.image, op)
+			}
+			dot += g.XAdvance
+		}
+	}
+}
+
+// glyphImage is a rasterized glyph, bounds are relative to the dot
+type glyphImage struct {
+	image  *ebiten.Image
+	bounds image.Rectangle
+}
+
+// glyphImage returns the glyph rasterized at the font size, caching it
+func (f *Font) glyphImage(gid gotext.GID) *glyphImage {
+	img, ok := f.glyphs[gid]
+	if ok {
+		return img
+	}
+	if f.glyphs == nil {
+		f.glyphs = make(map[gotext.GID]*glyphImage)
+	}
+	img = f.rasterize(gid)
+	f.glyphs[gid] = img
+	return img
+}
+
+// rasterize draws a glyph outline into an image, nil for empty glyphs
+func (f *Font) rasterize(gid gotext.GID) *glyphImage {
+	src := f.source
+	ppem := fixed.Int26_6(f.ppem() * 64)
+	segs, err := src.font.LoadGlyph(&src.buf, sfnt.GlyphIndex(gid), ppem, nil)
+	if err != nil || len(segs) == 0 {
+		return nil
+	}
+	b := segs.Bounds()
+	bounds := image.Rect(b.Min.X.Floor(), b.Min.Y.Floor(), b.Max.X.Ceil(), b.Max.Y.Ceil())
+	if bounds.Empty() {
+		return nil
+	}
+
+	ox := float32(bounds.Min.X)
+	oy := float32(bounds.Min.Y)
+	pt := func(p fixed.Point26_6) (float32, float32) {
+		return float32(p.X)/64 - ox, float32(p.Y)/64 - oy
+	}
+	r := vector.NewRasterizer(bounds.Dx(), bounds.Dy())
+	r.DrawOp = draw.Src
+	for _, seg := range segs {
+		switch seg.Op {
+		case sfnt.SegmentOpMoveTo:
+			r.MoveTo(pt(seg.Args[0]))
+		case sfnt.SegmentOpLineTo:
+			r.LineTo(pt(seg.Args[0]))
+		case sfnt.SegmentOpQuadTo:
+			x1, y1 := pt(seg.Args[0])
+			x2, y2 := pt(seg.Args[1])
+			r.QuadTo(x1, y1, x2, y2)
+		case sfnt.SegmentOpCubeTo:
+			x1, y1 := pt(seg.Args[0])
+			x2, y2 := pt(seg.Args[1])
+			x3, y3 := pt(seg.Args[2])
+			r.CubeTo(x1, y1, x2, y2, x3, y3)
+		}
+	}
+	r.ClosePath()
+	mask := image.NewAlpha(image.Rect(0, 0, bounds.Dx(), bounds.Dy()))
+	r.Draw(mask, mask.Bounds(), image.Opaque, image.Point{})
+
+	eimg, err := ebiten.NewImageFromImage(mask, ebiten.FilterDefault)
+	if err != nil {
+		return nil
+	}
+	return &glyphImage{image: eimg, bounds: bounds}
+}
+
+// clearGlyphs disposes rasterized glyphs, e.g. after the scale changes
+func (f *Font) clearGlyphs() {
+	for _, img := range f.glyphs {
+		if img != nil {
+			img.image.Dispose()
+		}
+	}
+	f.glyphs = nil
+}
+
+// shapingFace returns the face used by the shaper, parsing it on first use. nil if the data cannot be read
+func (fs *FontSource) shapingFace() *gotext.Face {
+	if fs.isShapingParsed {
+		return fs.shaping
+	}
+	fs.isShapingParsed = true
+	faces, err := gotext.ParseTTC(bytes.NewReader(fs.data))
+	if err != nil || fs.index >= len(faces) {
+		return nil
+	}
+	fs.shaping = faces[fs.index]
+	return fs.shaping
+}
@@ -0,0 +1,147 @@
+package common
+
+import (
+	"testing"
+
+	"github.com/go-text/typesetting/di"
+	"golang.org/x/image/math/fixed"
+)
+
+func TestSplitByFont(t *testing.T) {
+	f := NewFontFromFace("latin", &testFace{advance: 10, runes: "abc"})
+	greek := NewFontFromFace("greek", &testFace{advance: 20, runes: "αβ"})
+	symbols := NewFontFromFace("symbols", &testFace{advance: 30, runes: "αβ★"})
+	f.SetFallbacks(greek, symbols)
+
+	tests := []struct {
+		line  string
+		runs  []string
+		fonts []*Font
+		width int
+	}{
+		{"abc", []string{"abc"}, []*Font{f}, 30},
+		{"aαb", []string{"a", "α", "b"}, []*Font{f, greek, f}, 40},
+		{"αβ★", []string{"αβ", "★"}, []*Font{greek, symbols}, 70},
+		// spaces are drawn with the font itself
+		{"α β", []string{"α", " ", "β"}, []*Font{greek, f, greek}, 50},
+		// runes no font has are drawn with the first
+		{"a?", []string{"a?"}, []*Font{f}, 20},
+	}
+	for _, tt := range tests {
+		runs := f.splitByFont(tt.line)
+		if len(runs) != len(tt.runs) {
+			t.Errorf("%q: %d runs, want %d", tt.line, len(runs), len(tt.runs))
+			continue
+		}
+		for i, r := range runs {
+			if r.text != tt.runs[i] || r.font != tt.fonts[i] {
+				t.Errorf("%q: run %d is %q in %s, want %q in %s", tt.line, i, r.text, r.font.Name, tt.runs[i], tt.fonts[i].Name)
+			}
+		}
+		if w := f.advance(tt.line); w != tt.width {
+			t.Errorf("%q: width %d, want %d", tt.line, w, tt.width)
+		}
+	}
+}
+
+func TestSplitByFontWithoutFallbacks(t *testing.T) {
+	f := NewFontFromFace("latin", &testFace{advance: 10, runes: "abc"})
+	runs := f.splitByFont("aα")
+	if len(runs) != 1 || runs[0].text != "aα" || runs[0].font != f {
+		t.Errorf("got %v, want a single run", runs)
+	}
+}
+
+func TestHasGlyph(t *testing.T) {
+	f := NewFontFromFace("latin", &testFace{advance: 10, runes: "abc"})
+	if !f.HasGlyph('a') || f.HasGlyph('α') {
+		t.Errorf("has a %v, has α %v, want true, false", f.HasGlyph('a'), f.HasGlyph('α'))
+	}
+	// faces with a missing glyph report every rune, unless they can tell which they define
+	bm := NewFontFromFace("bm", &testCharFace{testFace: testFace{advance: 10, runes: "abc"}})
+	if !bm.HasGlyph('a') || bm.HasGlyph('α') {
+		t.Errorf("char checker has a %v, has α %v, want true, false", bm.HasGlyph('a'), bm.HasGlyph('α'))
+	}
+}
+
+// testCharFace draws a missing glyph for runes it lacks, like BMFont faces
+type testCharFace struct {
+	testFace
+}
+
+func (f *testCharFace) GlyphAdvance(r rune) (fixed.Int26_6, bool) {
+	return fixed.I(f.advance), true
+}
+
+func (f *testCharFace) HasChar(r rune) bool {
+	return f.has(r)
+}
+
+func TestNeedsShaping(t *testing.T) {
+	tests := []struct {
+		line string
+		want bool
+	}{
+		{"hello", false},
+		{"héllo αβγ", false},
+		{"שלום", true},
+		{"مرحبا", true},
+		{"नमस्ते", true},
+		{"สวัสดี", true},
+		{"abc שלום", true},
+	}
+	for _, tt := range tests {
+		if got := needsShaping(tt.line); got != tt.want {
+			t.Errorf("%q: got %v, want %v", tt.line, got, tt.want)
+		}
+	}
+}
+
+func TestIsRightToLeft(t *testing.T) {
+	tests := []struct {
+		line string
+		want bool
+	}{
+		{"hello", false},
+		{"שלום", true},
+		{"مرحبا", true},
+		// the first strong character decides
+		{"123 שלום abc", true},
+		{"abc שלום", false},
+		{"123", false},
+	}
+	for _, tt := range tests {
+		if got := isRightToLeft(tt.line); got != tt.want {
+			t.Errorf("%q: got %v, want %v", tt.line, got, tt.want)
+		}
+	}
+}
+
+func TestReorderRuns(t *testing.T) {
+	tests := []struct {
+		name  string
+		dirs  []di.Direction
+		isRTL bool
+		want  []int
+	}{
+		{"left to right", []di.Direction{di.DirectionLTR, di.DirectionLTR}, false, []int{0, 1}},
+		{"right to left", []di.Direction{di.DirectionRTL, di.DirectionRTL}, true, []int{1, 0}},
+		{"embedded right to left", []di.Direction{di.DirectionLTR, di.DirectionRTL, di.DirectionRTL, di.DirectionLTR}, false, []int{0, 2, 1, 3}},
+		{"embedded left to right", []di.Direction{di.DirectionRTL, di.DirectionLTR, di.DirectionLTR, di.DirectionRTL}, true, []int{3, 1, 2, 0}},
+	}
+	for _, tt := range tests {
+		runs := make([]shapedRun, len(tt.dirs))
+		fonts := make([]*Font, len(tt.dirs))
+		for i, d := range tt.dirs {
+			fonts[i] = &Font{}
+			runs[i].font = fonts[i]
+			runs[i].output.Direction = d
+		}
+		runs = reorderRuns(runs, tt.isRTL)
+		for i, want := range tt.want {
+			if runs[i].font != fonts[want] {
+				t.Errorf("%s: wrong run at %d, want logical run %d", tt.name, i, want)
+			}
+		}
+	}
+}
@@ -0,0 +1,58 @@
+package common
+
+const ellipsis = "…"
+
+// TextAlign is the horizontal alignment of text within a layout box
+type TextAlign int
+
+// Horizontal text alignments
+const (
+	TextAlignLeft TextAlign = iota
+	TextAlignCenter
+	TextAlignRight
+)
+
+// TextVAlign is the vertical alignment of text within a layout box
+type TextVAlign int
+
+// Vertical text alignments
+const (
+	TextAlignTop TextVAlign = iota
+	TextAlignMiddle
+	TextAlignBottom
+)
+
+// TextLayout describes the box text is drawn in. A Width or Height of 0 leaves that axis
+// unbounded, and alignment on it anchors at the draw position
+type TextLayout struct {
+	Width  float64
+	Height float64
+	Align  TextAlign
+	VAlign TextVAlign
+	// IsWrapped breaks lines longer than Width at spaces
+	IsWrapped bool
+	// IsEllipsis cuts lines longer than Width, and text taller than Height, with an ellipsis
+	IsEllipsis bool
+}
+
+// alignX returns how far a line w pixels wide moves from the left of the box
+func (l *TextLayout) alignX(w float64) float64 {
+	switch l.Align {
+	case TextAlignCenter:
+		return (l.Width - w) / 2
+	case TextAlignRight:
+		return l.Width - w
+	}
+	return 0
+}
+
+// alignY returns how far lines h pixels tall move from the top of the box
+func (l *TextLayout) alignY(h float64) float64 {
+	switch l.VAlign {
+	case TextAlignMiddle:
+		return (l.Height - h) / 2
+	case TextAlignBottom:
+		return l.Height - h
+	}
+	return 0
+}
@@ -0,0 +1,123 @@
+package common
+
+import (
+	"container/list"
+
+	"golang.org/x/image/math/fixed"
+)
+
+// DefaultTextCacheLimit is how many measured strings a font keeps before evicting the least recently used
+const DefaultTextCacheLimit = 1024
+
+// CacheStats counts lookups of a cache
+type CacheStats struct {
+	Hits      uint64
+	Misses    uint64
+	Evictions uint64
+	// Entries is how many items are currently cached
+	Entries int
+}
+
+// Add returns the sum of two stats
+func (s CacheStats) Add(o CacheStats) CacheStats {
+	return CacheStats{
+		Hits:      s.Hits + o.Hits,
+		Misses:    s.Misses + o.Misses,
+		Evictions: s.Evictions + o.Evictions,
+		Entries:   s.Entries + o.Entries,
+	}
+}
+
+// HitRate returns the fraction of lookups that were hits, 0 to 1
+func (s CacheStats) HitRate() float64 {
+	total := s.Hits + s.Misses
+	if total == 0 {
+		return 0
+	}
+	return float64(s.Hits) / float64(total)
+}
+
+// textCache is a least recently used cache of measured and shaped lines
+type textCache struct {
+	limit   int
+	entries map[string]*list.Element
+	order   *list.List
+	stats   CacheStats
+}
+
+// textCacheEntry holds everything measured about a line, each field is filled on first use
+type textCacheEntry struct {
+	key        string
+	bounds     fixed.Rectangle26_6
+	advance    fixed.Int26_6
+	isMeasured bool
+	width      int
+	isWidthSet bool
+	shaped     []shapedRun
+	isShaped   bool
+}
+
+func newTextCache(limit int) *textCache {
+	return &textCache{
+		limit:   limit,
+		entries: make(map[string]*list.Element),
+		order:   list.New(),
+	}
+}
+
+// entry returns the entry for key, creating it and evicting the oldest entries if needed
+func (c *textCache) entry(key string) *textCacheEntry {
+	el, ok := c.entries[key]
+	if ok {
+		c.order.MoveToFront(el)
+		return el.Value.(*textCacheEntry)
+	}
+	e := &textCacheEntry{key: key}
+	c.entries[key] = c.order.PushFront(e)
+	c.trim()
+	return e
+}
+
+// record counts a lookup
+func (c *textCache) record(isHit bool) {
+	if isHit {
+		c.stats.Hits++
+		return
+	}
+	c.stats.Misses++
+}
+
+// trim evicts least recently used entries past the limit
+func (c *textCache) trim() {
+	for c.limit > 0 && c.order.Len() > c.limit {
+		el := c.order.Back()
+		c.order.Remove(el)
+		delete(c.entries, el.Value.(*textCacheEntry).key)
+		c.stats.Evictions++
+	}
+}
+
+// clear drops every entry, e.g. when the face changes
+func (c *textCache) clear() {
+	c.entries = make(map[string]*list.Element)
+	c.order.Init()
+}
+
+// SetCacheLimit sets how many measured strings the font keeps, 0 is unlimited
+func (f *Font) SetCacheLimit(limit int) {
+	f.cache.limit = limit
+	f.cache.trim()
+}
+
+// CacheStats returns lookup statistics of measured strings
+func (f *Font) CacheStats() CacheStats {
+	s := f.cache.stats
+	s.Entries = f.cache.order.Len()
+	return s
+}
+
+// ClearCache drops every measured string and resets statistics
+func (f *Font) ClearCache() {
+	f.cache.clear()
+	f.cache.stats = CacheStats{}
+}
@@ -0,0 +1,99 @@
+package common
+
+import "testing"
+
+func TestTextCacheStats(t *testing.T) {
+	f := newTestFont()
+	for _, s := range []string{"a", "b", "a", "a"} {
+		f.MeasureString(s)
+	}
+	want := CacheStats{Hits: 2, Misses: 2, Entries: 2}
+	if got := f.CacheStats(); got != want {
+		t.Errorf("stats %+v, want %+v", got, want)
+	}
+	if rate := f.CacheStats().HitRate(); rate != 0.5 {
+		t.Errorf("hit rate %v, want 0.5", rate)
+	}
+
+	f.ClearCache()
+	if got := f.CacheStats(); got != (CacheStats{}) {
+		t.Errorf("after clear %+v, want empty", got)
+	}
+}
+
+func TestTextCacheEviction(t *testing.T) {
+	tests := []struct {
+		name      string
+		limit     int
+		lookups   []string
+		misses    uint64
+		evictions uint64
+		entries   int
+	}{
+		{"within limit", 2, []string{"a", "b", "a", "b"}, 2, 0, 2},
+		{"evicts oldest", 2, []string{"a", "b", "c", "a"}, 4, 2, 2},
+		// looking up a moves it to the front, so b is evicted instead
+		{"keeps recently used", 2, []string{"a", "b", "a", "c", "a"}, 3, 1, 2},
+		{"unlimited", 0, []string{"a", "b", "c", "a"}, 3, 0, 3},
+	}
+	for _, tt := range tests {
+		f := newTestFont()
+		f.SetCacheLimit(tt.limit)
+		for _, s := range tt.lookups {
+			f.MeasureString(s)
+		}
+		s := f.CacheStats()
+		if s.Misses != tt.misses || s.Evictions != tt.evictions || s.Entries != tt.entries {
+			t.Errorf("%s: misses %d evictions %d entries %d, want %d %d %d", tt.name,
+				s.Misses, s.Evictions, s.Entries, tt.misses, tt.evictions, tt.entries)
+		}
+	}
+}
+
+func TestTextCacheSetLimitTrims(t *testing.T) {
+	f := newTestFont()
+	for _, s := range []string{"a", "b", "c"} {
+		f.MeasureString(s)
+	}
+	f.SetCacheLimit(1)
+	s := f.CacheStats()
+	if s.Entries != 1 || s.Evictions != 2 {
+		t.Errorf("entries %d evictions %d, want 1 2", s.Entries, s.Evictions)
+	}
+	// c was used last, so it is kept
+	f.MeasureString("c")
+	if s := f.CacheStats(); s.Hits != 1 {
+		t.Errorf("hits %d, want 1", s.Hits)
+	}
+}
+
+func TestTextCacheAdvance(t *testing.T) {
+	f := newTestFont()
+	if w := f.advance("abc"); w != 30 {
+		t.Errorf("width %d, want 30", w)
+	}
+	before := f.CacheStats()
+	f.advance("abc")
+	after := f.CacheStats()
+	if after.Hits != before.Hits+1 || after.Misses != before.Misses {
+		t.Errorf("second advance was not a single hit: %+v then %+v", before, after)
+	}
+
+	// fallbacks change widths, so they drop cached lines
+	f.SetFallbacks(newTestFont())
+	if s := f.CacheStats(); s.Entries != 0 {
+		t.Errorf("entries %d after setting fallbacks, want 0", s.Entries)
+	}
+}
+
+func TestCacheStatsAdd(t *testing.T) {
+	a := CacheStats{Hits: 1, Misses: 2, Evictions: 3, Entries: 4}
+	b := CacheStats{Hits: 10, Misses: 20, Evictions: 30, Entries: 40}
+	want := CacheStats{Hits: 11, Misses: 22, Evictions: 33, Entries: 44}
+	if got := a.Add(b); got != want {
+		t.Errorf("got %+v, want %+v", got, want)
+	}
+	if rate := (CacheStats{}).HitRate(); rate != 0 {
+		t.Errorf("empty hit rate %v, want 0", rate)
+	}
+}
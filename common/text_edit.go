@@ -0,0 +1,391 @@
+package common
+
+import (
+	"regexp"
+	"unicode"
+)
+
+// TextFilter returns true if text is allowed as the content of an input
+type TextFilter func(text string) bool
+
+var (
+	numericPattern = regexp.MustCompile(`^-?[0-9]*\.?[0-9]*$`)
+	integerPattern = regexp.MustCompile(`^-?[0-9]*$`)
+)
+
+// FilterNumeric allows decimal numbers, including partial ones such as "-" or "1."
+func FilterNumeric(text string) bool {
+	return numericPattern.MatchString(text)
+}
+
+// FilterInteger allows whole numbers, including a lone "-"
+func FilterInteger(text string) bool {
+	return integerPattern.MatchString(text)
+}
+
+// FilterRegexp allows text matching re. The whole text is matched, so anchor re to
+// accept partial input as it is typed, e.g. `^[A-Za-z]{0,12}$`
+func FilterRegexp(re *regexp.Regexp) TextFilter {
+	return func(text string) bool {
+		return re.MatchString(text)
+	}
+}
+
+// maxTextEditHistory is how many edits can be undone
+const maxTextEditHistory = 200
+
+// TextEdit is editable text with a caret, selection and undo history, measured in runes.
+// It holds no input or drawing state, so text elements share it
+type TextEdit struct {
+	runes []rune
+	caret int
+	// anchor is where the selection started, equal to caret when nothing is selected
+	anchor    int
+	maxLength int
+	filter    TextFilter
+	undo      []textEditState
+	redo      []textEditState
+	// lastEdit is the kind of the last single rune edit, so typing a word undoes as one step
+	lastEdit textEditKind
+	// revision counts changes to the text
+	revision int
+}
+
+// textEditState is a snapshot of text and caret for undo
+type textEditState struct {
+	runes  []rune
+	caret  int
+	anchor int
+}
+
+// textEditKind groups consecutive edits into a single undo step
+type textEditKind int
+
+const (
+	textEditNone = textEditKind(iota)
+	textEditInsert
+	textEditDelete
+)
+
+// NewTextEdit returns an edit containing text with the caret at the end
+func NewTextEdit(text string) *TextEdit {
+	te := &TextEdit{}
+	te.SetText(text)
+	return te
+}
+
+// Text returns the edited text
+func (te *TextEdit) Text() string {
+	return string(te.runes)
+}
+
+// SetText replaces the text, cut to the max length, and moves the caret to the end.
+// The filter is not applied, so code may set any text
+func (te *TextEdit) SetText(text string) {
+	te.ClearHistory()
+	te.revision++
+	te.runes = []rune(text)
+	if te.maxLength > 0 && len(te.runes) > te.maxLength {
+		te.runes = te.runes[:te.maxLength]
+	}
+	te.caret = len(te.runes)
+	te.anchor = te.caret
+}
+
+// Revision returns a count that changes whenever the text does, so callers can cache what they derive from it
+// without comparing text
+func (te *TextEdit) Revision() int {
+	return te.revision
+}
+
+// Slice returns the text from rune index start to end
+func (te *TextEdit) Slice(start int, end int) string {
+	return string(te.runes[te.clamp(start):te.clamp(end)])
+}
+
+// RuneAt returns the rune at index pos
+func (te *TextEdit) RuneAt(pos int) rune {
+	return te.runes[pos]
+}
+
+// Len returns the length of text in runes
+func (te *TextEdit) Len() int {
+	return len(te.runes)
+}
+
+// MaxLength returns the most runes text may hold, 0 is unlimited
+func (te *TextEdit) MaxLength() int {
+	return te.maxLength
+}
+
+// SetMaxLength sets the most runes text may hold, 0 is unlimited. Longer text is cut
+func (te *TextEdit) SetMaxLength(maxLength int) {
+	te.maxLength = maxLength
+	if maxLength > 0 && len(te.runes) > maxLength {
+		te.revision++
+		te.runes = te.runes[:maxLength]
+		te.caret = te.clamp(te.caret)
+		te.anchor = te.clamp(te.anchor)
+	}
+}
+
+// SetFilter sets a filter edits must pass, nil allows anything
+func (te *TextEdit) SetFilter(filter TextFilter) {
+	te.filter = filter
+}
+
+// Caret returns the rune index of the caret
+func (te *TextEdit) Caret() int {
+	return te.caret
+}
+
+// SetCaret moves the caret to pos. If isSelecting, the selection is extended to pos instead of cleared
+func (te *TextEdit) SetCaret(pos int, isSelecting bool) {
+	te.lastEdit = textEditNone
+	te.caret = te.clamp(pos)
+	if !isSelecting {
+		te.anchor = te.caret
+	}
+}
+
+// Selection returns the start and end rune index of the selection, equal when nothing is selected
+func (te *TextEdit) Selection() (int, int) {
+	if te.anchor < te.caret {
+		return te.anchor, te.caret
+	}
+	return te.caret, te.anchor
+}
+
+// SetSelection selects from start to end, leaving the caret at end
+func (te *TextEdit) SetSelection(start int, end int) {
+	te.lastEdit = textEditNone
+	te.anchor = te.clamp(start)
+	te.caret = te.clamp(end)
+}
+
+// HasSelection returns true if any text is selected
+func (te *TextEdit) HasSelection() bool {
+	return te.anchor != te.caret
+}
+
+// SelectedText returns the selected text
+func (te *TextEdit) SelectedText() string {
+	start, end := te.Selection()
+	return string(te.runes[start:end])
+}
+
+// SelectAll selects all text
+func (te *TextEdit) SelectAll() {
+	te.lastEdit = textEditNone
+	te.anchor = 0
+	te.caret = len(te.runes)
+}
+
+// Insert replaces the selection with text at the caret, cutting text to fit the max length.
+// It returns false if the filter rejects the result, leaving the edit unchanged
+func (te *TextEdit) Insert(text string) bool {
+	start, end := te.Selection()
+	insert := []rune(text)
+	if te.maxLength > 0 {
+		room := te.maxLength - (len(te.runes) - (end - start))
+		if room < 0 {
+			room = 0
+		}
+		if len(insert) > room {
+			insert = insert[:room]
+		}
+	}
+	if len(insert) == 0 && start == end {
+		return false
+	}
+	return te.replace(start, end, insert)
+}
+
+// DeleteBackward removes the selection, or the rune or word before the caret
+func (te *TextEdit) DeleteBackward(isWord bool) bool {
+	if te.HasSelection() {
+		start, end := te.Selection()
+		return te.replace(start, end, nil)
+	}
+	if te.caret == 0 {
+		return false
+	}
+	start := te.caret - 1
+	if isWord {
+		start = te.WordStart(te.caret)
+	}
+	return te.replace(start, te.caret, nil)
+}
+
+// DeleteForward removes the selection, or the rune or word after the caret
+func (te *TextEdit) DeleteForward(isWord bool) bool {
+	if te.HasSelection() {
+		start, end := te.Selection()
+		return te.replace(start, end, nil)
+	}
+	if te.caret >= len(te.runes) {
+		return false
+	}
+	end := te.caret + 1
+	if isWord {
+		end = te.WordEnd(te.caret)
+	}
+	return te.replace(te.caret, end, nil)
+}
+
+// MoveLeft moves the caret a rune or word left. Without isSelecting, a selection collapses to its start
+func (te *TextEdit) MoveLeft(isSelecting bool, isWord bool) {
+	if te.HasSelection() && !isSelecting {
+		start, _ := te.Selection()
+		te.SetCaret(start, false)
+		return
+	}
+	pos := te.caret - 1
+	if isWord {
+		pos = te.WordStart(te.caret)
+	}
+	te.SetCaret(pos, isSelecting)
+}
+
+// MoveRight moves the caret a rune or word right. Without isSelecting, a selection collapses to its end
+func (te *TextEdit) MoveRight(isSelecting bool, isWord bool) {
+	if te.HasSelection() && !isSelecting {
+		_, end := te.Selection()
+		te.SetCaret(end, false)
+		return
+	}
+	pos := te.caret + 1
+	if isWord {
+		pos = te.WordEnd(te.caret)
+	}
+	te.SetCaret(pos, isSelecting)
+}
+
+// WordStart returns the start of the word before pos, skipping spaces
+func (te *TextEdit) WordStart(pos int) int {
+	pos = te.clamp(pos)
+	for pos > 0 && unicode.IsSpace(te.runes[pos-1]) {
+		pos--
+	}
+	for pos > 0 && !unicode.IsSpace(te.runes[pos-1]) {
+		pos--
+	}
+	return pos
+}
+
+// WordEnd returns the end of the word after pos, skipping spaces
+func (te *TextEdit) WordEnd(pos int) int {
+	pos = te.clamp(pos)
+	for pos < len(te.runes) && unicode.IsSpace(te.runes[pos]) {
+		pos++
+	}
+	for pos < len(te.runes) && !unicode.IsSpace(te.runes[pos]) {
+		pos++
+	}
+	return pos
+}
+
+// replace swaps runes from start to end with insert if the filter allows the result,
+// leaving the caret after insert
+func (te *TextEdit) replace(start int, end int, insert []rune) bool {
+	runes := make([]rune, 0, len(te.runes)-(end-start)+len(insert))
+	runes = append(runes, te.runes[:start]...)
+	runes = append(runes, insert...)
+	runes = append(runes, te.runes[end:]...)
+	if te.filter != nil && !te.filter(string(runes)) {
+		return false
+	}
+	te.record(start, end, len(insert))
+	te.revision++
+	te.runes = runes
+	te.caret = start + len(insert)
+	te.anchor = te.caret
+	return true
+}
+
+// record saves the text before an edit for undo. Single rune edits of the same kind that follow
+// the last one join its step, so a typed word or a held backspace undoes at once
+func (te *TextEdit) record(start int, end int, inserted int) {
+	kind := textEditNone
+	switch {
+	case end == start && inserted == 1:
+		kind = textEditInsert
+	case end-start == 1 && inserted == 0:
+		kind = textEditDelete
+	}
+	isJoined := kind != textEditNone && kind == te.lastEdit && !te.HasSelection() &&
+		(start == te.caret || end == te.caret)
+	te.lastEdit = kind
+	te.redo = nil
+	if isJoined {
+		return
+	}
+	te.undo = append(te.undo, te.state())
+	if len(te.undo) > maxTextEditHistory {
+		te.undo = te.undo[1:]
+	}
+}
+
+// state returns a snapshot of the text and caret
+func (te *TextEdit) state() textEditState {
+	return textEditState{runes: te.runes, caret: te.caret, anchor: te.anchor}
+}
+
+// restore applies a snapshot
+func (te *TextEdit) restore(s textEditState) {
+	te.revision++
+	te.runes = s.runes
+	te.caret = s.caret
+	te.anchor = s.anchor
+	te.lastEdit = textEditNone
+}
+
+// CanUndo returns true if there is an edit to undo
+func (te *TextEdit) CanUndo() bool {
+	return len(te.undo) > 0
+}
+
+// CanRedo returns true if there is an undone edit to redo
+func (te *TextEdit) CanRedo() bool {
+	return len(te.redo) > 0
+}
+
+// Undo reverts the last edit, returning false if there is none
+func (te *TextEdit) Undo() bool {
+	if len(te.undo) == 0 {
+		return false
+	}
+	te.redo = append(te.redo, te.state())
+	te.restore(te.undo[len(te.undo)-1])
+	te.undo = te.undo[:len(te.undo)-1]
+	return true
+}
+
+// Redo applies the last undone edit again, returning false if there is none
+func (te *TextEdit) Redo() bool {
+	if len(te.redo) == 0 {
+		return false
+	}
+	te.undo = append(te.undo, te.state())
+	te.restore(te.redo[len(te.redo)-1])
+	te.redo = te.redo[:len(te.redo)-1]
+	return true
+}
+
+// ClearHistory forgets all edits, so they can no longer be undone
+func (te *TextEdit) ClearHistory() {
+	te.undo = nil
+	te.redo = nil
+	te.lastEdit = textEditNone
+}
+
+// clamp keeps pos within text
+func (te *TextEdit) clamp(pos int) int {
+	if pos < 0 {
+		return 0
+	}
+	if pos > len(te.runes) {
+		return len(te.runes)
+	}
+	return pos
+}
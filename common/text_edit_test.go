@@ -0,0 +1,76 @@
+package common
+
+import "testing"
+
+func TestTextEditUndoRedo(t *testing.T) {
+	te := NewTextEdit("")
+	for _, r := range "hello" {
+		te.Insert(string(r))
+	}
+	te.Insert(" ")
+	te.Insert("world")
+	te.DeleteBackward(false)
+	te.DeleteBackward(false)
+
+	// typed runes and held backspaces each undo as one step
+	steps := []string{"hello world", "hello ", ""}
+	for _, want := range steps {
+		if !te.Undo() {
+			t.Fatalf("undo to %q failed", want)
+		}
+		if te.Text() != want {
+			t.Errorf("undo: %q, want %q", te.Text(), want)
+		}
+	}
+	if te.CanUndo() || te.Undo() {
+		t.Errorf("undo past the start")
+	}
+	for i := len(steps) - 2; i >= 0; i-- {
+		te.Redo()
+		if te.Text() != steps[i] {
+			t.Errorf("redo: %q, want %q", te.Text(), steps[i])
+		}
+	}
+	te.Redo()
+	if te.Text() != "hello wor" || te.CanRedo() {
+		t.Errorf("redo to the end: %q", te.Text())
+	}
+
+	// a new edit drops redo
+	te.Undo()
+	te.Insert("!")
+	if te.CanRedo() {
+		t.Errorf("redo kept after an edit")
+	}
+}
+
+func TestTextEditFilter(t *testing.T) {
+	te := NewTextEdit("")
+	te.SetFilter(FilterInteger)
+	te.SetMaxLength(4)
+	for _, s := range []string{"-", "1", "a", "2", ".", "3", "4"} {
+		te.Insert(s)
+	}
+	if te.Text() != "-123" {
+		t.Errorf("filtered text %q, want -123", te.Text())
+	}
+}
+
+func TestTextEditRevision(t *testing.T) {
+	te := NewTextEdit("abc")
+	rev := te.Revision()
+	te.SetCaret(1, false)
+	te.SetSelection(0, 2)
+	if te.Revision() != rev {
+		t.Errorf("moving the caret changed the revision")
+	}
+	te.Insert("x")
+	if te.Revision() == rev || te.Text() != "xc" {
+		t.Errorf("insert: revision unchanged or text %q", te.Text())
+	}
+	rev = te.Revision()
+	te.Undo()
+	if te.Revision() == rev || te.Text() != "abc" {
+		t.Errorf("undo: revision unchanged or text %q", te.Text())
+	}
+}
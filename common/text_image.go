@@ -0,0 +1,197 @@
+package common
+
+import (
+	"image"
+	"image/color"
+	"math"
+
+	"github.com/hajimehoshi/ebiten"
+	"golang.org/x/image/font"
+)
+
+// maxTextImageSize is the largest offscreen text image, bigger text is drawn directly
+const maxTextImageSize = 4096
+
+var textImageStats CacheStats
+
+// TextImageStats returns how often text images were reused (hits) or rendered again (misses)
+func TextImageStats() CacheStats {
+	return textImageStats
+}
+
+// ResetTextImageStats clears text image hit and miss counts
+func ResetTextImageStats() {
+	entries := textImageStats.Entries
+	textImageStats = CacheStats{Entries: entries}
+}
+
+// TextImage is text pre-rendered to an offscreen image. It renders again only when the font,
+// text, layout or rich text changes, so static labels cost a single image draw per frame.
+// Plain text is rendered white and tinted when drawn, so changing its color is free
+type TextImage struct {
+	image  *ebiten.Image
+	key    textImageKey
+	bounds image.Rectangle
+	// isDirect is true when the text is too large for an offscreen image
+	isDirect bool
+}
+
+// textImageKey is everything that changes how text renders
+type textImageKey struct {
+	font *Font
+	face font.Face
+	// revision is the font revision, or the sum of revisions of rich text fonts
+	revision int
+	text     string
+	layout   TextLayout
+	rich     *RichTextLayout
+	icons    *Image
+	color    color.Color
+}
+
+// DrawText draws text like Font.DrawText, with x, y the top left of the layout box
+func (ti *TextImage) DrawText(dst *ebiten.Image, f *Font, str string, x, y float64, layout *TextLayout, clr color.Color) {
+	if layout == nil {
+		layout = &TextLayout{}
+	}
+	key := textImageKey{font: f, face: f.Face, revision: f.Revision(), text: str, layout: *layout}
+	if key != ti.key || ti.image == nil && !ti.isDirect {
+		ti.key = key
+		ti.render(f.textBounds(str, layout), float64(f.RenderingLineHeight), func(img *ebiten.Image, ox, oy float64) {
+			f.DrawText(img, str, ox, oy, layout, color.White, -1)
+		})
+	} else {
+		textImageStats.Hits++
+	}
+	if ti.isDirect {
+		f.DrawText(dst, str, x, y, layout, clr, -1)
+		return
+	}
+	ti.draw(dst, x, y, clr)
+}
+
+// DrawRichText draws laid out rich text like RichTextLayout.Draw, with x, y the top left of the layout box.
+// Rich text is rendered in color, so changing clr renders it again
+func (ti *TextImage) DrawRichText(dst *ebiten.Image, rl *RichTextLayout, icons *Image, x, y float64, clr color.Color) {
+	key := textImageKey{rich: rl, revision: rl.fontRevision(), icons: icons, color: clr}
+	if key != ti.key || ti.image == nil && !ti.isDirect {
+		ti.key = key
+		ti.render(rl.bounds(), rl.lineHeight(), func(img *ebiten.Image, ox, oy float64) {
+			rl.Draw(img, icons, ox, oy, clr, -1)
+		})
+	} else {
+		textImageStats.Hits++
+	}
+	if ti.isDirect {
+		rl.Draw(dst, icons, x, y, clr, -1)
+		return
+	}
+	ti.draw(dst, x, y, color.White)
+}
+
+// render draws content into the offscreen image, reusing it when large enough.
+// bounds is the content relative to the layout box, padded by half a line for overhanging glyphs
+func (ti *TextImage) render(bounds image.Rectangle, lineHeight float64, drawFunc func(img *ebiten.Image, ox, oy float64)) {
+	textImageStats.Misses++
+	pad := int(math.Ceil(lineHeight / 2))
+	bounds = bounds.Inset(-pad)
+	ti.bounds = bounds
+	w, h := bounds.Dx(), bounds.Dy()
+
+	isDirect := w > maxTextImageSize || h > maxTextImageSize
+	if isDirect || w <= 0 || h <= 0 {
+		ti.Dispose()
+		ti.isDirect = isDirect
+		return
+	}
+	ti.isDirect = false
+	if ti.image != nil {
+		iw, ih := ti.image.Size()
+		if iw < w || ih < h {
+			ti.Dispose()
+		}
+	}
+	if ti.image == nil {
+		img, err := ebiten.NewImage(w, h, ebiten.FilterDefault)
+		if err != nil {
+			ti.isDirect = true
+			return
+		}
+		ti.image = img
+		textImageStats.Entries++
+	} else {
+		ti.image.Clear()
+	}
+	drawFunc(ti.image, float64(-bounds.Min.X), float64(-bounds.Min.Y))
+}
+
+// draw copies the rendered image to dst, tinted by clr
+func (ti *TextImage) draw(dst *ebiten.Image, x, y float64, clr color.Color) {
+	if ti.image == nil {
+		return
+	}
+	op := &ebiten.DrawImageOptions{}
+	op.GeoM.Translate(x+float64(ti.bounds.Min.X), y+float64(ti.bounds.Min.Y))
+	op.ColorM.Scale(ColorToScale(clr))
+	dst.DrawImage(ti.image, op)
+}
+
+// Dispose releases the offscreen image, it is rendered again on the next draw
+func (ti *TextImage) Dispose() {
+	if ti.image == nil {
+		return
+	}
+	ti.image.Dispose()
+	ti.image = nil
+	textImageStats.Entries--
+}
+
+// textBounds returns where text lands relative to the top left of its layout box
+func (f *Font) textBounds(str string, layout *TextLayout) image.Rectangle {
+	lines := f.LayoutText(str, layout)
+	w := 0
+	for _, l := range lines {
+		lw := f.advance(l)
+		if lw > w {
+			w = lw
+		}
+	}
+	h := len(lines) * f.RenderingLineHeight
+	ox := 0.0
+	switch layout.Align {
+	case TextAlignCenter:
+		ox = (layout.Width - float64(w)) / 2
+	case TextAlignRight:
+		ox = layout.Width - float64(w)
+	}
+	oy := 0.0
+	switch layout.VAlign {
+	case TextAlignMiddle:
+		oy = (layout.Height - float64(h)) / 2
+	case TextAlignBottom:
+		oy = layout.Height - float64(h)
+	}
+	content := image.Rect(int(math.Floor(ox)), int(math.Floor(oy)), int(math.Ceil(ox))+w, int(math.Ceil(oy))+h)
+	return content.Union(image.Rect(0, 0, int(layout.Width), int(layout.Height)))
+}
+
+// bounds returns where the laid out runs land relative to the top left of the box
+func (rl *RichTextLayout) bounds() image.Rectangle {
+	var b image.Rectangle
+	for _, r := range rl.runs {
+		rb := image.Rect(int(math.Floor(r.x)), int(math.Floor(r.y)), int(math.Ceil(r.x+r.w)), int(math.Ceil(r.y+r.h)))
+		b = b.Union(rb)
+	}
+	return b
+}
+
+// lineHeight returns the tallest run, used to pad rendered images
+func (rl *RichTextLayout) lineHeight() float64 {
+	h := 0.0
+	for _, r := range rl.runs {
+		if r.h > h {
+			h = r.h
+		}
+	}
+	return h
+}
@@ -0,0 +1,166 @@
+package common
+
+import (
+	"image"
+	"reflect"
+	"strings"
+	"testing"
+
+	"golang.org/x/image/font"
+	"golang.org/x/image/math/fixed"
+)
+
+// testFace is a face where every glyph is advance pixels wide. If runes is set, only those runes have glyphs
+type testFace struct {
+	advance int
+	runes   string
+}
+
+func (f *testFace) Close() error { return nil }
+
+func (f *testFace) Glyph(dot fixed.Point26_6, r rune) (image.Rectangle, image.Image, image.Point, fixed.Int26_6, bool) {
+	return image.Rectangle{}, nil, image.Point{}, fixed.I(f.advance), f.has(r)
+}
+
+func (f *testFace) GlyphBounds(r rune) (fixed.Rectangle26_6, fixed.Int26_6, bool) {
+	return fixed.R(0, -8, f.advance, 2), fixed.I(f.advance), f.has(r)
+}
+
+func (f *testFace) GlyphAdvance(r rune) (fixed.Int26_6, bool) {
+	return fixed.I(f.advance), f.has(r)
+}
+
+func (f *testFace) Kern(r0, r1 rune) fixed.Int26_6 { return 0 }
+
+func (f *testFace) Metrics() font.Metrics {
+	return font.Metrics{Height: fixed.I(10), Ascent: fixed.I(8), Descent: fixed.I(2)}
+}
+
+func (f *testFace) has(r rune) bool {
+	return f.runes == "" || strings.ContainsRune(f.runes, r)
+}
+
+// newTestFont returns a font 10 pixels wide per rune and 10 pixels per line
+func newTestFont() *Font {
+	return NewFontFromFace("test", &testFace{advance: 10})
+}
+
+func TestLayoutText(t *testing.T) {
+	tests := []struct {
+		name   string
+		text   string
+		layout *TextLayout
+		want   []string
+	}{
+		{"empty", "", nil, nil},
+		{"unbounded", "ab\ncd", nil, []string{"ab", "cd"}},
+		{"not wrapped", "hello world", &TextLayout{Width: 50}, []string{"hello world"}},
+		{"wrapped at spaces", "hello world foo", &TextLayout{Width: 50, IsWrapped: true}, []string{"hello", "world", "foo"}},
+		{"wrapped long word", "abcdefgh", &TextLayout{Width: 30, IsWrapped: true}, []string{"abc", "def", "gh"}},
+		{"wrapped keeps breaks", "ab cd\nef", &TextLayout{Width: 30, IsWrapped: true}, []string{"ab", "cd", "ef"}},
+		{"height trims", "a\nb\nc", &TextLayout{Height: 25}, []string{"a", "b"}},
+		{"height keeps one line", "a\nb", &TextLayout{Height: 5}, []string{"a"}},
+		{"ellipsis", "abcdefg", &TextLayout{Width: 40, IsEllipsis: true}, []string{"abc…"}},
+		{"ellipsis when cut by height", "a\nb\nc", &TextLayout{Width: 100, Height: 20, IsEllipsis: true}, []string{"a", "b…"}},
+		{"wrapped then ellipsis", "hello world foo", &TextLayout{Width: 50, Height: 20, IsWrapped: true, IsEllipsis: true}, []string{"hello", "worl…"}},
+	}
+	f := newTestFont()
+	for _, tt := range tests {
+		got := f.LayoutText(tt.text, tt.layout)
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s: got %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestWrapText(t *testing.T) {
+	tests := []struct {
+		line  string
+		width int
+		want  []string
+	}{
+		{"fits", 100, []string{"fits"}},
+		{"hello world foo", 50, []string{"hello", "world", "foo"}},
+		{"hello world foo", 90, []string{"hello", "world foo"}},
+		{"abcdefgh", 30, []string{"abc", "def", "gh"}},
+		{"ab abcdefgh", 30, []string{"ab", "abc", "def", "gh"}},
+	}
+	f := newTestFont()
+	for _, tt := range tests {
+		got := f.WrapText(tt.line, tt.width)
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%q at %d: got %q, want %q", tt.line, tt.width, got, tt.want)
+		}
+	}
+}
+
+func TestWrapBreaks(t *testing.T) {
+	tests := []struct {
+		line  string
+		width int
+		want  []int
+	}{
+		{"fits", 100, nil},
+		{"hello world foo", 50, []int{6, 12}},
+		{"abcdefgh", 30, []int{3, 6}},
+		// spaces stay at the end of the line they follow
+		{"ab  cd", 30, []int{4}},
+		{"ab abcdefgh", 30, []int{3, 6, 9}},
+	}
+	f := newTestFont()
+	for _, tt := range tests {
+		got := f.WrapBreaks(tt.line, tt.width)
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%q at %d: got %v, want %v", tt.line, tt.width, got, tt.want)
+		}
+	}
+}
+
+func TestEllipsis(t *testing.T) {
+	tests := []struct {
+		line  string
+		width int
+		want  string
+	}{
+		{"abc", 30, "abc"},
+		{"abcdefg", 40, "abc…"},
+		{"ab cdef", 40, "ab…"},
+		{"abcdef…", 40, "abc…"},
+		{"abcdefg", 10, ""},
+	}
+	f := newTestFont()
+	for _, tt := range tests {
+		if got := f.Ellipsis(tt.line, tt.width); got != tt.want {
+			t.Errorf("%q at %d: got %q, want %q", tt.line, tt.width, got, tt.want)
+		}
+	}
+}
+
+func TestMeasureSize(t *testing.T) {
+	f := newTestFont()
+	w, h := f.MeasureSize("abc\na\n")
+	if w != 30 || h != 20 {
+		t.Errorf("size %d x %d, want 30 x 20", w, h)
+	}
+}
+
+func TestTextLayoutAlign(t *testing.T) {
+	tests := []struct {
+		name   string
+		layout TextLayout
+		x      float64
+		y      float64
+	}{
+		{"top left", TextLayout{Width: 100, Height: 50}, 0, 0},
+		{"center middle", TextLayout{Width: 100, Height: 50, Align: TextAlignCenter, VAlign: TextAlignMiddle}, 35, 15},
+		{"right bottom", TextLayout{Width: 100, Height: 50, Align: TextAlignRight, VAlign: TextAlignBottom}, 70, 30},
+		{"unbounded anchors at draw position", TextLayout{Align: TextAlignRight, VAlign: TextAlignBottom}, -30, -20},
+	}
+	for _, tt := range tests {
+		// a 30 pixel wide line in a 20 pixel tall block
+		x, y := tt.layout.alignX(30), tt.layout.alignY(20)
+		if x != tt.x || y != tt.y {
+			t.Errorf("%s: offset %v, %v, want %v, %v", tt.name, x, y, tt.x, tt.y)
+		}
+	}
+}
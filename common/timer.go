@@ -0,0 +1,82 @@
+package common
+
+import "time"
+
+// Owner is anything that can be destroyed. Timers with an owner cancel themselves once it is
+type Owner interface {
+	IsDestroyed() bool
+}
+
+// Timer calls a function after an interval of game time, optionally repeating
+type Timer struct {
+	interval    float64
+	elapsed     float64
+	fn          func() bool
+	isRepeating bool
+	isCancelled bool
+	isDone      bool
+	owner       Owner
+	count       int
+}
+
+// Cancel stops the timer before it fires again
+func (t *Timer) Cancel() {
+	t.isCancelled = true
+}
+
+// IsCancelled returns true if the timer was cancelled, or its owner was destroyed
+func (t *Timer) IsCancelled() bool {
+	return t.isCancelled
+}
+
+// IsDone returns true once the timer will not fire again
+func (t *Timer) IsDone() bool {
+	return t.isDone || t.isCancelled
+}
+
+// SetOwner ties the timer to an owner, e.g. an element. The timer cancels once the owner is destroyed
+func (t *Timer) SetOwner(owner Owner) {
+	t.owner = owner
+}
+
+// Count returns how many times the timer has fired
+func (t *Timer) Count() int {
+	return t.count
+}
+
+// Remaining returns how much game time is left until the timer next fires
+func (t *Timer) Remaining() time.Duration {
+	if t.IsDone() {
+		return 0
+	}
+	return time.Duration((t.interval - t.elapsed) * float64(time.Second))
+}
+
+// update advances the timer by dt seconds. No time passes while paused, so even zero interval timers wait
+func (t *Timer) update(dt float64) {
+	if t.IsDone() || dt <= 0 {
+		return
+	}
+	if t.owner != nil && t.owner.IsDestroyed() {
+		t.isCancelled = true
+		return
+	}
+	t.elapsed += dt
+	for t.elapsed >= t.interval {
+		t.elapsed -= t.interval
+		t.count++
+		isStopped := t.fn()
+		if !t.isRepeating || isStopped {
+			t.isDone = true
+			return
+		}
+		if t.isCancelled {
+			return
+		}
+		// a zero interval fires once per update that time passes
+		if t.interval <= 0 {
+			t.elapsed = 0
+			return
+		}
+	}
+}
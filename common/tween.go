@@ -0,0 +1,251 @@
+package common
+
+import (
+	"image/color"
+	"reflect"
+	"time"
+)
+
+// Tweener is anything a TweenManager can advance each update
+type Tweener interface {
+	// Update advances by dt seconds and returns true once finished
+	Update(dt float64) bool
+	// Finish jumps to the end and calls end functions
+	Finish()
+	// Kill stops without calling end functions
+	Kill()
+	IsKilled() bool
+	IsFinished() bool
+	// HasTarget returns true if target is animated
+	HasTarget(target interface{}) bool
+}
+
+// Tween animates one or more float values from a start to an end over a duration
+type Tween struct {
+	target       interface{}
+	from         []float64
+	to           []float64
+	values       []float64
+	duration     float64
+	delay        float64
+	delayElapsed float64
+	elapsed      float64
+	ease         EaseFunc
+	repeat       int
+	playCount    int
+	isYoyo       bool
+	isReversed   bool
+	isStarted    bool
+	isFinished   bool
+	isKilled     bool
+	updateFunc   func(values []float64)
+	startFunc    func()
+	endFunc      func()
+}
+
+// NewTween creates a tween animating from and to, which must be the same length.
+// target identifies what is animated so it can be killed later and may be nil, it should be a pointer.
+// updateFunc is called with the eased values every update
+func NewTween(target interface{}, from []float64, to []float64, duration time.Duration, ease EaseFunc, updateFunc func(values []float64)) *Tween {
+	if ease == nil {
+		ease = EaseLinear
+	}
+	t := &Tween{
+		target:     target,
+		from:       make([]float64, len(from)),
+		to:         make([]float64, len(to)),
+		values:     make([]float64, len(from)),
+		duration:   duration.Seconds(),
+		ease:       ease,
+		updateFunc: updateFunc,
+	}
+	copy(t.from, from)
+	copy(t.to, to)
+	copy(t.values, from)
+	return t
+}
+
+// NewTweenFloat creates a tween animating a single value, e.g. alpha, rotation or progress
+func NewTweenFloat(target interface{}, from float64, to float64, duration time.Duration, ease EaseFunc, updateFunc func(value float64)) *Tween {
+	return NewTween(target, []float64{from}, []float64{to}, duration, ease, func(values []float64) {
+		if updateFunc != nil {
+			updateFunc(values[0])
+		}
+	})
+}
+
+// NewTweenPosition creates a tween animating a pair of values, e.g. position, scale or size
+func NewTweenPosition(target interface{}, fromX, fromY, toX, toY float64, duration time.Duration, ease EaseFunc, updateFunc func(x, y float64)) *Tween {
+	return NewTween(target, []float64{fromX, fromY}, []float64{toX, toY}, duration, ease, func(values []float64) {
+		if updateFunc != nil {
+			updateFunc(values[0], values[1])
+		}
+	})
+}
+
+// NewTweenColor creates a tween animating each channel of a color
+func NewTweenColor(target interface{}, from color.Color, to color.Color, duration time.Duration, ease EaseFunc, updateFunc func(c color.Color)) *Tween {
+	fr, fg, fb, fa := from.RGBA()
+	tr, tg, tb, ta := to.RGBA()
+	return NewTween(target,
+		[]float64{float64(fr), float64(fg), float64(fb), float64(fa)},
+		[]float64{float64(tr), float64(tg), float64(tb), float64(ta)},
+		duration, ease, func(values []float64) {
+			if updateFunc == nil {
+				return
+			}
+			updateFunc(color.RGBA64{
+				R: clampChannel(values[0]),
+				G: clampChannel(values[1]),
+				B: clampChannel(values[2]),
+				A: clampChannel(values[3]),
+			})
+		})
+}
+
+// clampChannel keeps overshooting eases like back and elastic inside a color channel
+func clampChannel(v float64) uint16 {
+	if v < 0 {
+		return 0
+	}
+	if v > 0xffff {
+		return 0xffff
+	}
+	return uint16(v)
+}
+
+// Update advances the tween by dt seconds and returns true once finished
+func (t *Tween) Update(dt float64) bool {
+	if t.isKilled || t.isFinished {
+		return true
+	}
+	if t.delayElapsed < t.delay {
+		t.delayElapsed += dt
+		if t.delayElapsed < t.delay {
+			return false
+		}
+		dt = t.delayElapsed - t.delay
+	}
+	if !t.isStarted {
+		t.isStarted = true
+		if t.startFunc != nil {
+			t.startFunc()
+		}
+	}
+
+	t.elapsed += dt
+	for t.elapsed >= t.duration {
+		if t.repeat >= 0 && t.playCount >= t.repeat {
+			t.Finish()
+			return true
+		}
+		if t.duration <= 0 {
+			break
+		}
+		t.elapsed -= t.duration
+		t.playCount++
+		if t.isYoyo {
+			t.isReversed = !t.isReversed
+		}
+	}
+	progress := 1.0
+	if t.duration > 0 {
+		progress = t.elapsed / t.duration
+	}
+	t.apply(progress)
+	return false
+}
+
+// apply sets values based on linear progress of the current play
+func (t *Tween) apply(progress float64) {
+	if t.isReversed {
+		progress = 1 - progress
+	}
+	e := t.ease(progress)
+	for i := range t.values {
+		t.values[i] = t.from[i] + (t.to[i]-t.from[i])*e
+	}
+	if t.updateFunc != nil {
+		t.updateFunc(t.values)
+	}
+}
+
+// Finish jumps the tween to its end and calls the end function
+func (t *Tween) Finish() {
+	if t.isKilled || t.isFinished {
+		return
+	}
+	if !t.isStarted {
+		t.isStarted = true
+		if t.startFunc != nil {
+			t.startFunc()
+		}
+	}
+	t.isFinished = true
+	t.apply(1)
+	if t.endFunc != nil {
+		t.endFunc()
+	}
+}
+
+// Kill stops the tween where it is without calling the end function
+func (t *Tween) Kill() {
+	t.isKilled = true
+}
+
+// IsKilled returns true if the tween was killed
+func (t *Tween) IsKilled() bool {
+	return t.isKilled
+}
+
+// IsFinished returns true if the tween played to the end
+func (t *Tween) IsFinished() bool {
+	return t.isFinished
+}
+
+// HasTarget returns true if the tween animates target. Targets of types that cannot be compared,
+// such as slices and maps, never match, so use a pointer to identify them
+func (t *Tween) HasTarget(target interface{}) bool {
+	if t.target == nil || target == nil {
+		return false
+	}
+	if !reflect.TypeOf(t.target).Comparable() || !reflect.TypeOf(target).Comparable() {
+		return false
+	}
+	return t.target == target
+}
+
+// Target returns what the tween animates
+func (t *Tween) Target() interface{} {
+	return t.target
+}
+
+// Values returns the current values of the tween
+func (t *Tween) Values() []float64 {
+	return t.values
+}
+
+// SetDelay sets how long to wait before the tween starts
+func (t *Tween) SetDelay(delay time.Duration) {
+	t.delay = delay.Seconds()
+}
+
+// SetRepeat sets how many extra times the tween plays, -1 repeats forever
+func (t *Tween) SetRepeat(repeat int) {
+	t.repeat = repeat
+}
+
+// SetYoyo flags if every repeat plays in the opposite direction of the last
+func (t *Tween) SetYoyo(isYoyo bool) {
+	t.isYoyo = isYoyo
+}
+
+// SetStartFunc sets a function to call once the delay has passed
+func (t *Tween) SetStartFunc(startFunc func()) {
+	t.startFunc = startFunc
+}
+
+// SetEndFunc sets a function to call once the tween finishes
+func (t *Tween) SetEndFunc(endFunc func()) {
+	t.endFunc = endFunc
+}
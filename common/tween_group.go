@@ -0,0 +1,114 @@
+package common
+
+// TweenGroup plays tweens one after another as a sequence, or all at once in parallel.
+// Groups are tweeners too, so they can be nested
+type TweenGroup struct {
+	tweens     []Tweener
+	isParallel bool
+	index      int
+	isFinished bool
+	isKilled   bool
+	endFunc    func()
+}
+
+// NewTweenSequence creates a group that plays each tween after the previous one finishes
+func NewTweenSequence(tweens ...Tweener) *TweenGroup {
+	return &TweenGroup{
+		tweens: tweens,
+	}
+}
+
+// NewTweenParallel creates a group that plays every tween at once, finishing with the longest
+func NewTweenParallel(tweens ...Tweener) *TweenGroup {
+	return &TweenGroup{
+		tweens:     tweens,
+		isParallel: true,
+	}
+}
+
+// Add appends a tween to the group
+func (g *TweenGroup) Add(t Tweener) {
+	g.tweens = append(g.tweens, t)
+}
+
+// Update advances the group by dt seconds and returns true once finished
+func (g *TweenGroup) Update(dt float64) bool {
+	if g.isKilled || g.isFinished {
+		return true
+	}
+	if g.isParallel {
+		isDone := true
+		for _, t := range g.tweens {
+			if !t.Update(dt) {
+				isDone = false
+			}
+		}
+		if isDone {
+			g.finish()
+		}
+		return isDone
+	}
+
+	for g.index < len(g.tweens) {
+		if !g.tweens[g.index].Update(dt) {
+			return false
+		}
+		g.index++
+		// leftover time is not carried into the next tween
+		dt = 0
+	}
+	g.finish()
+	return true
+}
+
+// Finish jumps every remaining tween to its end
+func (g *TweenGroup) Finish() {
+	if g.isKilled || g.isFinished {
+		return
+	}
+	for i := g.index; i < len(g.tweens); i++ {
+		g.tweens[i].Finish()
+	}
+	g.index = len(g.tweens)
+	g.finish()
+}
+
+func (g *TweenGroup) finish() {
+	g.isFinished = true
+	if g.endFunc != nil {
+		g.endFunc()
+	}
+}
+
+// Kill stops every tween in the group without calling end functions
+func (g *TweenGroup) Kill() {
+	g.isKilled = true
+	for _, t := range g.tweens {
+		t.Kill()
+	}
+}
+
+// IsKilled returns true if the group was killed
+func (g *TweenGroup) IsKilled() bool {
+	return g.isKilled
+}
+
+// IsFinished returns true if every tween in the group finished
+func (g *TweenGroup) IsFinished() bool {
+	return g.isFinished
+}
+
+// HasTarget returns true if any tween in the group animates target
+func (g *TweenGroup) HasTarget(target interface{}) bool {
+	for _, t := range g.tweens {
+		if t.HasTarget(target) {
+			return true
+		}
+	}
+	return false
+}
+
+// SetEndFunc sets a function to call once the group finishes
+func (g *TweenGroup) SetEndFunc(endFunc func()) {
+	g.endFunc = endFunc
+}
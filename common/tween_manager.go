@@ -0,0 +1,71 @@
+package common
+
+// TweenManager advances a list of tweens every update, dropping them once finished
+type TweenManager struct {
+	tweens []Tweener
+}
+
+// NewTweenManager returns a new tween manager
+func NewTweenManager() *TweenManager {
+	return &TweenManager{}
+}
+
+// Add starts playing a tween
+func (m *TweenManager) Add(t Tweener) {
+	if t == nil {
+		return
+	}
+	m.tweens = append(m.tweens, t)
+}
+
+// Update advances every tween by dt seconds
+func (m *TweenManager) Update(dt float64) {
+	// tweens added by end functions are appended and first advanced next update
+	count := len(m.tweens)
+	for i := 0; i < count && i < len(m.tweens); i++ {
+		t := m.tweens[i]
+		if t.IsKilled() {
+			continue
+		}
+		t.Update(dt)
+	}
+
+	n := 0
+	for _, t := range m.tweens {
+		if t.IsKilled() || t.IsFinished() {
+			continue
+		}
+		m.tweens[n] = t
+		n++
+	}
+	for i := n; i < len(m.tweens); i++ {
+		m.tweens[i] = nil
+	}
+	m.tweens = m.tweens[:n]
+}
+
+// Kill stops every tween animating target, returning how many were stopped
+func (m *TweenManager) Kill(target interface{}) int {
+	count := 0
+	for _, t := range m.tweens {
+		if t.IsKilled() || !t.HasTarget(target) {
+			continue
+		}
+		t.Kill()
+		count++
+	}
+	return count
+}
+
+// KillAll stops every tween
+func (m *TweenManager) KillAll() {
+	for _, t := range m.tweens {
+		t.Kill()
+	}
+	m.tweens = nil
+}
+
+// Count returns how many tweens are playing
+func (m *TweenManager) Count() int {
+	return len(m.tweens)
+}
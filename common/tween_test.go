@@ -0,0 +1,115 @@
+package common
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestTweenUpdate(t *testing.T) {
+	tests := []struct {
+		name     string
+		delay    time.Duration
+		repeat   int
+		isYoyo   bool
+		steps    []float64
+		value    float64
+		finished bool
+	}{
+		{"halfway", 0, 0, false, []float64{0.5}, 5, false},
+		{"end", 0, 0, false, []float64{0.5, 0.6}, 10, true},
+		{"delay", 500 * time.Millisecond, 0, false, []float64{0.25, 0.5}, 2.5, false},
+		{"repeat restarts", 0, 1, false, []float64{1.25}, 2.5, false},
+		{"repeat finishes", 0, 1, false, []float64{1, 1}, 10, true},
+		{"yoyo plays back", 0, 1, true, []float64{1.25}, 7.5, false},
+		{"forever", 0, -1, false, []float64{10.5}, 5, false},
+	}
+	for _, tt := range tests {
+		value := 0.0
+		tw := NewTweenFloat(nil, 0, 10, time.Second, EaseLinear, func(v float64) { value = v })
+		tw.SetDelay(tt.delay)
+		tw.SetRepeat(tt.repeat)
+		tw.SetYoyo(tt.isYoyo)
+		finished := false
+		for _, dt := range tt.steps {
+			finished = tw.Update(dt)
+		}
+		if math.Abs(value-tt.value) > 1e-9 {
+			t.Errorf("%s: value %v, want %v", tt.name, value, tt.value)
+		}
+		if finished != tt.finished || tw.IsFinished() != tt.finished {
+			t.Errorf("%s: finished %v, want %v", tt.name, finished, tt.finished)
+		}
+	}
+}
+
+func TestTweenFuncs(t *testing.T) {
+	started, ended := 0, 0
+	tw := NewTweenPosition(nil, 0, 0, 4, 8, time.Second, nil, nil)
+	tw.SetStartFunc(func() { started++ })
+	tw.SetEndFunc(func() { ended++ })
+	tw.Update(0.5)
+	tw.Finish()
+	tw.Update(1)
+	if started != 1 || ended != 1 {
+		t.Errorf("started %d ended %d, want 1 and 1", started, ended)
+	}
+	if v := tw.Values(); v[0] != 4 || v[1] != 8 {
+		t.Errorf("values %v, want [4 8]", v)
+	}
+
+	tw = NewTweenFloat(nil, 0, 1, time.Second, nil, nil)
+	tw.SetEndFunc(func() { ended++ })
+	tw.Kill()
+	if !tw.Update(1) || tw.IsFinished() || ended != 1 {
+		t.Errorf("killed tween finished or called its end function")
+	}
+}
+
+func TestTweenManager(t *testing.T) {
+	m := NewTweenManager()
+	a, b := new(int), new(int)
+	ta := NewTweenFloat(a, 0, 1, time.Second, nil, nil)
+	tb := NewTweenFloat(b, 0, 1, 2*time.Second, nil, nil)
+	m.Add(ta)
+	m.Add(tb)
+	m.Add(nil)
+	if m.Count() != 2 {
+		t.Fatalf("count %d, want 2", m.Count())
+	}
+	m.Update(1)
+	if !ta.IsFinished() || m.Count() != 1 {
+		t.Errorf("finished tween kept, count %d", m.Count())
+	}
+	if n := m.Kill(b); n != 1 || !tb.IsKilled() {
+		t.Errorf("kill stopped %d, want 1", n)
+	}
+	m.Update(0)
+	if m.Count() != 0 {
+		t.Errorf("killed tween kept, count %d", m.Count())
+	}
+}
+
+func TestTweenHasTarget(t *testing.T) {
+	p := new(int)
+	values := []float64{1}
+	tests := []struct {
+		name   string
+		target interface{}
+		other  interface{}
+		want   bool
+	}{
+		{"same pointer", p, p, true},
+		{"other pointer", p, new(int), false},
+		{"nil target", nil, nil, false},
+		{"slice target", values, values, false},
+		{"map target", map[string]int{}, p, false},
+		{"slice argument", p, values, false},
+	}
+	for _, tt := range tests {
+		tw := NewTweenFloat(tt.target, 0, 1, time.Second, nil, nil)
+		if got := tw.HasTarget(tt.other); got != tt.want {
+			t.Errorf("%s: %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
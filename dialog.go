@@ -0,0 +1,74 @@
+package egui
+
+import (
+	"fmt"
+
+	"github.com/pkg/errors"
+	"github.com/xackery/egui/common"
+	"github.com/xackery/egui/element/modal"
+)
+
+// DialogStyle returns how dialogs look
+func (u *UI) DialogStyle() modal.Style {
+	return u.dialogStyle
+}
+
+// SetDialogStyle sets how dialogs shown afterwards look
+func (u *UI) SetDialogStyle(style modal.Style) {
+	u.dialogStyle = style
+}
+
+// ShowDialog shows a modal dialog on the popup layer, blocking input to the rest of the scene until a button is chosen.
+// Enter or gamepad button 0 chooses the first button, escape or gamepad button 1 cancels with the last. No buttons shows OK
+func (u *UI) ShowDialog(scene string, title string, body string, buttons []string, onResult func(e *modal.Element, r modal.Result)) (*modal.Element, error) {
+	return u.showDialog(scene, title, body, buttons, nil, onResult)
+}
+
+// ShowPrompt shows a modal dialog with a text input holding text, see ShowDialog. The typed text is passed in the result
+func (u *UI) ShowPrompt(scene string, title string, body string, text string, buttons []string, onResult func(e *modal.Element, r modal.Result)) (*modal.Element, error) {
+	clipboard := u.clipboard
+	if clipboard == nil {
+		clipboard = &common.MemoryClipboard{}
+	}
+	e, err := u.showDialog(scene, title, body, buttons, clipboard, onResult)
+	if err != nil {
+		return nil, err
+	}
+	e.Input().SetText(text)
+	return e, nil
+}
+
+func (u *UI) showDialog(scene string, title string, body string, buttons []string, clipboard common.Clipboard, onResult func(e *modal.Element, r modal.Result)) (*modal.Element, error) {
+	imageName := "ui"
+	img, err := u.Image(imageName)
+	if err != nil {
+		return nil, errors.Wrap(err, imageName)
+	}
+
+	s, err := u.Scene(scene)
+	if err != nil {
+		return nil, common.ErrSceneNotFound
+	}
+
+	u.dialogCount++
+	name := fmt.Sprintf("dialog%d", u.dialogCount)
+	e, err := modal.New(name, scene, u.screenResolution.X, u.screenResolution.Y, title, body, buttons, u.defaultFont, img, u.dialogStyle, clipboard)
+	if err != nil {
+		return nil, err
+	}
+	err = s.AddElementToLayer(e, LayerPopup)
+	if err != nil {
+		return nil, err
+	}
+
+	// keys go to the dialog alone, and back to what had focus however it closes
+	focused := s.blur()
+	e.SetOnClose(func(e *modal.Element) {
+		for _, f := range focused {
+			f.SetIsFocused(true)
+		}
+	})
+	e.SetOnResult(onResult)
+	e.Show()
+	return e, nil
+}
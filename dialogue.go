@@ -0,0 +1,30 @@
+package egui
+
+import (
+	"image/color"
+
+	"github.com/pkg/errors"
+	"github.com/xackery/egui/common"
+	"github.com/xackery/egui/element/dialogue"
+)
+
+// NewDialogue creates a new dialogue box instance, drawn with the 9slice sliceName of the ui image
+func (u *UI) NewDialogue(name string, scene string, x float64, y float64, width int, height int, textColor color.Color, sliceName string) (*dialogue.Element, error) {
+	imageName := "ui"
+	img, err := u.Image(imageName)
+	if err != nil {
+		return nil, errors.Wrap(err, imageName)
+	}
+
+	s, err := u.Scene(scene)
+	if err != nil {
+		return nil, common.ErrSceneNotFound
+	}
+
+	e, err := dialogue.New(name, scene, x, y, width, height, u.defaultFont, textColor, img, sliceName)
+	err = s.AddElement(e)
+	if err != nil {
+		return nil, err
+	}
+	return e, nil
+}
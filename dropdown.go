@@ -0,0 +1,39 @@
+package egui
+
+import (
+	"image/color"
+
+	"github.com/pkg/errors"
+	"github.com/xackery/egui/common"
+	"github.com/xackery/egui/element/dropdown"
+)
+
+// NewDropdown creates a new dropdown instance choosing from items. Its popup list is placed on the popup layer,
+// above every element of lower layers whatever their render index
+func (u *UI) NewDropdown(name string, scene string, x float64, y float64, width int, height int, textColor color.Color, sliceName string, popupSliceName string, items []string) (*dropdown.Element, error) {
+	imageName := "ui"
+	img, err := u.Image(imageName)
+	if err != nil {
+		return nil, errors.Wrap(err, imageName)
+	}
+
+	s, err := u.Scene(scene)
+	if err != nil {
+		return nil, common.ErrSceneNotFound
+	}
+
+	e, err := dropdown.New(name, scene, x, y, width, height, u.defaultFont, textColor, img, sliceName, popupSliceName, items)
+	if err != nil {
+		return nil, err
+	}
+	err = s.AddElement(e)
+	if err != nil {
+		return nil, err
+	}
+	err = s.AddElementToLayer(e.Popup(), LayerPopup)
+	if err != nil {
+		s.RemoveElement(name)
+		return nil, errors.Wrap(err, e.Popup().Name())
+	}
+	return e, nil
+}
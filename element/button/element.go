@@ -6,7 +6,6 @@ import (
 
 	"github.com/hajimehoshi/ebiten"
 	"github.com/hajimehoshi/ebiten/inpututil"
-	"github.com/hajimehoshi/ebiten/text"
 	"github.com/xackery/egui/common"
 )
 
@@ -35,6 +34,7 @@ type Element struct {
 	font               *common.Font
 	pressedSliceName   string
 	unpressedSliceName string
+	textImage          common.TextImage
 }
 
 // New creates a new button instance
@@ -101,10 +101,10 @@ func (e *Element) SetRenderIndex(renderIndex int64) {
 func (e *Element) Update(dt float64) {
 
 	if e.lerpPosition.IsEnabled() {
-		e.x, e.y = e.lerpPosition.Lerp()
+		e.x, e.y = e.lerpPosition.Lerp(dt)
 		if !e.lerpPosition.IsEnabled() {
 			if e.lerpPosition.EndFunc() != nil {
-				e.lerpPosition.EndFunc()
+				e.lerpPosition.EndFunc()()
 			}
 			if e.lerpPosition.IsDestroyed() {
 				e.isDestroyed = true
@@ -116,9 +116,7 @@ func (e *Element) Update(dt float64) {
 	isRecentlyPressed := false
 	//mobile and desktop use differnet touch devices
 	for _, t := range inpututil.JustPressedTouchIDs() {
-		x, y := ebiten.TouchPosition(t)
-		fx := float64(x)
-		fy := float64(y)
+		fx, fy := common.TouchPosition(t)
 		if e.x <= fx && fx < e.x+float64(e.width) && e.y <= fy && fy < e.y+float64(e.height) {
 			e.isPressed = true
 			isRecentlyPressed = true
@@ -128,9 +126,7 @@ func (e *Element) Update(dt float64) {
 	}
 
 	if ebiten.IsMouseButtonPressed(ebiten.MouseButtonLeft) {
-		x, y := ebiten.CursorPosition()
-		fx := float64(x)
-		fy := float64(y)
+		fx, fy := common.CursorPosition()
 		if e.x <= fx && fx < e.x+float64(e.width) && e.y <= fy && fy < e.y+float64(e.height) {
 			e.isPressed = true
 			isRecentlyPressed = true
@@ -177,38 +173,14 @@ func (e *Element) Draw(dst *ebiten.Image) {
 		op.ColorM.Scale(0.5, 0.5, 0.5, 1)
 	}
 	common.DrawNineSlicing(dst, e.image.EbitenImage, slice.Keys[0], e.width, int(e.height), &op.GeoM, &op.ColorM)
-	//bounds, _ := font.BoundString(e.font.Face, e.text)
-	//w := float64((bounds.Max.X - bounds.Min.X).Ceil())
-	//text.Draw(dst, e.text, e.font.Face, int(e.X()), int(e.Y()), e.color)
-	//bounds, _ := font.BoundString(e.font.Face, e.text)
-
-	text.Draw(dst, e.text, e.font.Face, int(e.x), int(e.y), e.color)
-
-	/*_, th := e.font.MeasureSize(e.text)
-	tx := e.X() * e.ui.tileScale
-	tx += e.shape.Dx() * e.ui.tileScale / 2
-
-	ty := e.Y() * e.ui.tileScale
-	ty += (e.shape.Dy()*e.ui.tileScale - float64(th)*e.ui.textScale) / 2
-
-	cr, cg, cb, ca := e.color.RGBA()
-	r8 := uint8(cr >> 8)
-	g8 := uint8(cg >> 8)
-	b8 := uint8(cb >> 8)
-	a8 := uint8(ca >> 8)
-	var c color.Color = color.RGBA{r8, g8, b8, uint8(uint16(a8) * uint16(opacity) / 255)}
-	if !e.isEnabled {
-		c = color.RGBA{r8, g8, b8, uint8(uint16(a8) * uint16(opacity) / (2 * 255))}
-	}
-	l := e.font.Language
-	if l == language.Und {
-		l = e.ui.defaultLanguage
-	}
-	if e.isTextShadow {
-		e.font.DrawText(dst, e.text, tx+e.ui.textScale, ty+e.ui.textScale, e.ui.textScale, 0, color.Black, len([]rune(e.text)))
+	layout := &common.TextLayout{
+		Width:      float64(e.width) * e.scale,
+		Height:     float64(e.height) * e.scale,
+		Align:      common.TextAlignCenter,
+		VAlign:     common.TextAlignMiddle,
+		IsEllipsis: true,
 	}
-	e.font.DrawText(dst, e.text, tx, ty, e.ui.textScale, 0, c, len([]rune(e.text)))
-	*/
+	e.textImage.DrawText(dst, e.font, e.text, e.x, e.y, layout, e.color)
 
 }
 
@@ -234,7 +206,7 @@ func (e *Element) IsDestroyed() bool {
 
 // LerpPosition changes an element's position over duration
 func (e *Element) LerpPosition(endPositionX, endPositionY float64, duration time.Duration, isDestroyed bool, endFunc func()) {
-	e.lerpPosition.Init(time.Now(), e.x, e.y, endPositionX, endPositionY, duration, true, endFunc, isDestroyed)
+	e.lerpPosition.Init(e.x, e.y, endPositionX, endPositionY, duration, true, endFunc, isDestroyed)
 }
 
 // Position returns an element's position
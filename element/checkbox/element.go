@@ -0,0 +1,299 @@
+package checkbox
+
+import (
+	"image/color"
+	"time"
+
+	"github.com/hajimehoshi/ebiten"
+	"github.com/xackery/egui/common"
+)
+
+// Element represents a 9slice checkbox with a label.
+// A element is unchecked, checked or indeterminate
+// A element can register events on change
+type Element struct {
+	name                   string
+	image                  *common.Image
+	x                      float64
+	y                      float64
+	scale                  float64
+	width                  int
+	height                 int
+	text                   string
+	state                  common.CheckState
+	isEnabled              bool
+	isVisible              bool
+	isPressed              bool
+	isFocused              bool
+	renderIndex            int64
+	isDestroyed            bool
+	lerpPosition           *common.LerpPosition
+	color                  color.Color
+	font                   *common.Font
+	uncheckedSliceName     string
+	checkedSliceName       string
+	indeterminateSliceName string
+	textImage              common.TextImage
+	onChange               func(e *Element, state common.CheckState)
+}
+
+// New creates a new checkbox instance. The box is height pixels square, with the label right of it
+func New(name string, scene string, text string, x float64, y float64, width int, height int, font *common.Font, textColor color.Color, img *common.Image, uncheckedSliceName string, checkedSliceName string, indeterminateSliceName string) (*Element, error) {
+
+	e := &Element{
+		name:                   name,
+		image:                  img,
+		text:                   text,
+		isEnabled:              true,
+		isVisible:              true,
+		lerpPosition:           new(common.LerpPosition),
+		color:                  textColor,
+		x:                      x,
+		y:                      y,
+		width:                  width,
+		height:                 height,
+		font:                   font,
+		uncheckedSliceName:     uncheckedSliceName,
+		checkedSliceName:       checkedSliceName,
+		indeterminateSliceName: indeterminateSliceName,
+		scale:                  1,
+	}
+
+	return e, nil
+}
+
+// Name returns a element's name
+func (e *Element) Name() string {
+	return e.name
+}
+
+// IsVisible returns true if element is visible
+func (e *Element) IsVisible() bool {
+	return e.isVisible
+}
+
+// IsEnabled returns true if a element is enabled
+func (e *Element) IsEnabled() bool {
+	return e.isEnabled
+}
+
+// SetEnabled changes if a element is enabled. Disabled elements lose focus
+func (e *Element) SetEnabled(isEnabled bool) {
+	e.isEnabled = isEnabled
+	if !isEnabled {
+		e.isFocused = false
+		e.isPressed = false
+	}
+}
+
+// SetVisible changes the visibility of a element
+func (e *Element) SetVisible(isVisible bool) {
+	e.isVisible = isVisible
+}
+
+// RenderIndex returns the render index of element
+func (e *Element) RenderIndex() int64 {
+	return e.renderIndex
+}
+
+// SetRenderIndex sets the render index of element
+func (e *Element) SetRenderIndex(renderIndex int64) {
+	e.renderIndex = renderIndex
+}
+
+// Update is called during a game update
+func (e *Element) Update(dt float64) {
+
+	if e.lerpPosition.IsEnabled() {
+		e.x, e.y = e.lerpPosition.Lerp(dt)
+		if !e.lerpPosition.IsEnabled() {
+			if e.lerpPosition.EndFunc() != nil {
+				e.lerpPosition.EndFunc()()
+			}
+			if e.lerpPosition.IsDestroyed() {
+				e.isDestroyed = true
+				return
+			}
+		}
+	}
+
+	if !e.isEnabled || !e.isVisible {
+		return
+	}
+
+	x, y, ok := common.PointerJustPressed()
+	if ok {
+		e.isPressed = e.contains(x, y)
+		e.isFocused = e.isPressed
+	}
+	if e.isPressed {
+		x, y, ok = common.PointerPressed()
+		if !ok {
+			e.isPressed = false
+			if !common.IsPointerCancelled() {
+				e.Toggle()
+			}
+		} else if !e.contains(x, y) {
+			e.isPressed = false
+		}
+	}
+
+	if e.isFocused && (common.IsKeyJustPressed(ebiten.KeySpace) || common.IsKeyJustPressed(ebiten.KeyEnter)) {
+		e.Toggle()
+	}
+}
+
+// contains returns true if x, y is inside the box or label
+func (e *Element) contains(x float64, y float64) bool {
+	return e.x <= x && x < e.x+float64(e.width)*e.scale && e.y <= y && y < e.y+float64(e.height)*e.scale
+}
+
+// Draw is called during a game update
+func (e *Element) Draw(dst *ebiten.Image) {
+	if !e.isVisible {
+		return
+	}
+
+	sliceName := e.uncheckedSliceName
+	switch e.state {
+	case common.CheckChecked:
+		sliceName = e.checkedSliceName
+	case common.CheckIndeterminate:
+		if e.indeterminateSliceName != "" {
+			sliceName = e.indeterminateSliceName
+		}
+	}
+
+	op := &ebiten.DrawImageOptions{}
+	op.GeoM.Translate(e.x, e.y)
+	op.GeoM.Scale(e.scale, e.scale)
+
+	textColor := e.color
+	if !e.isEnabled {
+		op.ColorM.ChangeHSV(0, 0, 1)
+		op.ColorM.Scale(0.5, 0.5, 0.5, 1)
+		textColor = common.DisabledColor(e.color)
+	}
+
+	slice, err := e.image.Slice(sliceName)
+	if err == nil {
+		common.DrawNineSlicing(dst, e.image.EbitenImage, slice.Keys[0], e.height, e.height, &op.GeoM, &op.ColorM)
+	}
+
+	box := float64(e.height) * e.scale
+	gap := box / 4
+	layout := &common.TextLayout{
+		Width:      float64(e.width)*e.scale - box - gap,
+		Height:     box,
+		VAlign:     common.TextAlignMiddle,
+		IsEllipsis: true,
+	}
+	e.textImage.DrawText(dst, e.font, e.text, e.x+box+gap, e.y, layout, textColor)
+}
+
+// Toggle checks the element, or unchecks it if checked, calling the change function
+func (e *Element) Toggle() {
+	e.SetState(e.state.Toggled())
+}
+
+// State returns the check state
+func (e *Element) State() common.CheckState {
+	return e.state
+}
+
+// SetState sets the check state, calling the change function if it changed
+func (e *Element) SetState(state common.CheckState) {
+	if e.state == state {
+		return
+	}
+	e.state = state
+	if e.onChange != nil {
+		e.onChange(e, state)
+	}
+}
+
+// IsChecked returns true if the element is checked
+func (e *Element) IsChecked() bool {
+	return e.state == common.CheckChecked
+}
+
+// SetIsChecked checks or unchecks the element
+func (e *Element) SetIsChecked(isChecked bool) {
+	if isChecked {
+		e.SetState(common.CheckChecked)
+		return
+	}
+	e.SetState(common.CheckUnchecked)
+}
+
+// SetText changes the label of the element
+func (e *Element) SetText(text string) {
+	e.text = text
+}
+
+// Text returns the label of the element
+func (e *Element) Text() string {
+	return e.text
+}
+
+// IsFocused returns true if space and enter toggle the element
+func (e *Element) IsFocused() bool {
+	return e.isFocused
+}
+
+// SetIsFocused sets if space and enter toggle the element. Clicking the element focuses it
+func (e *Element) SetIsFocused(isFocused bool) {
+	e.isFocused = isFocused
+}
+
+// SetOnChange sets a function called when the check state changes
+func (e *Element) SetOnChange(f func(e *Element, state common.CheckState)) {
+	e.onChange = f
+}
+
+// IsDestroyed returns true when the element is flagged for deletion
+func (e *Element) IsDestroyed() bool {
+	return e.isDestroyed
+}
+
+// LerpPosition changes an element's position over duration
+func (e *Element) LerpPosition(endPositionX, endPositionY float64, duration time.Duration, isDestroyed bool, endFunc func()) {
+	e.lerpPosition.Init(e.x, e.y, endPositionX, endPositionY, duration, true, endFunc, isDestroyed)
+}
+
+// Position returns an element's position
+func (e *Element) Position() (float64, float64) {
+	return e.x, e.y
+}
+
+// SetPosition sets an element's position
+func (e *Element) SetPosition(x float64, y float64) {
+	e.x = x
+	e.y = y
+}
+
+// Width returns an element's width
+func (e *Element) Width() int {
+	return e.width
+}
+
+// SetWidth sets an element's width
+func (e *Element) SetWidth(width int) {
+	e.width = width
+}
+
+// Height returns an element's height
+func (e *Element) Height() int {
+	return e.height
+}
+
+// SetHeight sets an element's height
+func (e *Element) SetHeight(height int) {
+	e.height = height
+}
+
+// SetIsDestroyed sets an element to be destroyed on next update
+func (e *Element) SetIsDestroyed(isDestroyed bool) {
+	e.isDestroyed = true
+	e.textImage.Dispose()
+}
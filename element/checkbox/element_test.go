@@ -0,0 +1,60 @@
+package checkbox
+
+import (
+	"image/color"
+	"testing"
+
+	"github.com/xackery/egui/common"
+)
+
+func newTestCheckbox(t *testing.T) *Element {
+	e, err := New("check", "scene", "label", 0, 0, 100, 20, nil, color.White, nil, "", "", "")
+	if err != nil {
+		t.Fatalf("new: %v", err)
+	}
+	return e
+}
+
+func TestToggle(t *testing.T) {
+	tests := []struct {
+		state common.CheckState
+		want  common.CheckState
+	}{
+		{common.CheckUnchecked, common.CheckChecked},
+		{common.CheckChecked, common.CheckUnchecked},
+		// indeterminate options become checked
+		{common.CheckIndeterminate, common.CheckChecked},
+	}
+	for _, tt := range tests {
+		e := newTestCheckbox(t)
+		e.SetState(tt.state)
+		e.Toggle()
+		if e.State() != tt.want {
+			t.Errorf("toggle %v: got %v, want %v", tt.state, e.State(), tt.want)
+		}
+		if e.IsChecked() != (tt.want == common.CheckChecked) {
+			t.Errorf("toggle %v: checked %v", tt.state, e.IsChecked())
+		}
+	}
+}
+
+func TestOnChange(t *testing.T) {
+	e := newTestCheckbox(t)
+	var changes []common.CheckState
+	e.SetOnChange(func(e *Element, state common.CheckState) {
+		changes = append(changes, state)
+	})
+	e.SetIsChecked(true)
+	e.SetIsChecked(true)
+	e.SetState(common.CheckIndeterminate)
+	e.SetIsChecked(false)
+	want := []common.CheckState{common.CheckChecked, common.CheckIndeterminate, common.CheckUnchecked}
+	if len(changes) != len(want) {
+		t.Fatalf("changes %v, want %v", changes, want)
+	}
+	for i := range want {
+		if changes[i] != want[i] {
+			t.Errorf("change %d: got %v, want %v", i, changes[i], want[i])
+		}
+	}
+}
@@ -0,0 +1,476 @@
+package dialogue
+
+import (
+	"image/color"
+	"strings"
+	"time"
+
+	"github.com/hajimehoshi/ebiten"
+	"github.com/xackery/egui/common"
+	"github.com/xackery/egui/element/sprite"
+)
+
+// Element represents a 9slice dialogue box revealing text rune by rune.
+// A element has a speaker name and an optional portrait
+// A element pages text that overflows the box
+type Element struct {
+	name            string
+	image           *common.Image
+	sliceName       string
+	x               float64
+	y               float64
+	scale           float64
+	width           int
+	height          int
+	padding         int
+	text            string
+	speaker         string
+	speakerColor    color.Color
+	portrait        *sprite.Element
+	portraitWidth   int
+	isEnabled       bool
+	isVisible       bool
+	renderIndex     int64
+	isDestroyed     bool
+	lerpPosition    *common.LerpPosition
+	color           color.Color
+	font            *common.Font
+	speed           float64
+	advanceKeys     []ebiten.Key
+	pages           [][]string
+	page            int
+	revealed        float64
+	onCharacter     func(e *Element, r rune)
+	onPageComplete  func(e *Element, page int)
+	onFinished      func(e *Element)
+	onPressFunction func()
+	isFinished      bool
+}
+
+// New creates a new dialogue instance
+func New(name string, scene string, x float64, y float64, width int, height int, font *common.Font, textColor color.Color, img *common.Image, sliceName string) (*Element, error) {
+
+	e := &Element{
+		name:         name,
+		image:        img,
+		sliceName:    sliceName,
+		isEnabled:    true,
+		isVisible:    true,
+		lerpPosition: new(common.LerpPosition),
+		color:        textColor,
+		speakerColor: textColor,
+		x:            x,
+		y:            y,
+		width:        width,
+		height:       height,
+		padding:      8,
+		font:         font,
+		scale:        1,
+		speed:        30,
+		advanceKeys:  []ebiten.Key{ebiten.KeyEnter, ebiten.KeySpace},
+	}
+
+	return e, nil
+}
+
+// Name returns a element's name
+func (e *Element) Name() string {
+	return e.name
+}
+
+// IsVisible returns true if element is visible
+func (e *Element) IsVisible() bool {
+	return e.isVisible
+}
+
+// IsEnabled returns true if a element is enabled
+func (e *Element) IsEnabled() bool {
+	return e.isEnabled
+}
+
+// SetEnabled changes if a element is enabled
+func (e *Element) SetEnabled(isEnabled bool) {
+	e.isEnabled = isEnabled
+}
+
+// SetVisible changes the visibility of a element
+func (e *Element) SetVisible(isVisible bool) {
+	e.isVisible = isVisible
+}
+
+// RenderIndex returns the render index of element
+func (e *Element) RenderIndex() int64 {
+	return e.renderIndex
+}
+
+// SetRenderIndex sets the render index of element
+func (e *Element) SetRenderIndex(renderIndex int64) {
+	e.renderIndex = renderIndex
+}
+
+// Update is called during a game update
+func (e *Element) Update(dt float64) {
+
+	if e.lerpPosition.IsEnabled() {
+		e.x, e.y = e.lerpPosition.Lerp(dt)
+		if !e.lerpPosition.IsEnabled() {
+			if e.lerpPosition.EndFunc() != nil {
+				e.lerpPosition.EndFunc()()
+			}
+			if e.lerpPosition.IsDestroyed() {
+				e.isDestroyed = true
+				return
+			}
+		}
+	}
+
+	if e.portrait != nil {
+		e.portrait.Update(dt)
+	}
+
+	if !e.isVisible || !e.isEnabled {
+		return
+	}
+
+	if e.isAdvancePressed() {
+		e.Advance()
+		return
+	}
+	e.reveal(dt)
+}
+
+// isAdvancePressed returns true if an advance key was pressed, or the box was clicked or touched
+func (e *Element) isAdvancePressed() bool {
+	for _, k := range e.advanceKeys {
+		if common.IsKeyJustPressed(k) {
+			return true
+		}
+	}
+	x, y, ok := common.PointerJustPressed()
+	return ok && e.contains(x, y)
+}
+
+// contains returns true if x, y is inside the box
+func (e *Element) contains(x float64, y float64) bool {
+	return e.x <= x && x < e.x+float64(e.width)*e.scale && e.y <= y && y < e.y+float64(e.height)*e.scale
+}
+
+// reveal shows more runes of the current page based on speed, calling the character hook for each
+func (e *Element) reveal(dt float64) {
+	total := e.pageRuneCount()
+	if e.isFinished || int(e.revealed) >= total {
+		return
+	}
+	shown := int(e.revealed)
+	if e.speed <= 0 {
+		e.revealed = float64(total)
+	} else {
+		e.revealed += dt * e.speed
+	}
+	if int(e.revealed) > total {
+		e.revealed = float64(total)
+	}
+	if e.onCharacter != nil {
+		runes := []rune(strings.Join(e.pages[e.page], ""))
+		for _, r := range runes[shown:int(e.revealed)] {
+			e.onCharacter(e, r)
+		}
+	}
+	if int(e.revealed) >= total && e.onPageComplete != nil {
+		e.onPageComplete(e, e.page)
+	}
+}
+
+// Advance skips to the end of the current page if it is still revealing, otherwise shows the next page.
+// Advancing past the last page finishes the dialogue
+func (e *Element) Advance() {
+	if e.isFinished {
+		return
+	}
+	total := e.pageRuneCount()
+	if int(e.revealed) < total {
+		e.revealed = float64(total)
+		if e.onPageComplete != nil {
+			e.onPageComplete(e, e.page)
+		}
+		return
+	}
+	if e.page+1 < len(e.paginate()) {
+		e.page++
+		e.revealed = 0
+		return
+	}
+	e.isFinished = true
+	if e.onFinished != nil {
+		e.onFinished(e)
+	}
+	if e.onPressFunction != nil {
+		e.onPressFunction()
+	}
+}
+
+// Draw is called during a game update
+func (e *Element) Draw(dst *ebiten.Image) {
+	if !e.isVisible {
+		return
+	}
+
+	op := &ebiten.DrawImageOptions{}
+	op.GeoM.Translate(e.x, e.y)
+	op.GeoM.Scale(e.scale, e.scale)
+
+	if !e.isEnabled {
+		op.ColorM.ChangeHSV(0, 0, 1)
+		op.ColorM.Scale(0.5, 0.5, 0.5, 1)
+	}
+
+	slice, err := e.image.Slice(e.sliceName)
+	if err == nil {
+		common.DrawNineSlicing(dst, e.image.EbitenImage, slice.Keys[0], e.width, e.height, &op.GeoM, &op.ColorM)
+	}
+
+	pad := float64(e.padding) * e.scale
+	if e.portrait != nil {
+		e.portrait.SetPosition(e.x+pad, e.y+pad)
+		e.portrait.Draw(dst)
+	}
+
+	x, y := e.textPosition()
+	if e.speaker != "" {
+		e.font.DrawText(dst, e.speaker, x, y-float64(e.font.RenderingLineHeight), nil, e.speakerColor, -1)
+	}
+
+	pages := e.paginate()
+	if len(pages) == 0 {
+		return
+	}
+	e.font.DrawText(dst, strings.Join(pages[e.page], "\n"), x, y, nil, e.color, int(e.revealed))
+}
+
+// textPosition returns the top left of the page text, right of the portrait and below the speaker
+func (e *Element) textPosition() (float64, float64) {
+	pad := float64(e.padding) * e.scale
+	x := e.x + pad
+	y := e.y + pad
+	if e.portrait != nil {
+		x += float64(e.portraitWidth)*e.scale + pad
+	}
+	if e.speaker != "" {
+		y += float64(e.font.RenderingLineHeight)
+	}
+	return x, y
+}
+
+// paginate wraps text to the box and splits it into pages of as many lines as fit, once per change
+func (e *Element) paginate() [][]string {
+	if e.pages != nil || e.text == "" {
+		return e.pages
+	}
+	x, y := e.textPosition()
+	pad := float64(e.padding) * e.scale
+	layout := &common.TextLayout{
+		Width:     e.x + float64(e.width)*e.scale - pad - x,
+		IsWrapped: true,
+	}
+	lines := e.font.LayoutText(e.text, layout)
+
+	perPage := 1
+	if e.font.RenderingLineHeight > 0 {
+		perPage = int(e.y+float64(e.height)*e.scale-pad-y) / e.font.RenderingLineHeight
+	}
+	if perPage < 1 {
+		perPage = 1
+	}
+	for len(lines) > 0 {
+		n := perPage
+		if n > len(lines) {
+			n = len(lines)
+		}
+		e.pages = append(e.pages, lines[:n])
+		lines = lines[n:]
+	}
+	return e.pages
+}
+
+// pageRuneCount returns how many runes the current page reveals, line breaks excluded
+func (e *Element) pageRuneCount() int {
+	pages := e.paginate()
+	if e.page >= len(pages) {
+		return 0
+	}
+	count := 0
+	for _, l := range pages[e.page] {
+		count += len([]rune(l))
+	}
+	return count
+}
+
+// invalidate pages text again after a change to text or the box, starting from the first page
+func (e *Element) invalidate() {
+	e.pages = nil
+	e.page = 0
+	e.revealed = 0
+	e.isFinished = false
+}
+
+// SetText changes the text on the element and starts revealing it from the first page
+func (e *Element) SetText(text string) {
+	e.text = text
+	e.invalidate()
+}
+
+// Text returns the text of the element
+func (e *Element) Text() string {
+	return e.text
+}
+
+// Say sets the speaker and text together, e.g. for each line of a conversation
+func (e *Element) Say(speaker string, text string) {
+	e.speaker = speaker
+	e.SetText(text)
+}
+
+// Speaker returns the name shown above the text
+func (e *Element) Speaker() string {
+	return e.speaker
+}
+
+// SetSpeaker sets the name shown above the text. An empty name gives the line to text
+func (e *Element) SetSpeaker(speaker string) {
+	e.speaker = speaker
+	e.invalidate()
+}
+
+// SetSpeakerColor sets the color of the speaker name
+func (e *Element) SetSpeakerColor(speakerColor color.Color) {
+	e.speakerColor = speakerColor
+}
+
+// Portrait returns the portrait sprite, or nil if none is shown
+func (e *Element) Portrait() *sprite.Element {
+	return e.portrait
+}
+
+// SetPortrait sets a sprite drawn at the left of the box, reserving width pixels for it.
+// A nil portrait gives the space back to text
+func (e *Element) SetPortrait(portrait *sprite.Element, width int) {
+	e.portrait = portrait
+	e.portraitWidth = width
+	e.invalidate()
+}
+
+// Speed returns how many runes are revealed per second
+func (e *Element) Speed() float64 {
+	return e.speed
+}
+
+// SetSpeed sets how many runes are revealed per second, 0 shows each page at once
+func (e *Element) SetSpeed(speed float64) {
+	e.speed = speed
+}
+
+// Padding returns the space between the box edge and its contents
+func (e *Element) Padding() int {
+	return e.padding
+}
+
+// SetPadding sets the space between the box edge and its contents
+func (e *Element) SetPadding(padding int) {
+	e.padding = padding
+	e.invalidate()
+}
+
+// SetAdvanceKeys sets the keys that advance the dialogue, Enter and Space by default.
+// Clicking or touching the box always advances
+func (e *Element) SetAdvanceKeys(keys ...ebiten.Key) {
+	e.advanceKeys = keys
+}
+
+// Page returns the index of the current page
+func (e *Element) Page() int {
+	return e.page
+}
+
+// PageCount returns how many pages text is split into
+func (e *Element) PageCount() int {
+	return len(e.paginate())
+}
+
+// IsPageComplete returns true when every rune of the current page is shown
+func (e *Element) IsPageComplete() bool {
+	return int(e.revealed) >= e.pageRuneCount()
+}
+
+// IsFinished returns true after advancing past the last page
+func (e *Element) IsFinished() bool {
+	return e.isFinished
+}
+
+// SetOnCharacter sets a function called for each rune as it is revealed, e.g. to play a blip sound.
+// Runes skipped by advancing do not call it
+func (e *Element) SetOnCharacter(f func(e *Element, r rune)) {
+	e.onCharacter = f
+}
+
+// SetOnPageComplete sets a function called when a page is fully shown, e.g. to show a next indicator
+func (e *Element) SetOnPageComplete(f func(e *Element, page int)) {
+	e.onPageComplete = f
+}
+
+// SetOnFinished sets a function called when advancing past the last page
+func (e *Element) SetOnFinished(f func(e *Element)) {
+	e.onFinished = f
+}
+
+// SetOnPressFunction lets you pass a function without the need of element handling, called when finished
+func (e *Element) SetOnPressFunction(f func()) {
+	e.onPressFunction = f
+}
+
+// IsDestroyed returns true when the element is flagged for deletion
+func (e *Element) IsDestroyed() bool {
+	return e.isDestroyed
+}
+
+// LerpPosition changes an element's position over duration
+func (e *Element) LerpPosition(endPositionX, endPositionY float64, duration time.Duration, isDestroyed bool, endFunc func()) {
+	e.lerpPosition.Init(e.x, e.y, endPositionX, endPositionY, duration, true, endFunc, isDestroyed)
+}
+
+// Position returns an element's position
+func (e *Element) Position() (float64, float64) {
+	return e.x, e.y
+}
+
+// SetPosition sets an element's position
+func (e *Element) SetPosition(x float64, y float64) {
+	e.x = x
+	e.y = y
+}
+
+// Width returns an element's width
+func (e *Element) Width() int {
+	return e.width
+}
+
+// SetWidth sets an element's width
+func (e *Element) SetWidth(width int) {
+	e.width = width
+	e.invalidate()
+}
+
+// Height returns an element's height
+func (e *Element) Height() int {
+	return e.height
+}
+
+// SetHeight sets an element's height
+func (e *Element) SetHeight(height int) {
+	e.height = height
+	e.invalidate()
+}
+
+// SetIsDestroyed sets an element to be destroyed on next update
+func (e *Element) SetIsDestroyed(isDestroyed bool) {
+	e.isDestroyed = true
+}
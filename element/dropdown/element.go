@@ -0,0 +1,375 @@
+package dropdown
+
+import (
+	"image/color"
+	"time"
+
+	"github.com/hajimehoshi/ebiten"
+	"github.com/hajimehoshi/ebiten/ebitenutil"
+	"github.com/xackery/egui/common"
+)
+
+// Element represents a 9slice button showing the chosen item of a list.
+// A element opens a popup list of items when pressed
+// A element can register events on selection change
+type Element struct {
+	name         string
+	image        *common.Image
+	x            float64
+	y            float64
+	scale        float64
+	width        int
+	height       int
+	items        []string
+	selected     int
+	placeholder  string
+	isEnabled    bool
+	isVisible    bool
+	isPressed    bool
+	isFocused    bool
+	renderIndex  int64
+	isDestroyed  bool
+	lerpPosition *common.LerpPosition
+	color        color.Color
+	font         *common.Font
+	sliceName    string
+	popup        *Popup
+	keyRepeat    *common.KeyRepeat
+	textImage    common.TextImage
+	onChange     func(e *Element, index int)
+}
+
+// New creates a new dropdown instance. Its popup is named name.popup, and should be added to a layer above the element
+func New(name string, scene string, x float64, y float64, width int, height int, font *common.Font, textColor color.Color, img *common.Image, sliceName string, popupSliceName string, items []string) (*Element, error) {
+
+	e := &Element{
+		name:         name,
+		image:        img,
+		items:        items,
+		selected:     -1,
+		isEnabled:    true,
+		isVisible:    true,
+		lerpPosition: new(common.LerpPosition),
+		color:        textColor,
+		x:            x,
+		y:            y,
+		width:        width,
+		height:       height,
+		font:         font,
+		sliceName:    sliceName,
+		scale:        1,
+		keyRepeat:    common.NewKeyRepeat(),
+	}
+	e.popup = newPopup(e, popupSliceName)
+	return e, nil
+}
+
+// Name returns a element's name
+func (e *Element) Name() string {
+	return e.name
+}
+
+// IsVisible returns true if element is visible
+func (e *Element) IsVisible() bool {
+	return e.isVisible
+}
+
+// IsEnabled returns true if a element is enabled
+func (e *Element) IsEnabled() bool {
+	return e.isEnabled
+}
+
+// SetEnabled changes if a element is enabled. Disabling closes the popup
+func (e *Element) SetEnabled(isEnabled bool) {
+	e.isEnabled = isEnabled
+	if !isEnabled {
+		e.Close()
+	}
+}
+
+// SetVisible changes the visibility of a element. Hiding closes the popup
+func (e *Element) SetVisible(isVisible bool) {
+	e.isVisible = isVisible
+	if !isVisible {
+		e.Close()
+	}
+}
+
+// RenderIndex returns the render index of element
+func (e *Element) RenderIndex() int64 {
+	return e.renderIndex
+}
+
+// SetRenderIndex sets the render index of element
+func (e *Element) SetRenderIndex(renderIndex int64) {
+	e.renderIndex = renderIndex
+}
+
+// Update is called during a game update
+func (e *Element) Update(dt float64) {
+
+	if e.lerpPosition.IsEnabled() {
+		e.x, e.y = e.lerpPosition.Lerp(dt)
+		if !e.lerpPosition.IsEnabled() {
+			if e.lerpPosition.EndFunc() != nil {
+				e.lerpPosition.EndFunc()()
+			}
+			if e.lerpPosition.IsDestroyed() {
+				e.SetIsDestroyed(true)
+				return
+			}
+		}
+	}
+
+	if !e.isEnabled || !e.isVisible || e.IsOpen() {
+		return
+	}
+
+	x, y, ok := common.PointerJustPressed()
+	if ok {
+		e.isPressed = e.contains(x, y)
+		e.isFocused = e.isPressed
+	}
+	if e.isPressed {
+		x, y, ok = common.PointerPressed()
+		if !ok {
+			e.isPressed = false
+			if !common.IsPointerCancelled() {
+				e.Open()
+			}
+			return
+		} else if !e.contains(x, y) {
+			e.isPressed = false
+		}
+	}
+
+	if !e.isFocused {
+		return
+	}
+	if common.IsKeyJustPressed(ebiten.KeySpace) || common.IsKeyJustPressed(ebiten.KeyEnter) {
+		e.keyRepeat.Reset()
+		e.Open()
+		return
+	}
+	// arrows change the choice without opening, as native combo boxes do
+	step := e.keyRepeat.Count(ebiten.KeyDown, dt) - e.keyRepeat.Count(ebiten.KeyUp, dt)
+	if step != 0 && len(e.items) > 0 {
+		index := e.selected + step
+		if index < 0 {
+			index = 0
+		}
+		if index >= len(e.items) {
+			index = len(e.items) - 1
+		}
+		e.choose(index)
+	}
+}
+
+// contains returns true if x, y is inside the element
+func (e *Element) contains(x float64, y float64) bool {
+	return e.x <= x && x < e.x+float64(e.width)*e.scale && e.y <= y && y < e.y+float64(e.height)*e.scale
+}
+
+// Draw is called during a game update
+func (e *Element) Draw(dst *ebiten.Image) {
+	if !e.isVisible {
+		return
+	}
+
+	op := &ebiten.DrawImageOptions{}
+	op.GeoM.Translate(e.x, e.y)
+	op.GeoM.Scale(e.scale, e.scale)
+
+	textColor := e.color
+	if !e.isEnabled {
+		op.ColorM.ChangeHSV(0, 0, 1)
+		op.ColorM.Scale(0.5, 0.5, 0.5, 1)
+		textColor = common.DisabledColor(e.color)
+	}
+
+	slice, err := e.image.Slice(e.sliceName)
+	if err == nil {
+		common.DrawNineSlicing(dst, e.image.EbitenImage, slice.Keys[0], e.width, e.height, &op.GeoM, &op.ColorM)
+	}
+
+	h := float64(e.height) * e.scale
+	padding := h / 4
+	text := e.SelectedText()
+	if e.selected < 0 {
+		text = e.placeholder
+		textColor = common.DisabledColor(textColor)
+	}
+	layout := &common.TextLayout{
+		Width:      float64(e.width)*e.scale - padding - h,
+		Height:     h,
+		VAlign:     common.TextAlignMiddle,
+		IsEllipsis: true,
+	}
+	e.textImage.DrawText(dst, e.font, text, e.x+padding, e.y, layout, textColor)
+
+	// the arrow is a stack of shrinking bars, pointing up while open
+	bar := h / 12
+	cx := e.x + float64(e.width)*e.scale - h/2
+	cy := e.y + h/2 - bar*1.5
+	for i := 0; i < 3; i++ {
+		row := float64(i)
+		if e.IsOpen() {
+			row = float64(2 - i)
+		}
+		half := bar * float64(3-i)
+		ebitenutil.DrawRect(dst, cx-half, cy+row*bar, half*2, bar, textColor)
+	}
+}
+
+// Open shows the popup list, capturing input until it closes
+func (e *Element) Open() {
+	if !e.isEnabled || !e.isVisible || len(e.items) == 0 {
+		return
+	}
+	e.isFocused = true
+	e.popup.open()
+}
+
+// Close hides the popup list without changing the choice
+func (e *Element) Close() {
+	e.popup.close()
+}
+
+// IsOpen returns true if the popup list is shown
+func (e *Element) IsOpen() bool {
+	return e.popup.isVisible
+}
+
+// Popup returns the popup list element, drawn above the element
+func (e *Element) Popup() *Popup {
+	return e.popup
+}
+
+// choose selects an item as the user did, calling the change function if it changed
+func (e *Element) choose(index int) {
+	if index == e.selected {
+		return
+	}
+	e.selected = index
+	if e.onChange != nil {
+		e.onChange(e, index)
+	}
+}
+
+// SetText sets the placeholder shown while nothing is selected
+func (e *Element) SetText(text string) {
+	e.placeholder = text
+}
+
+// Items returns the choices of the element
+func (e *Element) Items() []string {
+	return e.items
+}
+
+// SetItems replaces the choices, clearing the selection
+func (e *Element) SetItems(items []string) {
+	e.Close()
+	e.items = items
+	e.selected = -1
+}
+
+// Selected returns the index of the chosen item, -1 if none
+func (e *Element) Selected() int {
+	return e.selected
+}
+
+// SetSelected chooses an item by index, -1 for none, without calling the change function
+func (e *Element) SetSelected(index int) {
+	if index < -1 || index >= len(e.items) {
+		index = -1
+	}
+	e.selected = index
+}
+
+// SelectedText returns the chosen item, empty if none
+func (e *Element) SelectedText() string {
+	if e.selected < 0 || e.selected >= len(e.items) {
+		return ""
+	}
+	return e.items[e.selected]
+}
+
+// Placeholder returns the text shown while nothing is selected
+func (e *Element) Placeholder() string {
+	return e.placeholder
+}
+
+// SetPlaceholder sets the text shown while nothing is selected
+func (e *Element) SetPlaceholder(placeholder string) {
+	e.placeholder = placeholder
+}
+
+// IsFocused returns true if keys change the choice
+func (e *Element) IsFocused() bool {
+	return e.isFocused
+}
+
+// SetIsFocused sets if keys change the choice. Pressing the element focuses it
+func (e *Element) SetIsFocused(isFocused bool) {
+	e.isFocused = isFocused
+	e.keyRepeat.Reset()
+}
+
+// SetOnChange sets a function called with the index of the item the user chose
+func (e *Element) SetOnChange(f func(e *Element, index int)) {
+	e.onChange = f
+}
+
+// IsDestroyed returns true when the element is flagged for deletion
+func (e *Element) IsDestroyed() bool {
+	return e.isDestroyed
+}
+
+// LerpPosition changes an element's position over duration
+func (e *Element) LerpPosition(endPositionX, endPositionY float64, duration time.Duration, isDestroyed bool, endFunc func()) {
+	e.lerpPosition.Init(e.x, e.y, endPositionX, endPositionY, duration, true, endFunc, isDestroyed)
+}
+
+// Position returns an element's position
+func (e *Element) Position() (float64, float64) {
+	return e.x, e.y
+}
+
+// SetPosition sets an element's position
+func (e *Element) SetPosition(x float64, y float64) {
+	e.x = x
+	e.y = y
+}
+
+// Width returns an element's width
+func (e *Element) Width() int {
+	return e.width
+}
+
+// SetWidth sets an element's width
+func (e *Element) SetWidth(width int) {
+	e.width = width
+}
+
+// Height returns an element's height
+func (e *Element) Height() int {
+	return e.height
+}
+
+// SetHeight sets an element's height
+func (e *Element) SetHeight(height int) {
+	e.height = height
+}
+
+// OnRemove closes the popup and removes it with the element
+func (e *Element) OnRemove() {
+	e.Close()
+	e.popup.isDestroyed = true
+}
+
+// SetIsDestroyed sets an element and its popup to be destroyed on next update
+func (e *Element) SetIsDestroyed(isDestroyed bool) {
+	e.isDestroyed = true
+	e.Close()
+	e.popup.isDestroyed = true
+}
@@ -0,0 +1,195 @@
+package dropdown
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/xackery/egui/common"
+)
+
+// newTestDropdown returns a dropdown 100x20 at the origin, so its popup starts at y 20
+func newTestDropdown(t *testing.T, items []string) *Element {
+	e, err := New("dropdown", "scene", 0, 0, 100, 20, nil, nil, nil, "", "", items)
+	if err != nil {
+		t.Fatalf("new: %v", err)
+	}
+	return e
+}
+
+// numberedItems returns n items named by their index
+func numberedItems(n int) []string {
+	items := make([]string, n)
+	for i := range items {
+		items[i] = fmt.Sprintf("item %d", i)
+	}
+	return items
+}
+
+func TestFind(t *testing.T) {
+	items := []string{"Apple", "apricot", "Banana", "blueberry", "cherry"}
+	tests := []struct {
+		name      string
+		highlight int
+		prefix    string
+		want      int
+	}{
+		{"letter after highlight", 0, "b", 2},
+		{"letter cycles", 2, "b", 3},
+		{"letter wraps", 3, "b", 2},
+		{"prefix includes highlight", 0, "ap", 0},
+		{"longer prefix", 0, "apr", 1},
+		{"repeated letter cycles", 0, "aa", 1},
+		{"ignores case", 4, "a", 0},
+		{"word", 0, "ch", 4},
+		{"not found", 0, "z", -1},
+	}
+	for _, tt := range tests {
+		p := newTestDropdown(t, items).Popup()
+		p.highlight = tt.highlight
+		if got := p.find(tt.prefix); got != tt.want {
+			t.Errorf("%s: found %d, want %d", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestOpen(t *testing.T) {
+	tests := []struct {
+		name      string
+		items     []string
+		selected  int
+		isOpen    bool
+		highlight int
+		top       int
+	}{
+		{"nothing selected", numberedItems(20), -1, true, 0, 0},
+		{"selection centered", numberedItems(20), 10, true, 10, 6},
+		{"centered at the end", numberedItems(20), 19, true, 19, 12},
+		{"fewer items than rows", numberedItems(3), 2, true, 2, 0},
+		{"no items", nil, -1, false, 0, 0},
+	}
+	for _, tt := range tests {
+		e := newTestDropdown(t, tt.items)
+		e.SetSelected(tt.selected)
+		e.Open()
+		p := e.Popup()
+		if e.IsOpen() != tt.isOpen {
+			t.Errorf("%s: open %v, want %v", tt.name, e.IsOpen(), tt.isOpen)
+		}
+		if tt.isOpen && common.InputCaptor() != p {
+			t.Errorf("%s: popup does not capture input", tt.name)
+		}
+		if p.highlight != tt.highlight || p.top != tt.top {
+			t.Errorf("%s: highlight %d top %d, want %d %d", tt.name, p.highlight, p.top, tt.highlight, tt.top)
+		}
+		e.Close()
+		if e.IsOpen() || common.InputCaptor() == p {
+			t.Errorf("%s: popup still open or capturing input after close", tt.name)
+		}
+	}
+}
+
+func TestScrollTo(t *testing.T) {
+	tests := []struct {
+		name  string
+		top   int
+		index int
+		want  int
+	}{
+		{"shown", 0, 7, 0},
+		{"below", 0, 10, 3},
+		{"above", 5, 1, 1},
+		{"past the end", 0, 30, 12},
+	}
+	for _, tt := range tests {
+		p := newTestDropdown(t, numberedItems(20)).Popup()
+		p.top = tt.top
+		p.scrollTo(tt.index)
+		if p.top != tt.want {
+			t.Errorf("%s: top %d, want %d", tt.name, p.top, tt.want)
+		}
+	}
+}
+
+func TestItemAt(t *testing.T) {
+	tests := []struct {
+		name  string
+		items []string
+		top   int
+		x     float64
+		y     float64
+		want  int
+	}{
+		{"first row", numberedItems(20), 0, 5, 25, 0},
+		{"second row", numberedItems(20), 0, 5, 45, 1},
+		{"scrolled", numberedItems(20), 4, 5, 45, 5},
+		{"on the dropdown", numberedItems(20), 0, 5, 10, -1},
+		{"right of the popup", numberedItems(20), 0, 150, 25, -1},
+		{"below the rows", numberedItems(3), 0, 5, 85, -1},
+	}
+	for _, tt := range tests {
+		p := newTestDropdown(t, tt.items).Popup()
+		p.top = tt.top
+		if got := p.itemAt(tt.x, tt.y); got != tt.want {
+			t.Errorf("%s: item %d, want %d", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestChoose(t *testing.T) {
+	tests := []struct {
+		name    string
+		indexes []int
+		want    int
+		changes int
+	}{
+		{"choose", []int{1}, 1, 1},
+		{"same twice", []int{1, 1}, 1, 1},
+		{"out of range", []int{1, 5}, 1, 1},
+		{"negative", []int{-1}, -1, 0},
+	}
+	for _, tt := range tests {
+		e := newTestDropdown(t, []string{"a", "b", "c"})
+		changes := 0
+		e.SetOnChange(func(e *Element, index int) {
+			changes++
+		})
+		for _, index := range tt.indexes {
+			e.Open()
+			e.Popup().choose(index)
+			if e.IsOpen() {
+				t.Errorf("%s: open after choosing %d", tt.name, index)
+			}
+		}
+		if e.Selected() != tt.want || changes != tt.changes {
+			t.Errorf("%s: selected %d with %d changes, want %d %d", tt.name, e.Selected(), changes, tt.want, tt.changes)
+		}
+	}
+}
+
+func TestSetSelected(t *testing.T) {
+	tests := []struct {
+		index int
+		want  int
+		text  string
+	}{
+		{1, 1, "b"},
+		{-1, -1, ""},
+		{3, -1, ""},
+		{-2, -1, ""},
+	}
+	for _, tt := range tests {
+		e := newTestDropdown(t, []string{"a", "b", "c"})
+		e.SetSelected(tt.index)
+		if e.Selected() != tt.want || e.SelectedText() != tt.text {
+			t.Errorf("set %d: selected %d %q, want %d %q", tt.index, e.Selected(), e.SelectedText(), tt.want, tt.text)
+		}
+	}
+
+	e := newTestDropdown(t, []string{"a", "b"})
+	e.SetSelected(1)
+	e.Open()
+	e.SetItems([]string{"c"})
+	if e.Selected() != -1 || e.IsOpen() {
+		t.Errorf("after set items selected %d open %v, want -1 false", e.Selected(), e.IsOpen())
+	}
+}
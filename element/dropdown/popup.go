@@ -0,0 +1,411 @@
+package dropdown
+
+import (
+	"image/color"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/hajimehoshi/ebiten"
+	"github.com/hajimehoshi/ebiten/ebitenutil"
+	"github.com/xackery/egui/common"
+)
+
+// typeAheadTimeout is how long after the last typed character a new search starts, in seconds
+const typeAheadTimeout = 1.0
+
+// Popup is the list a dropdown opens, placed below it.
+// A popup captures input while open, closing on a press outside it or escape
+type Popup struct {
+	dropdown       *Element
+	sliceName      string
+	isVisible      bool
+	isPressed      bool
+	isOpening      bool
+	renderIndex    int64
+	isDestroyed    bool
+	maxRows        int
+	top            int
+	highlight      int
+	highlightColor color.Color
+	typeAhead      string
+	typeAheadTime  float64
+	cursorX        float64
+	cursorY        float64
+	keyRepeat      *common.KeyRepeat
+}
+
+// newPopup creates the closed popup of a dropdown
+func newPopup(dropdown *Element, sliceName string) *Popup {
+	return &Popup{
+		dropdown:       dropdown,
+		sliceName:      sliceName,
+		maxRows:        8,
+		highlightColor: color.RGBA{51, 102, 204, 160},
+		keyRepeat:      common.NewKeyRepeat(),
+	}
+}
+
+// Name returns the dropdown name followed by .popup
+func (p *Popup) Name() string {
+	return p.dropdown.name + ".popup"
+}
+
+// IsVisible returns true if the popup is open
+func (p *Popup) IsVisible() bool {
+	return p.isVisible
+}
+
+// SetVisible opens or closes the popup
+func (p *Popup) SetVisible(isVisible bool) {
+	if isVisible {
+		p.dropdown.Open()
+		return
+	}
+	p.close()
+}
+
+// IsEnabled returns true if the dropdown is enabled
+func (p *Popup) IsEnabled() bool {
+	return p.dropdown.isEnabled
+}
+
+// SetEnabled changes if the dropdown is enabled
+func (p *Popup) SetEnabled(isEnabled bool) {
+	p.dropdown.SetEnabled(isEnabled)
+}
+
+// RenderIndex returns the render index of element
+func (p *Popup) RenderIndex() int64 {
+	return p.renderIndex
+}
+
+// SetRenderIndex sets the render index of element
+func (p *Popup) SetRenderIndex(renderIndex int64) {
+	p.renderIndex = renderIndex
+}
+
+// open shows the popup with the chosen item highlighted and in view
+func (p *Popup) open() {
+	// a removed popup is never updated, and would hold input forever
+	if p.isVisible || p.isDestroyed {
+		return
+	}
+	p.isVisible = true
+	p.isOpening = true
+	p.isPressed = false
+	p.typeAhead = ""
+	p.keyRepeat.Reset()
+	p.highlight = p.dropdown.selected
+	if p.highlight < 0 {
+		p.highlight = 0
+	}
+	p.top = p.highlight - p.rows()/2
+	p.clampTop()
+	common.CaptureInput(p)
+}
+
+// close hides the popup and releases input
+func (p *Popup) close() {
+	p.isVisible = false
+	p.isPressed = false
+	common.ReleaseInput(p)
+}
+
+// rows returns how many items are shown at once
+func (p *Popup) rows() int {
+	n := len(p.dropdown.items)
+	if p.maxRows > 0 && n > p.maxRows {
+		return p.maxRows
+	}
+	return n
+}
+
+// rowHeight returns the height of an item, the same as the dropdown
+func (p *Popup) rowHeight() float64 {
+	return float64(p.dropdown.height) * p.dropdown.scale
+}
+
+// bounds returns the popup rectangle, below the dropdown
+func (p *Popup) bounds() (float64, float64, float64, float64) {
+	d := p.dropdown
+	return d.x, d.y + p.rowHeight(), float64(d.width) * d.scale, p.rowHeight() * float64(p.rows())
+}
+
+// itemAt returns the index of the item at x, y, or -1 if x, y is outside the popup
+func (p *Popup) itemAt(x float64, y float64) int {
+	px, py, w, h := p.bounds()
+	if x < px || x >= px+w || y < py || y >= py+h {
+		return -1
+	}
+	index := p.top + int((y-py)/p.rowHeight())
+	if index >= len(p.dropdown.items) {
+		return -1
+	}
+	return index
+}
+
+// clampTop keeps scrolling within the items
+func (p *Popup) clampTop() {
+	if p.top > len(p.dropdown.items)-p.rows() {
+		p.top = len(p.dropdown.items) - p.rows()
+	}
+	if p.top < 0 {
+		p.top = 0
+	}
+}
+
+// scrollTo scrolls the least needed to show an item
+func (p *Popup) scrollTo(index int) {
+	if index < p.top {
+		p.top = index
+	}
+	if index >= p.top+p.rows() {
+		p.top = index - p.rows() + 1
+	}
+	p.clampTop()
+}
+
+// Update is called during a game update
+func (p *Popup) Update(dt float64) {
+	d := p.dropdown
+	if !p.isVisible {
+		return
+	}
+	if !d.isEnabled || !d.isVisible || d.isDestroyed || len(d.items) == 0 {
+		p.close()
+		return
+	}
+
+	common.BeginCapturedInput(p)
+	defer common.EndCapturedInput()
+	if p.updatePointer() {
+		return
+	}
+	// the key that opened the popup is ignored, so enter does not choose at once
+	if p.isOpening {
+		p.isOpening = false
+		return
+	}
+	p.updateKeys(dt)
+}
+
+// updatePointer scrolls with the wheel, highlights the hovered item and chooses the pressed one.
+// It returns true if the popup closed
+func (p *Popup) updatePointer() bool {
+	cx, cy := common.CursorPosition()
+	hover := p.itemAt(cx, cy)
+	// hovering only moves the highlight when the cursor moves, so keys can move it under a resting cursor
+	isMoved := cx != p.cursorX || cy != p.cursorY
+	p.cursorX, p.cursorY = cx, cy
+	_, wheel := ebiten.Wheel()
+	if wheel != 0 && hover >= 0 {
+		if wheel > 0 {
+			p.top--
+		} else {
+			p.top++
+		}
+		p.clampTop()
+		hover = p.itemAt(cx, cy)
+		isMoved = true
+	}
+
+	x, y, ok := common.PointerJustPressed()
+	if ok {
+		if p.itemAt(x, y) < 0 {
+			// a press outside, including on the dropdown itself, closes without choosing
+			p.close()
+			return true
+		}
+		p.isPressed = true
+	}
+	if p.isPressed {
+		x, y, ok = common.PointerPressed()
+		if ok {
+			hover = p.itemAt(x, y)
+		} else {
+			p.isPressed = false
+			if p.highlight >= 0 && !common.IsPointerCancelled() {
+				p.choose(p.highlight)
+				return true
+			}
+		}
+	}
+	if hover >= 0 && (isMoved || p.isPressed) {
+		p.highlight = hover
+	}
+	return false
+}
+
+// updateKeys moves the highlight with arrows, page keys, home, end and typing, choosing with enter
+func (p *Popup) updateKeys(dt float64) {
+	items := p.dropdown.items
+	if common.IsKeyJustPressed(ebiten.KeyEscape) {
+		p.close()
+		return
+	}
+	if common.IsKeyJustPressed(ebiten.KeyEnter) || common.IsKeyJustPressed(ebiten.KeyKPEnter) {
+		p.choose(p.highlight)
+		return
+	}
+
+	index := p.highlight
+	page := p.rows()
+	index += p.keyRepeat.Count(ebiten.KeyDown, dt) - p.keyRepeat.Count(ebiten.KeyUp, dt)
+	index += (p.keyRepeat.Count(ebiten.KeyPageDown, dt) - p.keyRepeat.Count(ebiten.KeyPageUp, dt)) * page
+	if common.IsKeyJustPressed(ebiten.KeyHome) {
+		index = 0
+	}
+	if common.IsKeyJustPressed(ebiten.KeyEnd) {
+		index = len(items) - 1
+	}
+
+	p.typeAheadTime += dt
+	chars := common.InputChars()
+	if len(chars) > 0 {
+		if p.typeAheadTime > typeAheadTimeout {
+			p.typeAhead = ""
+		}
+		p.typeAheadTime = 0
+		p.typeAhead += strings.ToLower(string(chars))
+		if found := p.find(p.typeAhead); found >= 0 {
+			index = found
+		}
+	}
+
+	if index < 0 {
+		index = 0
+	}
+	if index >= len(items) {
+		index = len(items) - 1
+	}
+	if index != p.highlight {
+		p.highlight = index
+		p.scrollTo(index)
+	}
+}
+
+// find returns the index of the next item starting with prefix, or -1 if none does.
+// Typing the same letter again cycles through items starting with it
+func (p *Popup) find(prefix string) int {
+	items := p.dropdown.items
+	start := p.highlight
+	r, size := utf8.DecodeRuneInString(prefix)
+	if strings.Count(prefix, string(r))*size == len(prefix) {
+		// repeating a single letter searches for that letter after the highlighted item
+		prefix = string(r)
+		start++
+	}
+	for i := 0; i < len(items); i++ {
+		index := (start + i) % len(items)
+		if index < 0 {
+			index += len(items)
+		}
+		if strings.HasPrefix(strings.ToLower(items[index]), prefix) {
+			return index
+		}
+	}
+	return -1
+}
+
+// choose closes the popup and chooses an item
+func (p *Popup) choose(index int) {
+	p.close()
+	if index < 0 || index >= len(p.dropdown.items) {
+		return
+	}
+	p.dropdown.choose(index)
+}
+
+// Draw is called during a game update
+func (p *Popup) Draw(dst *ebiten.Image) {
+	if !p.isVisible {
+		return
+	}
+	d := p.dropdown
+	x, y, w, h := p.bounds()
+	rowHeight := p.rowHeight()
+
+	slice, err := d.image.Slice(p.sliceName)
+	if err == nil {
+		op := &ebiten.DrawImageOptions{}
+		op.GeoM.Translate(x, y)
+		op.GeoM.Scale(d.scale, d.scale)
+		common.DrawNineSlicing(dst, d.image.EbitenImage, slice.Keys[0], d.width, int(h/d.scale), &op.GeoM, &op.ColorM)
+	}
+
+	padding := rowHeight / 4
+	barWidth := 0.0
+	if p.rows() < len(d.items) {
+		barWidth = padding
+	}
+	layout := &common.TextLayout{
+		Width:      w - padding*2 - barWidth,
+		Height:     rowHeight,
+		VAlign:     common.TextAlignMiddle,
+		IsEllipsis: true,
+	}
+	for row := 0; row < p.rows(); row++ {
+		index := p.top + row
+		if index >= len(d.items) {
+			break
+		}
+		ry := y + float64(row)*rowHeight
+		if index == p.highlight {
+			ebitenutil.DrawRect(dst, x, ry, w-barWidth, rowHeight, p.highlightColor)
+		}
+		d.font.DrawText(dst, d.items[index], x+padding, ry, layout, d.color, -1)
+	}
+
+	if barWidth > 0 {
+		thumb := h * float64(p.rows()) / float64(len(d.items))
+		thumbY := y + h*float64(p.top)/float64(len(d.items))
+		ebitenutil.DrawRect(dst, x+w-barWidth, thumbY, barWidth, thumb, common.DisabledColor(d.color))
+	}
+}
+
+// OnRemove closes the popup for good when it is removed from its scene, releasing input
+func (p *Popup) OnRemove() {
+	p.close()
+	p.isDestroyed = true
+}
+
+// OnSceneExit closes the popup when its scene stops being shown, releasing input
+func (p *Popup) OnSceneExit() {
+	p.close()
+}
+
+// SetText is not used by popups
+func (p *Popup) SetText(text string) {
+}
+
+// MaxRows returns how many items are shown before the popup scrolls
+func (p *Popup) MaxRows() int {
+	return p.maxRows
+}
+
+// SetMaxRows sets how many items are shown before the popup scrolls, 0 shows every item
+func (p *Popup) SetMaxRows(maxRows int) {
+	p.maxRows = maxRows
+	p.clampTop()
+}
+
+// SetHighlightColor sets the color drawn behind the highlighted item
+func (p *Popup) SetHighlightColor(highlightColor color.Color) {
+	p.highlightColor = highlightColor
+}
+
+// IsDestroyed returns true when the popup or its dropdown is flagged for deletion
+func (p *Popup) IsDestroyed() bool {
+	return p.isDestroyed
+}
+
+// SetIsDestroyed destroys the popup with its dropdown
+func (p *Popup) SetIsDestroyed(isDestroyed bool) {
+	p.dropdown.SetIsDestroyed(true)
+}
+
+// LerpPosition moves the dropdown, which the popup follows
+func (p *Popup) LerpPosition(endPositionX, endPositionY float64, duration time.Duration, isDestroyed bool, endFunc func()) {
+	p.dropdown.LerpPosition(endPositionX, endPositionY-p.rowHeight(), duration, isDestroyed, endFunc)
+}
@@ -23,3 +23,43 @@ type Interfacer interface {
 	SetText(text string)
 	LerpPosition(endPositionX, endpositionY float64, duration time.Duration, isDestroyed bool, endFunc func())
 }
+
+// Pivoter is implemented by elements that expose a pivot point, e.g. the feet of a character.
+// Scenes sorted by Y use the pivot to decide draw order, elements without one sort by their bottom edge
+type Pivoter interface {
+	Pivot() (float64, float64)
+}
+
+// Positioner is implemented by elements that can be placed, e.g. inside a scroll view
+type Positioner interface {
+	Position() (float64, float64)
+	SetPosition(x float64, y float64)
+}
+
+// Sizer is implemented by elements with a known size
+type Sizer interface {
+	Width() int
+	Height() int
+}
+
+// Remover is implemented by elements that clean up when removed from their scene, e.g. popups releasing input
+type Remover interface {
+	OnRemove()
+}
+
+// SceneExiter is implemented by elements that reset when their scene stops being shown, e.g. tooltips and popups
+type SceneExiter interface {
+	OnSceneExit()
+}
+
+// PointerClaimer is implemented by elements that keep a press from elements under them, e.g. windows.
+// Layers offer a press to claimers from front to back before updating elements
+type PointerClaimer interface {
+	ClaimPointer(x float64, y float64) bool
+}
+
+// Focuser is implemented by elements that take keys while focused
+type Focuser interface {
+	IsFocused() bool
+	SetIsFocused(isFocused bool)
+}
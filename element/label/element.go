@@ -2,11 +2,11 @@ package label
 
 import (
 	"image/color"
+	"math"
 	"time"
 
 	"github.com/hajimehoshi/ebiten"
 	"github.com/hajimehoshi/ebiten/inpututil"
-	"github.com/hajimehoshi/ebiten/text"
 	"github.com/xackery/egui/common"
 )
 
@@ -33,6 +33,20 @@ type Element struct {
 	lerpColor       *common.LerpColor
 	color           color.Color
 	font            *common.Font
+	align           common.TextAlign
+	vAlign          common.TextVAlign
+	isWrapped       bool
+	isEllipsis      bool
+	isAutoSize      bool
+	isRichText      bool
+	richText        *common.RichText
+	richStyle       common.RichTextStyle
+	richLayout      *common.RichTextLayout
+	onLinkPressed   func(e *Element, link string)
+	pressX          float64
+	pressY          float64
+	isCached        bool
+	textImage       common.TextImage
 }
 
 // New creates a new button instance
@@ -53,6 +67,8 @@ func New(name string, scene string, text string, x float64, y float64, font *com
 		height:       50,
 		font:         font,
 		scale:        1,
+		richStyle:    common.RichTextStyle{Font: font},
+		isCached:     true,
 	}
 
 	return e, nil
@@ -97,10 +113,10 @@ func (e *Element) SetRenderIndex(renderIndex int64) {
 func (e *Element) Update(dt float64) {
 
 	if e.lerpPosition.IsEnabled() {
-		e.x, e.y = e.lerpPosition.Lerp()
+		e.x, e.y = e.lerpPosition.Lerp(dt)
 		if !e.lerpPosition.IsEnabled() {
 			if e.lerpPosition.EndFunc() != nil {
-				e.lerpPosition.EndFunc()
+				e.lerpPosition.EndFunc()()
 			}
 			if e.lerpPosition.IsDestroyed() {
 				e.isDestroyed = true
@@ -112,24 +128,22 @@ func (e *Element) Update(dt float64) {
 	isRecentlyPressed := false
 	//mobile and desktop use differnet touch devices
 	for _, t := range inpututil.JustPressedTouchIDs() {
-		x, y := ebiten.TouchPosition(t)
-		fx := float64(x)
-		fy := float64(y)
+		fx, fy := common.TouchPosition(t)
 		if e.x <= fx && fx < e.x+float64(e.width) && e.y <= fy && fy < e.y+float64(e.height) {
 			e.isPressed = true
 			isRecentlyPressed = true
+			e.pressX, e.pressY = fx, fy
 		} else {
 			e.isPressed = false
 		}
 	}
 
 	if ebiten.IsMouseButtonPressed(ebiten.MouseButtonLeft) {
-		x, y := ebiten.CursorPosition()
-		fx := float64(x)
-		fy := float64(y)
+		fx, fy := common.CursorPosition()
 		if e.x <= fx && fx < e.x+float64(e.width) && e.y <= fy && fy < e.y+float64(e.height) {
 			e.isPressed = true
 			isRecentlyPressed = true
+			e.pressX, e.pressY = fx, fy
 		} else {
 			e.isPressed = false
 		}
@@ -142,6 +156,12 @@ func (e *Element) Update(dt float64) {
 		if e.onPressFunction != nil {
 			e.onPressFunction()
 		}
+		if e.isRichText && e.onLinkPressed != nil {
+			link, ok := e.richTextLayout().LinkAt(e.pressX-e.x, e.pressY-e.y)
+			if ok {
+				e.onLinkPressed(e, link)
+			}
+		}
 		e.isPressed = false
 	}
 }
@@ -163,39 +183,186 @@ func (e *Element) Draw(dst *ebiten.Image) {
 		op.ColorM.Scale(0.5, 0.5, 0.5, 1)
 	}
 
-	text.Draw(dst, e.text, e.font.Face, int(e.x), int(e.y), e.color)
+	if !e.isCached {
+		if e.isRichText {
+			e.richTextLayout().Draw(dst, e.richStyle.Icons, e.x, e.y, e.color, -1)
+			return
+		}
+		e.font.DrawText(dst, e.text, e.x, e.y, e.textLayout(), e.color, -1)
+		return
+	}
+	if e.isRichText {
+		e.textImage.DrawRichText(dst, e.richTextLayout(), e.richStyle.Icons, e.x, e.y, e.color)
+		return
+	}
+	e.textImage.DrawText(dst, e.font, e.text, e.x, e.y, e.textLayout(), e.color)
+
+}
 
-	/*_, th := e.font.MeasureSize(e.text)
-	tx := e.X() * e.ui.tileScale
-	tx += e.shape.Dx() * e.ui.tileScale / 2
+// SetText changes the text on the element. Rich text markup is parsed here, once
+func (e *Element) SetText(text string) {
+	e.text = text
+	e.richText = nil
+	if e.isRichText {
+		e.richText = common.ParseRichText(text)
+	}
+	e.invalidate()
+}
 
-	ty := e.Y() * e.ui.tileScale
-	ty += (e.shape.Dy()*e.ui.tileScale - float64(th)*e.ui.textScale) / 2
+// Text returns the text of the element
+func (e *Element) Text() string {
+	return e.text
+}
 
-	cr, cg, cb, ca := e.color.RGBA()
-	r8 := uint8(cr >> 8)
-	g8 := uint8(cg >> 8)
-	b8 := uint8(cb >> 8)
-	a8 := uint8(ca >> 8)
-	var c color.Color = color.RGBA{r8, g8, b8, uint8(uint16(a8) * uint16(opacity) / 255)}
-	if !e.isEnabled {
-		c = color.RGBA{r8, g8, b8, uint8(uint16(a8) * uint16(opacity) / (2 * 255))}
+// textLayout returns the box text is laid out in
+func (e *Element) textLayout() *common.TextLayout {
+	return &common.TextLayout{
+		Width:      float64(e.width) * e.scale,
+		Height:     float64(e.height) * e.scale,
+		Align:      e.align,
+		VAlign:     e.vAlign,
+		IsWrapped:  e.isWrapped,
+		IsEllipsis: e.isEllipsis,
 	}
-	l := e.font.Language
-	if l == language.Und {
-		l = e.ui.defaultLanguage
+}
+
+// richTextLayout returns the laid out rich text, laying it out again if anything changed
+func (e *Element) richTextLayout() *common.RichTextLayout {
+	if e.richLayout == nil {
+		e.richLayout = e.richText.Layout(&e.richStyle, e.textLayout())
 	}
-	if e.isTextShadow {
-		e.font.DrawText(dst, e.text, tx+e.ui.textScale, ty+e.ui.textScale, e.ui.textScale, 0, color.Black, len([]rune(e.text)))
+	return e.richLayout
+}
+
+// invalidate refits the element and drops the rich text layout after a change to text, fonts or size
+func (e *Element) invalidate() {
+	e.autoSize()
+	e.richLayout = nil
+}
+
+// autoSize fits the element to its text. Wrapped labels keep their width and grow in height
+func (e *Element) autoSize() {
+	if !e.isAutoSize || e.font == nil {
+		return
 	}
-	e.font.DrawText(dst, e.text, tx, ty, e.ui.textScale, 0, c, len([]rune(e.text)))
-	*/
+	if e.isRichText {
+		layout := e.textLayout()
+		layout.Height = 0
+		if !e.isWrapped {
+			layout.Width = 0
+		}
+		rl := e.richText.Layout(&e.richStyle, layout)
+		if !e.isWrapped {
+			e.width = int(math.Ceil(rl.Width))
+		}
+		e.height = int(math.Ceil(rl.Height))
+		return
+	}
+	if e.isWrapped {
+		layout := e.textLayout()
+		layout.Height = 0
+		e.height = len(e.font.LayoutText(e.text, layout)) * e.font.RenderingLineHeight
+		return
+	}
+	e.width, e.height = e.font.MeasureSize(e.text)
+}
 
+// Align returns the horizontal alignment of text
+func (e *Element) Align() common.TextAlign {
+	return e.align
 }
 
-// SetText changes the text on the element
-func (e *Element) SetText(text string) {
-	e.text = text
+// SetAlign sets the horizontal alignment of text within the element
+func (e *Element) SetAlign(align common.TextAlign) {
+	e.align = align
+	e.richLayout = nil
+}
+
+// VAlign returns the vertical alignment of text
+func (e *Element) VAlign() common.TextVAlign {
+	return e.vAlign
+}
+
+// SetVAlign sets the vertical alignment of text within the element
+func (e *Element) SetVAlign(vAlign common.TextVAlign) {
+	e.vAlign = vAlign
+	e.richLayout = nil
+}
+
+// IsWrapped returns true if text wraps to the element width
+func (e *Element) IsWrapped() bool {
+	return e.isWrapped
+}
+
+// SetIsWrapped sets if text wraps to the element width
+func (e *Element) SetIsWrapped(isWrapped bool) {
+	e.isWrapped = isWrapped
+	e.invalidate()
+}
+
+// IsEllipsis returns true if text overflowing the element is cut with an ellipsis
+func (e *Element) IsEllipsis() bool {
+	return e.isEllipsis
+}
+
+// SetIsEllipsis sets if text overflowing the element is cut with an ellipsis
+func (e *Element) SetIsEllipsis(isEllipsis bool) {
+	e.isEllipsis = isEllipsis
+}
+
+// IsCached returns true if text is pre-rendered to an offscreen image
+func (e *Element) IsCached() bool {
+	return e.isCached
+}
+
+// SetIsCached sets if text is pre-rendered to an offscreen image, on by default.
+// Turn it off for text that changes every frame
+func (e *Element) SetIsCached(isCached bool) {
+	e.isCached = isCached
+	if !isCached {
+		e.textImage.Dispose()
+	}
+}
+
+// IsRichText returns true if text is parsed as markup
+func (e *Element) IsRichText() bool {
+	return e.isRichText
+}
+
+// SetIsRichText sets if text is parsed as markup, e.g. "[color=#ff0]gold[/color] [icon=coin] [link=shop]buy[/link]"
+func (e *Element) SetIsRichText(isRichText bool) {
+	e.isRichText = isRichText
+	e.SetText(e.text)
+}
+
+// SetRichFonts sets the fonts used by bold and italic markup. Missing fonts fall back to the regular font
+func (e *Element) SetRichFonts(bold *common.Font, italic *common.Font, boldItalic *common.Font) {
+	e.richStyle.BoldFont = bold
+	e.richStyle.ItalicFont = italic
+	e.richStyle.BoldItalicFont = boldItalic
+	e.invalidate()
+}
+
+// SetIcons sets the image containing slices drawn by icon markup
+func (e *Element) SetIcons(img *common.Image) {
+	e.richStyle.Icons = img
+	e.invalidate()
+}
+
+// SetOnLinkPressed sets a function called with the id of a link span when it is pressed
+func (e *Element) SetOnLinkPressed(f func(e *Element, link string)) {
+	e.onLinkPressed = f
+}
+
+// IsAutoSize returns true if the element sizes itself to its text
+func (e *Element) IsAutoSize() bool {
+	return e.isAutoSize
+}
+
+// SetIsAutoSize sets if the element sizes itself to its text
+func (e *Element) SetIsAutoSize(isAutoSize bool) {
+	e.isAutoSize = isAutoSize
+	e.invalidate()
 }
 
 // SetOnPressed sets a element state
@@ -215,7 +382,7 @@ func (e *Element) IsDestroyed() bool {
 
 // LerpPosition changes an element's position over duration
 func (e *Element) LerpPosition(endPositionX, endPositionY float64, duration time.Duration, isDestroyed bool, endFunc func()) {
-	e.lerpPosition.Init(time.Now(), e.x, e.y, endPositionX, endPositionY, duration, true, endFunc, isDestroyed)
+	e.lerpPosition.Init(e.x, e.y, endPositionX, endPositionY, duration, true, endFunc, isDestroyed)
 }
 
 // Position returns an element's position
@@ -237,6 +404,7 @@ func (e *Element) Width() int {
 // SetWidth sets an element's width
 func (e *Element) SetWidth(width int) {
 	e.width = width
+	e.invalidate()
 }
 
 // Height returns an element's height
@@ -247,6 +415,7 @@ func (e *Element) Height() int {
 // SetHeight sets an element's height
 func (e *Element) SetHeight(height int) {
 	e.height = height
+	e.richLayout = nil
 }
 
 // SetIsDestroyed sets an element to be destroyed on next update
@@ -0,0 +1,793 @@
+package listview
+
+import (
+	"image/color"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/hajimehoshi/ebiten"
+	"github.com/hajimehoshi/ebiten/ebitenutil"
+	"github.com/xackery/egui/common"
+	"github.com/xackery/egui/element"
+)
+
+// wheelStep is how far a wheel notch scrolls
+const wheelStep = 40
+
+// Element represents a virtualized list of items from a data source.
+// A element only creates and draws rows for visible items, recycling them as it scrolls
+// A element can select, sort and filter items
+type Element struct {
+	name           string
+	x              float64
+	y              float64
+	scale          float64
+	width          int
+	height         int
+	source         DataSource
+	rowHeight      int
+	selectionMode  SelectionMode
+	selected       map[int]bool
+	isEnabled      bool
+	isVisible      bool
+	isFocused      bool
+	isPressed      bool
+	isDragging     bool
+	pressY         float64
+	lastY          float64
+	renderIndex    int64
+	isDestroyed    bool
+	lerpPosition   *common.LerpPosition
+	selectionColor color.Color
+	cursorColor    color.Color
+	keyRepeat      *common.KeyRepeat
+	scrollY        float64
+	// view maps positions in the list to source indexes after filtering and sorting, nil shows every item in order
+	view   []int
+	filter func(index int) bool
+	less   func(a int, b int) bool
+	// offsets are the top of each position and the total height, nil when rows share rowHeight
+	offsets []float64
+	// cursor is the position keyboard navigation moves from, anchor is where shift selection started
+	cursor     int
+	anchor     int
+	rows       map[int]element.Interfacer
+	pool       []element.Interfacer
+	canvas     *ebiten.Image
+	onSelect   func(e *Element)
+	onActivate func(e *Element, index int)
+}
+
+// New creates a new list instance showing items of source. Rows are rowHeight high unless source is a RowHeighter
+func New(name string, scene string, x float64, y float64, width int, height int, source DataSource, rowHeight int) (*Element, error) {
+	if !isRowHeightValid(source, rowHeight) {
+		return nil, common.ErrRowHeightInvalid
+	}
+
+	e := &Element{
+		name:           name,
+		x:              x,
+		y:              y,
+		scale:          1,
+		width:          width,
+		height:         height,
+		source:         source,
+		rowHeight:      rowHeight,
+		selectionMode:  SelectSingle,
+		selected:       make(map[int]bool),
+		isEnabled:      true,
+		isVisible:      true,
+		lerpPosition:   new(common.LerpPosition),
+		selectionColor: color.RGBA{51, 102, 204, 160},
+		cursorColor:    color.RGBA{255, 255, 255, 96},
+		keyRepeat:      common.NewKeyRepeat(),
+		rows:           make(map[int]element.Interfacer),
+	}
+	e.Refresh()
+	return e, nil
+}
+
+// Name returns a element's name
+func (e *Element) Name() string {
+	return e.name
+}
+
+// IsVisible returns true if element is visible
+func (e *Element) IsVisible() bool {
+	return e.isVisible
+}
+
+// IsEnabled returns true if a element is enabled
+func (e *Element) IsEnabled() bool {
+	return e.isEnabled
+}
+
+// SetEnabled changes if a element is enabled
+func (e *Element) SetEnabled(isEnabled bool) {
+	e.isEnabled = isEnabled
+}
+
+// SetVisible changes the visibility of a element
+func (e *Element) SetVisible(isVisible bool) {
+	e.isVisible = isVisible
+}
+
+// RenderIndex returns the render index of element
+func (e *Element) RenderIndex() int64 {
+	return e.renderIndex
+}
+
+// SetRenderIndex sets the render index of element
+func (e *Element) SetRenderIndex(renderIndex int64) {
+	e.renderIndex = renderIndex
+}
+
+// Update is called during a game update
+func (e *Element) Update(dt float64) {
+
+	if e.lerpPosition.IsEnabled() {
+		e.x, e.y = e.lerpPosition.Lerp(dt)
+		if !e.lerpPosition.IsEnabled() {
+			if e.lerpPosition.EndFunc() != nil {
+				e.lerpPosition.EndFunc()()
+			}
+			if e.lerpPosition.IsDestroyed() {
+				e.isDestroyed = true
+				return
+			}
+		}
+	}
+
+	if !e.isEnabled || !e.isVisible {
+		return
+	}
+
+	e.updatePointer()
+	if e.isFocused {
+		e.updateKeys(dt)
+	}
+	e.clampScroll()
+	e.layoutRows()
+
+	// rows see the pointer relative to the top left of the list, and miss it while dragging
+	w, h := float64(e.width)*e.scale, float64(e.height)*e.scale
+	if e.isDragging {
+		w, h = 0, 0
+	}
+	common.PushInputView(e.x, e.y, w, h, e.x, e.y)
+	for _, row := range e.rows {
+		row.Update(dt)
+	}
+	common.PopInputView()
+}
+
+// updatePointer scrolls with the wheel and drags, and selects the pressed item on release
+func (e *Element) updatePointer() {
+	cx, cy := common.CursorPosition()
+	_, wheel := ebiten.Wheel()
+	if wheel != 0 && e.contains(cx, cy) {
+		e.scrollY -= wheel * wheelStep
+	}
+
+	x, y, ok := common.PointerJustPressed()
+	if ok {
+		e.isPressed = e.contains(x, y)
+		e.isFocused = e.isPressed
+		e.pressY, e.lastY = y, y
+		return
+	}
+	if !e.isPressed {
+		return
+	}
+	_, y, ok = common.PointerPressed()
+	if !ok {
+		if !e.isDragging && !common.IsPointerCancelled() {
+			pos := e.positionAt(e.pressY - e.y + e.scrollY)
+			if pos >= 0 {
+				e.click(pos)
+			}
+		}
+		e.isPressed = false
+		e.isDragging = false
+		return
+	}
+	if !e.isDragging && math.Abs(y-e.pressY) >= 6 {
+		e.isDragging = true
+	}
+	if e.isDragging {
+		e.scrollY -= y - e.lastY
+		e.lastY = y
+	}
+}
+
+// click selects the item at pos as a mouse click would, extending with shift or toggling with control in multi mode
+func (e *Element) click(pos int) {
+	isShift := common.IsKeyPressed(ebiten.KeyShift)
+	isControl := common.IsKeyPressed(ebiten.KeyControl)
+	e.cursor = pos
+	switch e.selectionMode {
+	case SelectNone:
+		return
+	case SelectMulti:
+		if isShift {
+			e.selectRange(e.anchor, pos, isControl)
+			return
+		}
+		e.anchor = pos
+		if isControl {
+			e.toggle(pos)
+			return
+		}
+	}
+	e.anchor = pos
+	e.selectOnly(pos)
+}
+
+// updateKeys moves the cursor with arrows, page keys, home and end, selecting as it moves
+func (e *Element) updateKeys(dt float64) {
+	count := e.Len()
+	if count == 0 {
+		return
+	}
+	pos := e.cursor
+	page := e.pageSize()
+	for i := e.keyRepeat.Count(ebiten.KeyUp, dt); i > 0; i-- {
+		pos--
+	}
+	for i := e.keyRepeat.Count(ebiten.KeyDown, dt); i > 0; i-- {
+		pos++
+	}
+	for i := e.keyRepeat.Count(ebiten.KeyPageUp, dt); i > 0; i-- {
+		pos -= page
+	}
+	for i := e.keyRepeat.Count(ebiten.KeyPageDown, dt); i > 0; i-- {
+		pos += page
+	}
+	if common.IsKeyJustPressed(ebiten.KeyHome) {
+		pos = 0
+	}
+	if common.IsKeyJustPressed(ebiten.KeyEnd) {
+		pos = count - 1
+	}
+	if pos < 0 {
+		pos = 0
+	}
+	if pos >= count {
+		pos = count - 1
+	}
+
+	if pos != e.cursor {
+		e.cursor = pos
+		switch {
+		case e.selectionMode == SelectMulti && common.IsKeyPressed(ebiten.KeyShift):
+			e.selectRange(e.anchor, pos, false)
+		case e.selectionMode == SelectMulti && common.IsKeyPressed(ebiten.KeyControl):
+			// control moves the cursor without selecting
+		case e.selectionMode != SelectNone:
+			e.anchor = pos
+			e.selectOnly(pos)
+		}
+		e.ScrollToPosition(pos)
+	}
+
+	if e.selectionMode == SelectMulti && common.IsKeyJustPressed(ebiten.KeySpace) {
+		e.anchor = e.cursor
+		e.toggle(e.cursor)
+	}
+	if common.IsShortcutPressed(ebiten.KeyA) && e.selectionMode == SelectMulti {
+		e.selectRange(0, count-1, false)
+	}
+	if (common.IsKeyJustPressed(ebiten.KeyEnter) || common.IsKeyJustPressed(ebiten.KeyKPEnter)) && e.onActivate != nil {
+		e.onActivate(e, e.Index(e.cursor))
+	}
+}
+
+// pageSize returns how many rows fit in the list, at least one
+func (e *Element) pageSize() int {
+	n := int(float64(e.height) * e.scale / float64(maxInt(1, e.rowHeight)))
+	if e.offsets != nil {
+		top := e.positionAt(e.scrollY)
+		n = e.positionAt(e.scrollY+float64(e.height)*e.scale-1) - top
+	}
+	return maxInt(1, n)
+}
+
+// contains returns true if x, y is inside the list
+func (e *Element) contains(x float64, y float64) bool {
+	return e.x <= x && x < e.x+float64(e.width)*e.scale && e.y <= y && y < e.y+float64(e.height)*e.scale
+}
+
+// Refresh filters and sorts items again and rebinds visible rows, e.g. after the data source changes
+func (e *Element) Refresh() {
+	count := e.source.Count()
+	e.view = nil
+	if e.filter != nil || e.less != nil {
+		e.view = make([]int, 0, count)
+		for i := 0; i < count; i++ {
+			if e.filter != nil && !e.filter(i) {
+				continue
+			}
+			e.view = append(e.view, i)
+		}
+		if e.less != nil {
+			sort.SliceStable(e.view, func(a, b int) bool {
+				return e.less(e.view[a], e.view[b])
+			})
+		}
+	}
+
+	// selected items that were filtered out or removed are deselected
+	for index := range e.selected {
+		if index >= count || (e.filter != nil && !e.filter(index)) {
+			delete(e.selected, index)
+		}
+	}
+
+	e.offsets = nil
+	if rh, ok := e.source.(RowHeighter); ok {
+		n := e.Len()
+		e.offsets = make([]float64, n+1)
+		for pos := 0; pos < n; pos++ {
+			e.offsets[pos+1] = e.offsets[pos] + float64(rh.RowHeight(e.Index(pos)))
+		}
+	}
+
+	if e.cursor >= e.Len() {
+		e.cursor = maxInt(0, e.Len()-1)
+	}
+	e.releaseRows()
+	e.clampScroll()
+}
+
+// Len returns how many items are shown after filtering
+func (e *Element) Len() int {
+	if e.view != nil {
+		return len(e.view)
+	}
+	return e.source.Count()
+}
+
+// Index returns the source index of the item shown at pos
+func (e *Element) Index(pos int) int {
+	if e.view != nil {
+		return e.view[pos]
+	}
+	return pos
+}
+
+// positionOf returns where the item of a source index is shown, or -1 if it is filtered out
+func (e *Element) positionOf(index int) int {
+	if e.view == nil {
+		if index < e.source.Count() {
+			return index
+		}
+		return -1
+	}
+	for pos, i := range e.view {
+		if i == index {
+			return pos
+		}
+	}
+	return -1
+}
+
+// top returns the content y of the row at pos
+func (e *Element) top(pos int) float64 {
+	if e.offsets != nil {
+		return e.offsets[pos]
+	}
+	return float64(pos * e.rowHeight)
+}
+
+// contentHeight returns the height of all rows
+func (e *Element) contentHeight() float64 {
+	return e.top(e.Len())
+}
+
+// positionAt returns the position of the row at content y, or -1 if there is none
+func (e *Element) positionAt(y float64) int {
+	if y < 0 {
+		return -1
+	}
+	n := e.Len()
+	pos := 0
+	if e.offsets != nil {
+		pos = sort.Search(n, func(i int) bool {
+			return e.offsets[i+1] > y
+		})
+	} else if e.rowHeight > 0 {
+		pos = int(y) / e.rowHeight
+	}
+	if pos >= n {
+		return -1
+	}
+	return pos
+}
+
+// clampScroll keeps scrolling within the rows
+func (e *Element) clampScroll() {
+	max := e.contentHeight() - float64(e.height)*e.scale
+	if e.scrollY > max {
+		e.scrollY = max
+	}
+	if e.scrollY < 0 {
+		e.scrollY = 0
+	}
+}
+
+// layoutRows gives each visible position a row, reusing rows of positions scrolled out of view, and places them
+func (e *Element) layoutRows() {
+	first := e.positionAt(e.scrollY)
+	last := first
+	if first >= 0 {
+		bottom := e.scrollY + float64(e.height)*e.scale
+		for last+1 < e.Len() && e.top(last+1) < bottom {
+			last++
+		}
+	}
+
+	for pos, row := range e.rows {
+		if first < 0 || pos < first || pos > last {
+			delete(e.rows, pos)
+			e.pool = append(e.pool, row)
+		}
+	}
+	if first < 0 {
+		return
+	}
+	for pos := first; pos <= last; pos++ {
+		row, ok := e.rows[pos]
+		if !ok {
+			if len(e.pool) > 0 {
+				row = e.pool[len(e.pool)-1]
+				e.pool = e.pool[:len(e.pool)-1]
+			} else {
+				row = e.source.NewRow()
+			}
+			e.rows[pos] = row
+			index := e.Index(pos)
+			e.source.BindRow(row, index, e.selected[index])
+		}
+		if p, ok := row.(element.Positioner); ok {
+			p.SetPosition(0, e.top(pos)-e.scrollY)
+		}
+	}
+}
+
+// releaseRows returns every row to the pool, so visible rows are bound again
+func (e *Element) releaseRows() {
+	for pos, row := range e.rows {
+		delete(e.rows, pos)
+		e.pool = append(e.pool, row)
+	}
+}
+
+// rebind shows the current selection state in visible rows
+func (e *Element) rebind() {
+	for pos, row := range e.rows {
+		index := e.Index(pos)
+		e.source.BindRow(row, index, e.selected[index])
+	}
+}
+
+// selectOnly selects the item at pos and deselects the rest
+func (e *Element) selectOnly(pos int) {
+	index := e.Index(pos)
+	if len(e.selected) == 1 && e.selected[index] {
+		return
+	}
+	e.selected = map[int]bool{index: true}
+	e.selectionChanged()
+}
+
+// toggle flips the selection of the item at pos
+func (e *Element) toggle(pos int) {
+	index := e.Index(pos)
+	if e.selected[index] {
+		delete(e.selected, index)
+	} else {
+		e.selected[index] = true
+	}
+	e.selectionChanged()
+}
+
+// selectRange selects positions from a to b, adding to the selection if isAdding
+func (e *Element) selectRange(a int, b int, isAdding bool) {
+	if a > b {
+		a, b = b, a
+	}
+	if !isAdding {
+		e.selected = make(map[int]bool)
+	}
+	for pos := maxInt(a, 0); pos <= b && pos < e.Len(); pos++ {
+		e.selected[e.Index(pos)] = true
+	}
+	e.selectionChanged()
+}
+
+// selectionChanged rebinds rows and calls the select function
+func (e *Element) selectionChanged() {
+	e.rebind()
+	if e.onSelect != nil {
+		e.onSelect(e)
+	}
+}
+
+// Draw is called during a game update
+func (e *Element) Draw(dst *ebiten.Image) {
+	if !e.isVisible {
+		return
+	}
+	w, h := int(float64(e.width)*e.scale), int(float64(e.height)*e.scale)
+	canvas := e.canvasImage(w, h)
+	if canvas == nil {
+		return
+	}
+	canvas.Clear()
+
+	for pos, row := range e.rows {
+		top := e.top(pos) - e.scrollY
+		height := e.top(pos+1) - e.top(pos)
+		if e.selected[e.Index(pos)] {
+			ebitenutil.DrawRect(canvas, 0, top, float64(w), height, e.selectionColor)
+		}
+		if e.isFocused && pos == e.cursor && e.selectionMode == SelectMulti {
+			ebitenutil.DrawRect(canvas, 0, top, float64(w), 1, e.cursorColor)
+			ebitenutil.DrawRect(canvas, 0, top+height-1, float64(w), 1, e.cursorColor)
+		}
+		if row.IsVisible() {
+			row.Draw(canvas)
+		}
+	}
+
+	op := &ebiten.DrawImageOptions{}
+	if !e.isEnabled {
+		op.ColorM.ChangeHSV(0, 0, 1)
+		op.ColorM.Scale(0.5, 0.5, 0.5, 1)
+	}
+	op.GeoM.Translate(e.x, e.y)
+	dst.DrawImage(canvas, op)
+}
+
+// canvasImage returns the offscreen image rows are clipped to, creating it when the size changes
+func (e *Element) canvasImage(w int, h int) *ebiten.Image {
+	if w <= 0 || h <= 0 {
+		return nil
+	}
+	if e.canvas != nil {
+		cw, ch := e.canvas.Size()
+		if cw == w && ch == h {
+			return e.canvas
+		}
+		e.canvas.Dispose()
+		e.canvas = nil
+	}
+	canvas, err := ebiten.NewImage(w, h, ebiten.FilterDefault)
+	if err != nil {
+		return nil
+	}
+	e.canvas = canvas
+	return canvas
+}
+
+func maxInt(a int, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
+
+// SetText is not used by lists
+func (e *Element) SetText(text string) {
+}
+
+// Source returns the data source of the list
+func (e *Element) Source() DataSource {
+	return e.source
+}
+
+// isRowHeightValid returns true if rows of source get a height, from rowHeight or the source itself
+func isRowHeightValid(source DataSource, rowHeight int) bool {
+	if _, ok := source.(RowHeighter); ok {
+		return true
+	}
+	return rowHeight > 0
+}
+
+// SetSource shows items of another data source, clearing the selection and rows.
+// A source that is not a RowHeighter needs the list to have a row height
+func (e *Element) SetSource(source DataSource) error {
+	if !isRowHeightValid(source, e.rowHeight) {
+		return common.ErrRowHeightInvalid
+	}
+	e.source = source
+	e.rows = make(map[int]element.Interfacer)
+	e.pool = nil
+	e.selected = make(map[int]bool)
+	e.cursor = 0
+	e.anchor = 0
+	e.scrollY = 0
+	e.Refresh()
+	return nil
+}
+
+// SetFilter shows only items whose source index passes filter, nil shows every item
+func (e *Element) SetFilter(filter func(index int) bool) {
+	e.filter = filter
+	e.Refresh()
+}
+
+// SetSort orders items by less of their source indexes, nil keeps source order
+func (e *Element) SetSort(less func(a int, b int) bool) {
+	e.less = less
+	e.Refresh()
+}
+
+// SelectionMode returns how many items can be selected
+func (e *Element) SelectionMode() SelectionMode {
+	return e.selectionMode
+}
+
+// SetSelectionMode sets how many items can be selected, clearing the selection
+func (e *Element) SetSelectionMode(mode SelectionMode) {
+	e.selectionMode = mode
+	if len(e.selected) > 0 {
+		e.selected = make(map[int]bool)
+		e.selectionChanged()
+	}
+}
+
+// Selected returns the source indexes of selected items in the order they are shown
+func (e *Element) Selected() []int {
+	var indexes []int
+	for pos := 0; pos < e.Len() && len(indexes) < len(e.selected); pos++ {
+		if e.selected[e.Index(pos)] {
+			indexes = append(indexes, e.Index(pos))
+		}
+	}
+	return indexes
+}
+
+// IsSelected returns true if the item at source index is selected
+func (e *Element) IsSelected(index int) bool {
+	return e.selected[index]
+}
+
+// SetSelected selects or deselects the item at source index
+func (e *Element) SetSelected(index int, isSelected bool) {
+	if e.selectionMode == SelectNone || e.selected[index] == isSelected {
+		return
+	}
+	if isSelected && e.selectionMode == SelectSingle {
+		e.selected = make(map[int]bool)
+	}
+	if isSelected {
+		e.selected[index] = true
+	} else {
+		delete(e.selected, index)
+	}
+	e.selectionChanged()
+}
+
+// ClearSelection deselects every item
+func (e *Element) ClearSelection() {
+	if len(e.selected) == 0 {
+		return
+	}
+	e.selected = make(map[int]bool)
+	e.selectionChanged()
+}
+
+// ScrollToPosition scrolls the least needed to show the item shown at pos
+func (e *Element) ScrollToPosition(pos int) {
+	if pos < 0 || pos >= e.Len() {
+		return
+	}
+	top, bottom := e.top(pos), e.top(pos+1)
+	h := float64(e.height) * e.scale
+	if bottom > e.scrollY+h {
+		e.scrollY = bottom - h
+	}
+	if top < e.scrollY {
+		e.scrollY = top
+	}
+	e.clampScroll()
+}
+
+// ScrollToIndex scrolls the least needed to show the item at source index, if it is not filtered out
+func (e *Element) ScrollToIndex(index int) {
+	e.ScrollToPosition(e.positionOf(index))
+}
+
+// ScrollY returns how many pixels rows are scrolled up
+func (e *Element) ScrollY() float64 {
+	return e.scrollY
+}
+
+// SetScrollY scrolls rows up by scrollY pixels, kept within the rows
+func (e *Element) SetScrollY(scrollY float64) {
+	e.scrollY = scrollY
+	e.clampScroll()
+}
+
+// SetSelectionColor sets the color drawn behind selected rows
+func (e *Element) SetSelectionColor(selectionColor color.Color) {
+	e.selectionColor = selectionColor
+}
+
+// KeyRepeat returns the repeat timing of held keys
+func (e *Element) KeyRepeat() *common.KeyRepeat {
+	return e.keyRepeat
+}
+
+// IsFocused returns true if keys move through the list
+func (e *Element) IsFocused() bool {
+	return e.isFocused
+}
+
+// SetIsFocused sets if keys move through the list. Clicking the list focuses it
+func (e *Element) SetIsFocused(isFocused bool) {
+	e.isFocused = isFocused
+	e.keyRepeat.Reset()
+}
+
+// SetOnSelect sets a function called when the selection changes
+func (e *Element) SetOnSelect(f func(e *Element)) {
+	e.onSelect = f
+}
+
+// SetOnActivate sets a function called with the source index of the item under the cursor when enter is pressed
+func (e *Element) SetOnActivate(f func(e *Element, index int)) {
+	e.onActivate = f
+}
+
+// IsDestroyed returns true when the element is flagged for deletion
+func (e *Element) IsDestroyed() bool {
+	return e.isDestroyed
+}
+
+// LerpPosition changes an element's position over duration
+func (e *Element) LerpPosition(endPositionX, endPositionY float64, duration time.Duration, isDestroyed bool, endFunc func()) {
+	e.lerpPosition.Init(e.x, e.y, endPositionX, endPositionY, duration, true, endFunc, isDestroyed)
+}
+
+// Position returns an element's position
+func (e *Element) Position() (float64, float64) {
+	return e.x, e.y
+}
+
+// SetPosition sets an element's position
+func (e *Element) SetPosition(x float64, y float64) {
+	e.x = x
+	e.y = y
+}
+
+// Width returns an element's width
+func (e *Element) Width() int {
+	return e.width
+}
+
+// SetWidth sets an element's width
+func (e *Element) SetWidth(width int) {
+	e.width = width
+}
+
+// Height returns an element's height
+func (e *Element) Height() int {
+	return e.height
+}
+
+// SetHeight sets an element's height
+func (e *Element) SetHeight(height int) {
+	e.height = height
+}
+
+// SetIsDestroyed sets an element to be destroyed on next update
+func (e *Element) SetIsDestroyed(isDestroyed bool) {
+	e.isDestroyed = true
+	if e.canvas != nil {
+		e.canvas.Dispose()
+		e.canvas = nil
+	}
+}
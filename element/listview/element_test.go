@@ -0,0 +1,302 @@
+package listview
+
+import (
+	"reflect"
+	"sort"
+	"testing"
+
+	"github.com/xackery/egui/common"
+	"github.com/xackery/egui/element"
+	"github.com/xackery/egui/element/progress"
+)
+
+// testSource lists words, remembering which index each row was last bound to
+type testSource struct {
+	words   []string
+	created int
+	bound   map[element.Interfacer]int
+}
+
+func newTestSource(words ...string) *testSource {
+	return &testSource{words: words, bound: make(map[element.Interfacer]int)}
+}
+
+func (s *testSource) Count() int {
+	return len(s.words)
+}
+
+func (s *testSource) NewRow() element.Interfacer {
+	s.created++
+	row, _ := progress.New("row", "scene", "", 0, 0, 50, 10, nil, nil, nil, "", "")
+	return row
+}
+
+func (s *testSource) BindRow(row element.Interfacer, index int, isSelected bool) {
+	s.bound[row] = index
+}
+
+// testHeightSource gives each word its own row height
+type testHeightSource struct {
+	*testSource
+	heights []int
+}
+
+func (s *testHeightSource) RowHeight(index int) int {
+	return s.heights[index]
+}
+
+// newTestList returns a list 25 pixels tall of rows 10 pixels tall
+func newTestList(t *testing.T, source DataSource) *Element {
+	e, err := New("list", "scene", 0, 0, 50, 25, source, 10)
+	if err != nil {
+		t.Fatalf("new: %v", err)
+	}
+	return e
+}
+
+func TestNewRowHeight(t *testing.T) {
+	if _, err := New("list", "scene", 0, 0, 50, 25, newTestSource(), 0); err != common.ErrRowHeightInvalid {
+		t.Errorf("no row height: got %v, want %v", err, common.ErrRowHeightInvalid)
+	}
+	source := &testHeightSource{newTestSource(), nil}
+	if _, err := New("list", "scene", 0, 0, 50, 25, source, 0); err != nil {
+		t.Errorf("row heighter: got %v, want nil", err)
+	}
+}
+
+func TestFilterSort(t *testing.T) {
+	words := []string{"cherry", "apple", "banana", "date"}
+	isLong := func(index int) bool { return len(words[index]) > 4 }
+	byWord := func(a, b int) bool { return words[a] < words[b] }
+	tests := []struct {
+		name   string
+		filter func(index int) bool
+		less   func(a int, b int) bool
+		want   []int
+	}{
+		{"source order", nil, nil, []int{0, 1, 2, 3}},
+		{"filtered", isLong, nil, []int{0, 1, 2}},
+		{"sorted", nil, byWord, []int{1, 2, 0, 3}},
+		{"filtered and sorted", isLong, byWord, []int{1, 2, 0}},
+	}
+	for _, tt := range tests {
+		e := newTestList(t, newTestSource(words...))
+		e.SetFilter(tt.filter)
+		e.SetSort(tt.less)
+		var got []int
+		for pos := 0; pos < e.Len(); pos++ {
+			got = append(got, e.Index(pos))
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s: indexes %v, want %v", tt.name, got, tt.want)
+		}
+		for pos, index := range tt.want {
+			if got := e.positionOf(index); got != pos {
+				t.Errorf("%s: position of %d is %d, want %d", tt.name, index, got, pos)
+			}
+		}
+	}
+}
+
+func TestFilterDeselects(t *testing.T) {
+	words := []string{"cherry", "apple", "banana", "date"}
+	e := newTestList(t, newTestSource(words...))
+	e.SetSelectionMode(SelectMulti)
+	e.SetSelected(3, true)
+	e.SetSelected(1, true)
+	e.SetFilter(func(index int) bool { return len(words[index]) > 4 })
+	if got := e.Selected(); !reflect.DeepEqual(got, []int{1}) {
+		t.Errorf("selected %v, want [1]", got)
+	}
+	if e.positionOf(3) != -1 {
+		t.Errorf("position of filtered item %d, want -1", e.positionOf(3))
+	}
+	// removing the filter does not select the item again
+	e.SetFilter(nil)
+	if e.IsSelected(3) {
+		t.Errorf("filtered item selected again")
+	}
+}
+
+func TestSelectedOrder(t *testing.T) {
+	e := newTestList(t, newTestSource("a", "b", "c", "d"))
+	e.SetSelectionMode(SelectMulti)
+	e.SetSelected(0, true)
+	e.SetSelected(3, true)
+	e.SetSort(func(a, b int) bool { return a > b })
+	if got := e.Selected(); !reflect.DeepEqual(got, []int{3, 0}) {
+		t.Errorf("selected %v, want shown order [3 0]", got)
+	}
+}
+
+func TestSetSelected(t *testing.T) {
+	tests := []struct {
+		name    string
+		mode    SelectionMode
+		indexes []int
+		want    []int
+		changes int
+	}{
+		{"none", SelectNone, []int{1, 2}, nil, 0},
+		{"single", SelectSingle, []int{1, 2}, []int{2}, 2},
+		{"single again", SelectSingle, []int{1, 1}, []int{1}, 1},
+		{"multi", SelectMulti, []int{2, 1}, []int{1, 2}, 2},
+	}
+	for _, tt := range tests {
+		e := newTestList(t, newTestSource("a", "b", "c", "d"))
+		e.SetSelectionMode(tt.mode)
+		changes := 0
+		e.SetOnSelect(func(e *Element) {
+			changes++
+		})
+		for _, index := range tt.indexes {
+			e.SetSelected(index, true)
+		}
+		if got := e.Selected(); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s: selected %v, want %v", tt.name, got, tt.want)
+		}
+		if changes != tt.changes {
+			t.Errorf("%s: %d select calls, want %d", tt.name, changes, tt.changes)
+		}
+	}
+}
+
+func TestSetSelectionModeClears(t *testing.T) {
+	e := newTestList(t, newTestSource("a", "b"))
+	e.SetSelected(1, true)
+	e.SetSelectionMode(SelectMulti)
+	if len(e.Selected()) != 0 {
+		t.Errorf("selected %v, want none", e.Selected())
+	}
+}
+
+func TestSelectRange(t *testing.T) {
+	tests := []struct {
+		name     string
+		a        int
+		b        int
+		isAdding bool
+		want     []int
+	}{
+		{"forward", 1, 2, false, []int{1, 2}},
+		{"backward", 3, 1, false, []int{1, 2, 3}},
+		{"adding", 2, 3, true, []int{0, 2, 3}},
+		{"past the end", 2, 10, false, []int{2, 3}},
+	}
+	for _, tt := range tests {
+		e := newTestList(t, newTestSource("a", "b", "c", "d"))
+		e.SetSelectionMode(SelectMulti)
+		e.SetSelected(0, true)
+		e.selectRange(tt.a, tt.b, tt.isAdding)
+		if got := e.Selected(); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s: selected %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestToggle(t *testing.T) {
+	e := newTestList(t, newTestSource("a", "b", "c"))
+	e.SetSelectionMode(SelectMulti)
+	e.toggle(0)
+	e.toggle(2)
+	e.toggle(0)
+	if got := e.Selected(); !reflect.DeepEqual(got, []int{2}) {
+		t.Errorf("selected %v, want [2]", got)
+	}
+}
+
+func TestPositionAt(t *testing.T) {
+	source := &testHeightSource{newTestSource("a", "b", "c", "d"), []int{10, 20, 30, 40}}
+	tests := []struct {
+		name   string
+		source DataSource
+		y      float64
+		want   int
+	}{
+		{"above", source, -1, -1},
+		{"first", source, 0, 0},
+		{"end of first", source, 9, 0},
+		{"second", source, 10, 1},
+		{"end of second", source, 29, 1},
+		{"third", source, 30, 2},
+		{"last", source, 99, 3},
+		{"below", source, 100, -1},
+		{"fixed height", newTestSource("a", "b", "c", "d"), 35, 3},
+		{"below fixed height", newTestSource("a", "b", "c", "d"), 40, -1},
+	}
+	for _, tt := range tests {
+		e := newTestList(t, tt.source)
+		if got := e.positionAt(tt.y); got != tt.want {
+			t.Errorf("%s: position %d, want %d", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestScrollToPosition(t *testing.T) {
+	// 10 rows 10 pixels tall, 25 shown, so scroll stops at 75
+	words := []string{"a", "b", "c", "d", "e", "f", "g", "h", "i", "j"}
+	tests := []struct {
+		name    string
+		scrollY float64
+		pos     int
+		want    float64
+	}{
+		{"shown", 0, 1, 0},
+		{"below", 0, 5, 35},
+		{"above", 50, 1, 10},
+		{"last", 0, 9, 75},
+		{"out of range", 20, 10, 20},
+	}
+	for _, tt := range tests {
+		e := newTestList(t, newTestSource(words...))
+		e.SetScrollY(tt.scrollY)
+		e.ScrollToPosition(tt.pos)
+		if e.ScrollY() != tt.want {
+			t.Errorf("%s: scroll %v, want %v", tt.name, e.ScrollY(), tt.want)
+		}
+	}
+
+	e := newTestList(t, newTestSource(words...))
+	for _, tt := range []struct {
+		scrollY float64
+		want    float64
+	}{{1000, 75}, {-5, 0}} {
+		e.SetScrollY(tt.scrollY)
+		if e.ScrollY() != tt.want {
+			t.Errorf("set scroll %v: got %v, want %v", tt.scrollY, e.ScrollY(), tt.want)
+		}
+	}
+}
+
+func TestLayoutRows(t *testing.T) {
+	source := newTestSource("a", "b", "c", "d", "e", "f", "g", "h", "i", "j")
+	e := newTestList(t, source)
+	tests := []struct {
+		scrollY   float64
+		positions []int
+	}{
+		{0, []int{0, 1, 2}},
+		{5, []int{0, 1, 2}},
+		{10, []int{1, 2, 3}},
+		{75, []int{7, 8, 9}},
+	}
+	for _, tt := range tests {
+		e.SetScrollY(tt.scrollY)
+		e.layoutRows()
+		var positions []int
+		for pos, row := range e.rows {
+			positions = append(positions, pos)
+			if source.bound[row] != e.Index(pos) {
+				t.Errorf("scroll %v: row at %d bound to %d", tt.scrollY, pos, source.bound[row])
+			}
+		}
+		sort.Ints(positions)
+		if !reflect.DeepEqual(positions, tt.positions) {
+			t.Errorf("scroll %v: positions %v, want %v", tt.scrollY, positions, tt.positions)
+		}
+	}
+	// rows scrolled out of view are reused instead of created again
+	if source.created != 3 {
+		t.Errorf("created %d rows, want 3", source.created)
+	}
+}
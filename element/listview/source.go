@@ -0,0 +1,42 @@
+package listview
+
+import "github.com/xackery/egui/element"
+
+// DataSource supplies the items of a list. Only rows for visible items exist, and rows
+// scrolled out of view are bound to other items instead of created again
+type DataSource interface {
+	// Count returns how many items there are
+	Count() int
+	// NewRow creates a row element. Rows should implement element.Positioner to be placed
+	NewRow() element.Interfacer
+	// BindRow shows the item at index in a row, created by NewRow and possibly used for another item before
+	BindRow(row element.Interfacer, index int, isSelected bool)
+}
+
+// RowHeighter is implemented by data sources with rows of different heights
+type RowHeighter interface {
+	RowHeight(index int) int
+}
+
+// SelectionMode is how many items of a list can be selected
+type SelectionMode int
+
+const (
+	// SelectNone disables selection
+	SelectNone = SelectionMode(0)
+	// SelectSingle selects one item at a time
+	SelectSingle = SelectionMode(1)
+	// SelectMulti selects many items with control and shift
+	SelectMulti = SelectionMode(2)
+)
+
+func (m SelectionMode) String() string {
+	switch m {
+	case SelectSingle:
+		return "single"
+	case SelectMulti:
+		return "multi"
+	default:
+		return "none"
+	}
+}
@@ -150,9 +150,7 @@ func (e *Map) Update(dt float64) {
 	isRecentlyPressed := false
 	//mobile and desktop use different touch devices
 	for _, t := range inpututil.JustPressedTouchIDs() {
-		x, y := ebiten.TouchPosition(t)
-		fx := float64(x)
-		fy := float64(y)
+		fx, fy := common.TouchPosition(t)
 		if e.shape.Min.X <= fx && fx < e.shape.Max.X && e.shape.Min.Y <= fy && fy < e.shape.Max.Y {
 			e.isPressed = true
 			isRecentlyPressed = true
@@ -162,9 +160,7 @@ func (e *Map) Update(dt float64) {
 	}
 
 	if ebiten.IsMouseButtonPressed(ebiten.MouseButtonLeft) {
-		x, y := ebiten.CursorPosition()
-		fx := float64(x)
-		fy := float64(y)
+		fx, fy := common.CursorPosition()
 		if e.shape.Min.X <= fx && fx < e.shape.Max.X && e.shape.Min.Y <= fy && fy < e.shape.Max.Y {
 			e.isPressed = true
 			isRecentlyPressed = true
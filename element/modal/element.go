@@ -0,0 +1,422 @@
+package modal
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/hajimehoshi/ebiten"
+	"github.com/hajimehoshi/ebiten/ebitenutil"
+	"github.com/hajimehoshi/ebiten/inpututil"
+	"github.com/xackery/egui/common"
+	"github.com/xackery/egui/element/button"
+	"github.com/xackery/egui/element/textinput"
+)
+
+// gamepadDeadZone is how far a stick is pushed before it moves focus
+const gamepadDeadZone = 0.5
+
+// Element represents a 9slice modal dialog over a dimmed backdrop.
+// A element has a title, body text, buttons and optionally a text input
+// A element captures input until a button is chosen
+type Element struct {
+	name         string
+	image        *common.Image
+	x            float64
+	y            float64
+	width        int
+	height       int
+	screenWidth  int
+	screenHeight int
+	title        string
+	body         string
+	labels       []string
+	buttons      []*button.Element
+	input        *textinput.Element
+	focus        int
+	style        Style
+	font         *common.Font
+	isEnabled    bool
+	isVisible    bool
+	isOpening    bool
+	isShown      bool
+	isClosed     bool
+	renderIndex  int64
+	isDestroyed  bool
+	keyRepeat    *common.KeyRepeat
+	titleImage   common.TextImage
+	bodyImage    common.TextImage
+	onResult     func(e *Element, r Result)
+	onClose      func(e *Element)
+}
+
+// New creates a new dialog instance centered on a screen of screenWidth by screenHeight. It captures input once shown,
+// or on its first update. Enter chooses the first button and escape the last. A clipboard adds a text input to the dialog
+func New(name string, scene string, screenWidth int, screenHeight int, title string, body string, labels []string, font *common.Font, img *common.Image, style Style, clipboard common.Clipboard) (*Element, error) {
+	if len(labels) == 0 {
+		labels = []string{"OK"}
+	}
+
+	e := &Element{
+		name:         name,
+		image:        img,
+		screenWidth:  screenWidth,
+		screenHeight: screenHeight,
+		title:        title,
+		body:         body,
+		labels:       labels,
+		style:        style,
+		font:         font,
+		isEnabled:    true,
+		isVisible:    true,
+		isOpening:    true,
+		keyRepeat:    common.NewKeyRepeat(),
+	}
+
+	for i, label := range labels {
+		index := i
+		b, err := button.New(fmt.Sprintf("%s.button%d", name, i), scene, label, 0, 0, 0, style.ButtonHeight, font, style.TextColor, img, style.ButtonPressedSliceName, style.ButtonSliceName)
+		if err != nil {
+			return nil, err
+		}
+		b.SetOnPressed(func(b *button.Element) {
+			e.choose(index, false)
+		})
+		e.buttons = append(e.buttons, b)
+	}
+
+	if clipboard != nil {
+		input, err := textinput.New(name+".input", scene, 0, 0, 0, style.ButtonHeight, font, style.TextColor, img, style.InputSliceName, style.InputFocusedSliceName, clipboard)
+		if err != nil {
+			return nil, err
+		}
+		input.SetIsFocused(true)
+		e.input = input
+	}
+
+	e.layout()
+	return e, nil
+}
+
+// layout sizes the panel to its text and centers it, placing the input and buttons
+func (e *Element) layout() {
+	padding := e.style.Padding
+	e.width = e.style.Width
+	if e.width > e.screenWidth-padding*2 {
+		e.width = e.screenWidth - padding*2
+	}
+	inner := e.width - padding*2
+
+	height := padding
+	if e.title != "" {
+		height += e.font.RenderingLineHeight + padding/2
+	}
+	if e.body != "" {
+		lines := e.font.LayoutText(e.body, e.bodyLayout())
+		height += len(lines)*e.font.RenderingLineHeight + padding
+	}
+	inputY := height
+	if e.input != nil {
+		height += e.style.ButtonHeight + padding
+	}
+	buttonY := height
+	height += e.style.ButtonHeight + padding
+	e.height = height
+
+	e.x = float64(e.screenWidth-e.width) / 2
+	e.y = float64(e.screenHeight-e.height) / 2
+
+	if e.input != nil {
+		e.input.SetPosition(e.x+float64(padding), e.y+float64(inputY))
+		e.input.SetWidth(inner)
+	}
+	n := len(e.buttons)
+	w := (inner - padding*(n-1)) / n
+	for i, b := range e.buttons {
+		b.SetPosition(e.x+float64(padding+i*(w+padding)), e.y+float64(buttonY))
+		b.SetWidth(w)
+	}
+}
+
+// bodyLayout returns the box body text wraps in
+func (e *Element) bodyLayout() *common.TextLayout {
+	return &common.TextLayout{
+		Width:     float64(e.width - e.style.Padding*2),
+		Align:     common.TextAlignCenter,
+		IsWrapped: true,
+	}
+}
+
+// Name returns a element's name
+func (e *Element) Name() string {
+	return e.name
+}
+
+// IsVisible returns true if element is visible
+func (e *Element) IsVisible() bool {
+	return e.isVisible
+}
+
+// IsEnabled returns true if a element is enabled
+func (e *Element) IsEnabled() bool {
+	return e.isEnabled
+}
+
+// SetEnabled changes if a element is enabled
+func (e *Element) SetEnabled(isEnabled bool) {
+	e.isEnabled = isEnabled
+}
+
+// SetVisible changes the visibility of a element
+func (e *Element) SetVisible(isVisible bool) {
+	e.isVisible = isVisible
+}
+
+// RenderIndex returns the render index of element
+func (e *Element) RenderIndex() int64 {
+	return e.renderIndex
+}
+
+// SetRenderIndex sets the render index of element
+func (e *Element) SetRenderIndex(renderIndex int64) {
+	e.renderIndex = renderIndex
+}
+
+// Update is called during a game update
+func (e *Element) Update(dt float64) {
+	if e.isClosed || !e.isEnabled || !e.isVisible {
+		return
+	}
+	if !e.isShown {
+		e.Show()
+	}
+
+	common.BeginCapturedInput(e)
+	defer common.EndCapturedInput()
+	if e.input != nil {
+		e.input.Update(dt)
+	}
+	for _, b := range e.buttons {
+		b.Update(dt)
+		if e.isClosed {
+			break
+		}
+	}
+	if e.isClosed {
+		return
+	}
+
+	// the key that opened the dialog is ignored, so enter does not choose at once
+	if e.isOpening {
+		e.isOpening = false
+		return
+	}
+	e.updateKeys(dt)
+}
+
+// updateKeys moves focus between buttons, choosing with enter and cancelling with escape
+func (e *Element) updateKeys(dt float64) {
+	isConfirmed := common.IsKeyJustPressed(ebiten.KeyEnter) || common.IsKeyJustPressed(ebiten.KeyKPEnter)
+	isCancelled := common.IsKeyJustPressed(ebiten.KeyEscape)
+
+	step := 0
+	if common.IsKeyJustPressed(ebiten.KeyTab) {
+		step = 1
+		if common.IsKeyPressed(ebiten.KeyShift) {
+			step = -1
+		}
+	}
+	// arrows move the caret while the prompt is focused
+	if e.input == nil || !e.input.IsFocused() {
+		step += e.keyRepeat.Count(ebiten.KeyRight, dt) - e.keyRepeat.Count(ebiten.KeyLeft, dt)
+	}
+	for _, id := range common.GamepadIDs() {
+		v := ebiten.GamepadAxis(id, 0)
+		step += e.keyRepeat.CountHeld([2]int{id, 1}, v > gamepadDeadZone, dt)
+		step -= e.keyRepeat.CountHeld([2]int{id, -1}, v < -gamepadDeadZone, dt)
+		isConfirmed = isConfirmed || inpututil.IsGamepadButtonJustPressed(id, ebiten.GamepadButton0)
+		isCancelled = isCancelled || inpututil.IsGamepadButtonJustPressed(id, ebiten.GamepadButton1)
+	}
+
+	n := len(e.buttons)
+	e.focus = ((e.focus+step)%n + n) % n
+
+	switch {
+	case isCancelled:
+		e.choose(n-1, true)
+	case isConfirmed:
+		e.choose(e.focus, false)
+	}
+}
+
+// choose closes the dialog with a button, calling the result function
+func (e *Element) choose(index int, isCancelled bool) {
+	if e.isClosed {
+		return
+	}
+	e.Close()
+	if e.onResult == nil {
+		return
+	}
+	r := Result{
+		Button:      index,
+		Label:       e.labels[index],
+		IsCancelled: isCancelled,
+	}
+	if e.input != nil {
+		r.Text = e.input.Text()
+	}
+	e.onResult(e, r)
+}
+
+// Show captures input for the dialog, blocking the rest of the scene. Call it once the dialog is added to a scene
+func (e *Element) Show() {
+	if e.isShown || e.isClosed {
+		return
+	}
+	e.isShown = true
+	common.CaptureInput(e)
+}
+
+// Close closes the dialog without calling the result function, releasing input and calling the close function.
+// Every way a dialog goes away, including removal from its scene, closes it here
+func (e *Element) Close() {
+	if e.isClosed {
+		return
+	}
+	e.isClosed = true
+	e.isDestroyed = true
+	common.ReleaseInput(e)
+	if e.onClose != nil {
+		e.onClose(e)
+	}
+}
+
+// IsClosed returns true once a button is chosen or the dialog is closed
+func (e *Element) IsClosed() bool {
+	return e.isClosed
+}
+
+// Draw is called during a game update
+func (e *Element) Draw(dst *ebiten.Image) {
+	if e.isClosed {
+		return
+	}
+
+	sw, sh := dst.Size()
+	ebitenutil.DrawRect(dst, 0, 0, float64(sw), float64(sh), e.style.BackdropColor)
+
+	op := &ebiten.DrawImageOptions{}
+	op.GeoM.Translate(e.x, e.y)
+	slice, err := e.image.Slice(e.style.SliceName)
+	if err == nil {
+		common.DrawNineSlicing(dst, e.image.EbitenImage, slice.Keys[0], e.width, e.height, &op.GeoM, &op.ColorM)
+	}
+
+	padding := float64(e.style.Padding)
+	y := e.y + padding
+	if e.title != "" {
+		layout := &common.TextLayout{
+			Width:      float64(e.width) - padding*2,
+			Align:      common.TextAlignCenter,
+			IsEllipsis: true,
+		}
+		e.titleImage.DrawText(dst, e.font, e.title, e.x+padding, y, layout, e.style.TextColor)
+		y += float64(e.font.RenderingLineHeight) + padding/2
+	}
+	if e.body != "" {
+		e.bodyImage.DrawText(dst, e.font, e.body, e.x+padding, y, e.bodyLayout(), e.style.TextColor)
+	}
+
+	if e.input != nil {
+		e.input.Draw(dst)
+	}
+	for i, b := range e.buttons {
+		b.Draw(dst)
+		if i != e.focus || len(e.buttons) < 2 {
+			continue
+		}
+		bx, by := b.Position()
+		w, h := float64(b.Width()), float64(e.style.ButtonHeight)
+		ebitenutil.DrawRect(dst, bx, by-2, w, 1, e.style.FocusColor)
+		ebitenutil.DrawRect(dst, bx, by+h+1, w, 1, e.style.FocusColor)
+	}
+}
+
+// SetText changes the body text
+func (e *Element) SetText(text string) {
+	e.body = text
+	e.layout()
+}
+
+// Title returns the title of the dialog
+func (e *Element) Title() string {
+	return e.title
+}
+
+// SetTitle changes the title of the dialog
+func (e *Element) SetTitle(title string) {
+	e.title = title
+	e.layout()
+}
+
+// Input returns the text input of a prompt, nil for other dialogs
+func (e *Element) Input() *textinput.Element {
+	return e.input
+}
+
+// Focus returns the index of the button enter chooses
+func (e *Element) Focus() int {
+	return e.focus
+}
+
+// SetFocus sets the button enter chooses, the first by default
+func (e *Element) SetFocus(index int) {
+	if index < 0 || index >= len(e.buttons) {
+		return
+	}
+	e.focus = index
+}
+
+// SetOnResult sets a function called with the chosen button once the dialog closes
+func (e *Element) SetOnResult(f func(e *Element, r Result)) {
+	e.onResult = f
+}
+
+// SetOnClose sets a function called once when the dialog closes, whether a button was chosen or not
+func (e *Element) SetOnClose(f func(e *Element)) {
+	e.onClose = f
+}
+
+// OnSceneExit closes the dialog when its scene stops being shown, releasing input
+func (e *Element) OnSceneExit() {
+	e.Close()
+}
+
+// OnRemove closes the dialog when it is removed from its scene, releasing input
+func (e *Element) OnRemove() {
+	e.Close()
+}
+
+// IsDestroyed returns true when the element is flagged for deletion
+func (e *Element) IsDestroyed() bool {
+	return e.isDestroyed
+}
+
+// LerpPosition is not used by dialogs, which stay centered
+func (e *Element) LerpPosition(endPositionX, endPositionY float64, duration time.Duration, isDestroyed bool, endFunc func()) {
+}
+
+// Width returns an element's width
+func (e *Element) Width() int {
+	return e.width
+}
+
+// Height returns an element's height
+func (e *Element) Height() int {
+	return e.height
+}
+
+// SetIsDestroyed closes the dialog without calling the result function
+func (e *Element) SetIsDestroyed(isDestroyed bool) {
+	e.Close()
+}
@@ -0,0 +1,160 @@
+package modal
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/xackery/egui/common"
+	"golang.org/x/image/font/basicfont"
+)
+
+// newTestModal returns a dialog on a 640x480 screen with lines 13 pixels tall
+func newTestModal(t *testing.T, screenWidth int, labels []string, clipboard common.Clipboard) *Element {
+	font := common.NewFontFromFace("basic", basicfont.Face7x13)
+	e, err := New("modal", "scene", screenWidth, 480, "Title", "body", labels, font, nil, DefaultStyle(), clipboard)
+	if err != nil {
+		t.Fatalf("new: %v", err)
+	}
+	return e
+}
+
+func TestLayout(t *testing.T) {
+	tests := []struct {
+		name        string
+		screenWidth int
+		labels      []string
+		clipboard   common.Clipboard
+		width       int
+		height      int
+		buttons     [][2]float64
+		buttonWidth int
+		input       [2]float64
+	}{
+		{"one button", 640, nil, nil, 360, 114, [][2]float64{{156, 249}}, 328, [2]float64{}},
+		{"two buttons", 640, []string{"Yes", "No"}, nil, 360, 114, [][2]float64{{156, 249}, {328, 249}}, 156, [2]float64{}},
+		{"prompt", 640, nil, &common.MemoryClipboard{}, 360, 162, [][2]float64{{156, 273}}, 328, [2]float64{156, 225}},
+		{"narrow screen", 200, nil, nil, 168, 114, [][2]float64{{32, 249}}, 136, [2]float64{}},
+	}
+	for _, tt := range tests {
+		e := newTestModal(t, tt.screenWidth, tt.labels, tt.clipboard)
+		if e.Width() != tt.width || e.Height() != tt.height {
+			t.Errorf("%s: size %d, %d, want %d, %d", tt.name, e.Width(), e.Height(), tt.width, tt.height)
+		}
+		if len(e.buttons) != len(tt.buttons) {
+			t.Fatalf("%s: %d buttons, want %d", tt.name, len(e.buttons), len(tt.buttons))
+		}
+		for i, b := range e.buttons {
+			x, y := b.Position()
+			if x != tt.buttons[i][0] || y != tt.buttons[i][1] || b.Width() != tt.buttonWidth {
+				t.Errorf("%s: button %d at %v, %v width %d, want %v width %d", tt.name, i, x, y, b.Width(), tt.buttons[i], tt.buttonWidth)
+			}
+		}
+		if tt.clipboard == nil {
+			if e.Input() != nil {
+				t.Errorf("%s: input without a clipboard", tt.name)
+			}
+			continue
+		}
+		x, y := e.Input().Position()
+		if x != tt.input[0] || y != tt.input[1] {
+			t.Errorf("%s: input at %v, %v, want %v", tt.name, x, y, tt.input)
+		}
+	}
+}
+
+func TestLayoutText(t *testing.T) {
+	e := newTestModal(t, 640, nil, nil)
+	e.SetTitle("")
+	if e.Height() != 93 {
+		t.Errorf("height without title %d, want 93", e.Height())
+	}
+	e.SetText("")
+	if e.Height() != 64 {
+		t.Errorf("height without text %d, want 64", e.Height())
+	}
+	e.SetText("one\ntwo")
+	if e.Height() != 106 {
+		t.Errorf("height with two lines %d, want 106", e.Height())
+	}
+}
+
+func TestChoose(t *testing.T) {
+	tests := []struct {
+		name        string
+		index       int
+		isCancelled bool
+		want        Result
+	}{
+		{"first", 0, false, Result{Button: 0, Label: "Save", Text: "name"}},
+		{"second", 1, false, Result{Button: 1, Label: "Discard", Text: "name"}},
+		{"cancelled", 2, true, Result{Button: 2, Label: "Cancel", Text: "name", IsCancelled: true}},
+	}
+	for _, tt := range tests {
+		e := newTestModal(t, 640, []string{"Save", "Discard", "Cancel"}, &common.MemoryClipboard{})
+		e.Input().SetText("name")
+		var results []Result
+		e.SetOnResult(func(e *Element, r Result) {
+			results = append(results, r)
+		})
+		closes := 0
+		e.SetOnClose(func(e *Element) {
+			closes++
+		})
+		e.Show()
+		e.choose(tt.index, tt.isCancelled)
+		e.choose(0, false)
+		if !reflect.DeepEqual(results, []Result{tt.want}) {
+			t.Errorf("%s: results %+v, want %+v", tt.name, results, tt.want)
+		}
+		if closes != 1 || !e.IsClosed() || !e.IsDestroyed() {
+			t.Errorf("%s: closed %d times, closed %v destroyed %v", tt.name, closes, e.IsClosed(), e.IsDestroyed())
+		}
+		if common.InputCaptor() == e {
+			t.Errorf("%s: input still captured", tt.name)
+		}
+	}
+}
+
+func TestShowCapturesInput(t *testing.T) {
+	tests := []struct {
+		name  string
+		close func(e *Element)
+	}{
+		{"close", (*Element).Close},
+		{"scene exit", (*Element).OnSceneExit},
+		{"remove", (*Element).OnRemove},
+	}
+	for _, tt := range tests {
+		e := newTestModal(t, 640, nil, nil)
+		results := 0
+		e.SetOnResult(func(e *Element, r Result) {
+			results++
+		})
+		e.Show()
+		if common.InputCaptor() != e {
+			t.Errorf("%s: input not captured once shown", tt.name)
+		}
+		tt.close(e)
+		if common.InputCaptor() == e || !e.IsClosed() || results != 0 {
+			t.Errorf("%s: captured %v closed %v results %d, want released, closed and no result", tt.name, common.InputCaptor() == e, e.IsClosed(), results)
+		}
+		// a closed dialog is not shown again
+		e.Show()
+		if common.InputCaptor() == e {
+			t.Errorf("%s: closed dialog captured input", tt.name)
+		}
+	}
+}
+
+func TestSetFocus(t *testing.T) {
+	e := newTestModal(t, 640, []string{"Yes", "No"}, nil)
+	for _, tt := range []struct {
+		index int
+		want  int
+	}{{1, 1}, {2, 1}, {-1, 1}, {0, 0}} {
+		e.SetFocus(tt.index)
+		if e.Focus() != tt.want {
+			t.Errorf("set focus %d: got %d, want %d", tt.index, e.Focus(), tt.want)
+		}
+	}
+}
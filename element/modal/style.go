@@ -0,0 +1,49 @@
+package modal
+
+import "image/color"
+
+// Style is how dialogs look. Slices are read from the ui image
+type Style struct {
+	TextColor     color.Color
+	BackdropColor color.Color
+	// FocusColor outlines the button enter chooses
+	FocusColor             color.Color
+	SliceName              string
+	ButtonSliceName        string
+	ButtonPressedSliceName string
+	InputSliceName         string
+	InputFocusedSliceName  string
+	// Width is the width of the panel, narrowed to fit the screen
+	Width        int
+	ButtonHeight int
+	Padding      int
+}
+
+// DefaultStyle returns a style with white text over a dimmed backdrop
+func DefaultStyle() Style {
+	return Style{
+		TextColor:              color.White,
+		BackdropColor:          color.RGBA{0, 0, 0, 160},
+		FocusColor:             color.RGBA{255, 255, 255, 192},
+		SliceName:              "panel",
+		ButtonSliceName:        "button",
+		ButtonPressedSliceName: "button_pressed",
+		InputSliceName:         "input",
+		InputFocusedSliceName:  "input_focused",
+		Width:                  360,
+		ButtonHeight:           32,
+		Padding:                16,
+	}
+}
+
+// Result is how a dialog was closed
+type Result struct {
+	// Button is the index of the chosen button, the last button if cancelled
+	Button int
+	// Label is the text of the chosen button
+	Label string
+	// Text is what was typed in a prompt
+	Text string
+	// IsCancelled is true if the dialog was closed with escape
+	IsCancelled bool
+}
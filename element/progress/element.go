@@ -7,9 +7,7 @@ import (
 
 	"github.com/hajimehoshi/ebiten"
 	"github.com/hajimehoshi/ebiten/inpututil"
-	"github.com/hajimehoshi/ebiten/text"
 	"github.com/xackery/egui/common"
-	"golang.org/x/image/font"
 )
 
 // Element represents a UI clickable 9slice button.
@@ -40,6 +38,7 @@ type Element struct {
 	fillSliceName   string
 	isShadowText    bool
 	value           float64
+	textImage       common.TextImage
 }
 
 // New creates a new button instance
@@ -105,6 +104,11 @@ func (e *Element) SetScale(scale float64) {
 	e.scale = scale
 }
 
+// Scale returns an element's scale. default 1
+func (e *Element) Scale() float64 {
+	return e.scale
+}
+
 // SetRenderIndex sets the render index of element
 func (e *Element) SetRenderIndex(renderIndex int64) {
 	e.renderIndex = renderIndex
@@ -114,10 +118,10 @@ func (e *Element) SetRenderIndex(renderIndex int64) {
 func (e *Element) Update(dt float64) {
 
 	if e.lerpPosition.IsEnabled() {
-		e.x, e.y = e.lerpPosition.Lerp()
+		e.x, e.y = e.lerpPosition.Lerp(dt)
 		if !e.lerpPosition.IsEnabled() {
 			if e.lerpPosition.EndFunc() != nil {
-				e.lerpPosition.EndFunc()
+				e.lerpPosition.EndFunc()()
 			}
 			if e.lerpPosition.IsDestroyed() {
 				e.isDestroyed = true
@@ -129,9 +133,7 @@ func (e *Element) Update(dt float64) {
 	isRecentlyPressed := false
 	//mobile and desktop use differnet touch devices
 	for _, t := range inpututil.JustPressedTouchIDs() {
-		x, y := ebiten.TouchPosition(t)
-		fx := float64(x)
-		fy := float64(y)
+		fx, fy := common.TouchPosition(t)
 		if e.x <= fx && fx < e.x+float64(e.width) && e.y <= fy && fy < e.y+float64(e.height) {
 			e.isPressed = true
 			isRecentlyPressed = true
@@ -141,9 +143,7 @@ func (e *Element) Update(dt float64) {
 	}
 
 	if ebiten.IsMouseButtonPressed(ebiten.MouseButtonLeft) {
-		x, y := ebiten.CursorPosition()
-		fx := float64(x)
-		fy := float64(y)
+		fx, fy := common.CursorPosition()
 		if e.x <= fx && fx < e.x+float64(e.width) && e.y <= fy && fy < e.y+float64(e.height) {
 			e.isPressed = true
 			isRecentlyPressed = true
@@ -201,46 +201,17 @@ func (e *Element) Draw(dst *ebiten.Image) {
 
 	common.DrawNineSlicing(dst, e.image.EbitenImage, slice.Keys[0], e.width, int(e.height), &op.GeoM, &op.ColorM)
 
-	//bounds, _ := font.BoundString(e.font.Face, e.text)
-	//w := float64((bounds.Max.X - bounds.Min.X).Ceil())
-	//text.Draw(dst, e.text, e.font.Face, int(e.x), int(e.y), e.color)
-	bounds, _ := font.BoundString(e.font.Face, e.text)
-	w := float64((bounds.Max.X - bounds.Min.X).Ceil())
-	h := float64((bounds.Max.Y - bounds.Min.Y).Ceil())
-	x := e.x + (float64(e.width)-w)/2
-	//y := float64(e.height) - (float64(e.height)-float64(e.font.Height))/2
-	y := e.y + (float64(e.height) - h/2)
-
-	if e.isShadowText {
-		text.Draw(dst, e.text, e.font.Face, int(x+1), int(y+1), color.Black)
+	layout := &common.TextLayout{
+		Width:      float64(e.width) * e.scale,
+		Height:     float64(e.height) * e.scale,
+		Align:      common.TextAlignCenter,
+		VAlign:     common.TextAlignMiddle,
+		IsEllipsis: true,
 	}
-	text.Draw(dst, e.text, e.font.Face, int(x), int(y), e.color)
-
-	/*_, th := e.font.MeasureSize(e.text)
-	tx := e.x * e.ui.tileScale
-	tx += e.shape.Dx() * e.ui.tileScale / 2
-
-	ty := e.y * e.ui.tileScale
-	ty += (e.shape.Dy()*e.ui.tileScale - float64(th)*e.ui.textScale) / 2
-
-	cr, cg, cb, ca := e.color.RGBA()
-	r8 := uint8(cr >> 8)
-	g8 := uint8(cg >> 8)
-	b8 := uint8(cb >> 8)
-	a8 := uint8(ca >> 8)
-	var c color.Color = color.RGBA{r8, g8, b8, uint8(uint16(a8) * uint16(opacity) / 255)}
-	if !e.isEnabled {
-		c = color.RGBA{r8, g8, b8, uint8(uint16(a8) * uint16(opacity) / (2 * 255))}
-	}
-	l := e.font.Language
-	if l == language.Und {
-		l = e.ui.defaultLanguage
-	}
-	if e.isTextShadow {
-		e.font.DrawText(dst, e.text, tx+e.ui.textScale, ty+e.ui.textScale, e.ui.textScale, 0, color.Black, len([]rune(e.text)))
+	if e.isShadowText {
+		e.textImage.DrawText(dst, e.font, e.text, e.x+1, e.y+1, layout, color.Black)
 	}
-	e.font.DrawText(dst, e.text, tx, ty, e.ui.textScale, 0, c, len([]rune(e.text)))
-	*/
+	e.textImage.DrawText(dst, e.font, e.text, e.x, e.y, layout, e.color)
 
 }
 
@@ -266,7 +237,7 @@ func (e *Element) IsDestroyed() bool {
 
 // LerpPosition changes an element's position over duration
 func (e *Element) LerpPosition(endPositionX, endPositionY float64, duration time.Duration, isDestroyed bool, endFunc func()) {
-	e.lerpPosition.Init(time.Now(), e.x, e.y, endPositionX, endPositionY, duration, true, endFunc, isDestroyed)
+	e.lerpPosition.Init(e.x, e.y, endPositionX, endPositionY, duration, true, endFunc, isDestroyed)
 }
 
 // Position returns an element's position
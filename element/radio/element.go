@@ -0,0 +1,307 @@
+package radio
+
+import (
+	"image/color"
+	"time"
+
+	"github.com/hajimehoshi/ebiten"
+	"github.com/xackery/egui/common"
+)
+
+// Element represents a 9slice radio button with a label.
+// A element belongs to a group, which selects one element at a time
+type Element struct {
+	name               string
+	image              *common.Image
+	x                  float64
+	y                  float64
+	scale              float64
+	width              int
+	height             int
+	text               string
+	group              *Group
+	isEnabled          bool
+	isVisible          bool
+	isPressed          bool
+	isFocused          bool
+	isFocusMoved       bool
+	focusKey           ebiten.Key
+	renderIndex        int64
+	isDestroyed        bool
+	lerpPosition       *common.LerpPosition
+	color              color.Color
+	font               *common.Font
+	uncheckedSliceName string
+	checkedSliceName   string
+	textImage          common.TextImage
+}
+
+// New creates a new radio instance in group. The button is height pixels square, with the label right of it
+func New(name string, scene string, text string, x float64, y float64, width int, height int, font *common.Font, textColor color.Color, img *common.Image, group *Group, uncheckedSliceName string, checkedSliceName string) (*Element, error) {
+	if group == nil {
+		group = NewGroup(name)
+	}
+
+	e := &Element{
+		name:               name,
+		image:              img,
+		text:               text,
+		isEnabled:          true,
+		isVisible:          true,
+		lerpPosition:       new(common.LerpPosition),
+		color:              textColor,
+		x:                  x,
+		y:                  y,
+		width:              width,
+		height:             height,
+		font:               font,
+		uncheckedSliceName: uncheckedSliceName,
+		checkedSliceName:   checkedSliceName,
+		group:              group,
+		scale:              1,
+	}
+	group.add(e)
+
+	return e, nil
+}
+
+// Name returns a element's name
+func (e *Element) Name() string {
+	return e.name
+}
+
+// IsVisible returns true if element is visible
+func (e *Element) IsVisible() bool {
+	return e.isVisible
+}
+
+// IsEnabled returns true if a element is enabled
+func (e *Element) IsEnabled() bool {
+	return e.isEnabled
+}
+
+// SetEnabled changes if a element is enabled. Disabled elements lose focus
+func (e *Element) SetEnabled(isEnabled bool) {
+	e.isEnabled = isEnabled
+	if !isEnabled {
+		e.isFocused = false
+		e.isPressed = false
+	}
+}
+
+// SetVisible changes the visibility of a element
+func (e *Element) SetVisible(isVisible bool) {
+	e.isVisible = isVisible
+}
+
+// RenderIndex returns the render index of element
+func (e *Element) RenderIndex() int64 {
+	return e.renderIndex
+}
+
+// SetRenderIndex sets the render index of element
+func (e *Element) SetRenderIndex(renderIndex int64) {
+	e.renderIndex = renderIndex
+}
+
+// Update is called during a game update
+func (e *Element) Update(dt float64) {
+
+	if e.lerpPosition.IsEnabled() {
+		e.x, e.y = e.lerpPosition.Lerp(dt)
+		if !e.lerpPosition.IsEnabled() {
+			if e.lerpPosition.EndFunc() != nil {
+				e.lerpPosition.EndFunc()()
+			}
+			if e.lerpPosition.IsDestroyed() {
+				e.isDestroyed = true
+				return
+			}
+		}
+	}
+
+	if !e.isEnabled || !e.isVisible {
+		return
+	}
+
+	x, y, ok := common.PointerJustPressed()
+	if ok {
+		e.isPressed = e.contains(x, y)
+		e.isFocused = e.isPressed
+	}
+	if e.isPressed {
+		x, y, ok = common.PointerPressed()
+		if !ok {
+			e.isPressed = false
+			if !common.IsPointerCancelled() {
+				e.Select()
+			}
+		} else if !e.contains(x, y) {
+			e.isPressed = false
+		}
+	}
+
+	if !e.isFocused {
+		return
+	}
+	// the key that moved focus here is still just pressed if this option updates later in the same update
+	if e.isFocusMoved {
+		e.isFocusMoved = false
+		if common.IsKeyJustPressed(e.focusKey) {
+			return
+		}
+	}
+	for _, key := range []ebiten.Key{ebiten.KeySpace, ebiten.KeyEnter, ebiten.KeyUp, ebiten.KeyLeft, ebiten.KeyDown, ebiten.KeyRight} {
+		if !common.IsKeyJustPressed(key) {
+			continue
+		}
+		switch key {
+		case ebiten.KeySpace, ebiten.KeyEnter:
+			e.Select()
+		case ebiten.KeyUp, ebiten.KeyLeft:
+			e.moveFocus(-1, key)
+		default:
+			e.moveFocus(1, key)
+		}
+		return
+	}
+}
+
+// moveFocus focuses and selects the next option of the group in direction dir, after key was pressed
+func (e *Element) moveFocus(dir int, key ebiten.Key) {
+	next := e.group.step(e, dir)
+	if next == e {
+		return
+	}
+	e.isFocused = false
+	next.isFocused = true
+	next.isFocusMoved = true
+	next.focusKey = key
+	next.Select()
+}
+
+// contains returns true if x, y is inside the button or label
+func (e *Element) contains(x float64, y float64) bool {
+	return e.x <= x && x < e.x+float64(e.width)*e.scale && e.y <= y && y < e.y+float64(e.height)*e.scale
+}
+
+// Draw is called during a game update
+func (e *Element) Draw(dst *ebiten.Image) {
+	if !e.isVisible {
+		return
+	}
+
+	sliceName := e.uncheckedSliceName
+	if e.IsSelected() {
+		sliceName = e.checkedSliceName
+	}
+
+	op := &ebiten.DrawImageOptions{}
+	op.GeoM.Translate(e.x, e.y)
+	op.GeoM.Scale(e.scale, e.scale)
+
+	textColor := e.color
+	if !e.isEnabled {
+		op.ColorM.ChangeHSV(0, 0, 1)
+		op.ColorM.Scale(0.5, 0.5, 0.5, 1)
+		textColor = common.DisabledColor(e.color)
+	}
+
+	slice, err := e.image.Slice(sliceName)
+	if err == nil {
+		common.DrawNineSlicing(dst, e.image.EbitenImage, slice.Keys[0], e.height, e.height, &op.GeoM, &op.ColorM)
+	}
+
+	box := float64(e.height) * e.scale
+	gap := box / 4
+	layout := &common.TextLayout{
+		Width:      float64(e.width)*e.scale - box - gap,
+		Height:     box,
+		VAlign:     common.TextAlignMiddle,
+		IsEllipsis: true,
+	}
+	e.textImage.DrawText(dst, e.font, e.text, e.x+box+gap, e.y, layout, textColor)
+}
+
+// Select selects the element, deselecting the rest of its group
+func (e *Element) Select() {
+	e.group.Select(e)
+}
+
+// IsSelected returns true if the element is the selected element of its group
+func (e *Element) IsSelected() bool {
+	return e.group.selected == e
+}
+
+// Group returns the group of the element
+func (e *Element) Group() *Group {
+	return e.group
+}
+
+// SetText changes the label of the element
+func (e *Element) SetText(text string) {
+	e.text = text
+}
+
+// Text returns the label of the element
+func (e *Element) Text() string {
+	return e.text
+}
+
+// IsFocused returns true if space and enter select the element, and arrows move through its group
+func (e *Element) IsFocused() bool {
+	return e.isFocused
+}
+
+// SetIsFocused sets if space and enter select the element, and arrows move through its group. Clicking the element focuses it
+func (e *Element) SetIsFocused(isFocused bool) {
+	e.isFocused = isFocused
+}
+
+// IsDestroyed returns true when the element is flagged for deletion
+func (e *Element) IsDestroyed() bool {
+	return e.isDestroyed
+}
+
+// LerpPosition changes an element's position over duration
+func (e *Element) LerpPosition(endPositionX, endPositionY float64, duration time.Duration, isDestroyed bool, endFunc func()) {
+	e.lerpPosition.Init(e.x, e.y, endPositionX, endPositionY, duration, true, endFunc, isDestroyed)
+}
+
+// Position returns an element's position
+func (e *Element) Position() (float64, float64) {
+	return e.x, e.y
+}
+
+// SetPosition sets an element's position
+func (e *Element) SetPosition(x float64, y float64) {
+	e.x = x
+	e.y = y
+}
+
+// Width returns an element's width
+func (e *Element) Width() int {
+	return e.width
+}
+
+// SetWidth sets an element's width
+func (e *Element) SetWidth(width int) {
+	e.width = width
+}
+
+// Height returns an element's height
+func (e *Element) Height() int {
+	return e.height
+}
+
+// SetHeight sets an element's height
+func (e *Element) SetHeight(height int) {
+	e.height = height
+}
+
+// SetIsDestroyed sets an element to be destroyed on next update
+func (e *Element) SetIsDestroyed(isDestroyed bool) {
+	e.isDestroyed = true
+	e.group.remove(e)
+	e.textImage.Dispose()
+}
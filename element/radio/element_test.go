@@ -0,0 +1,151 @@
+package radio
+
+import (
+	"image/color"
+	"testing"
+)
+
+// newTestGroup returns a group with an option for each name
+func newTestGroup(t *testing.T, names ...string) (*Group, []*Element) {
+	g := NewGroup("group")
+	var options []*Element
+	for _, name := range names {
+		e, err := New(name, "scene", name, 0, 0, 100, 20, nil, color.White, nil, g, "", "")
+		if err != nil {
+			t.Fatalf("new %s: %v", name, err)
+		}
+		options = append(options, e)
+	}
+	return g, options
+}
+
+func TestGroupSelect(t *testing.T) {
+	g, options := newTestGroup(t, "a", "b", "c")
+	var changes []string
+	g.SetOnChange(func(g *Group, e *Element) {
+		name := ""
+		if e != nil {
+			name = e.Name()
+		}
+		changes = append(changes, name)
+	})
+
+	tests := []struct {
+		name     string
+		action   func()
+		selected string
+	}{
+		{"select a", options[0].Select, "a"},
+		{"select b deselects a", options[1].Select, "b"},
+		{"select b again", options[1].Select, "b"},
+		{"group selects c", func() { g.Select(options[2]) }, "c"},
+		{"clear", func() { g.Select(nil) }, ""},
+	}
+	for _, tt := range tests {
+		tt.action()
+		selected := ""
+		if g.Selected() != nil {
+			selected = g.Selected().Name()
+		}
+		if selected != tt.selected {
+			t.Errorf("%s: selected %q, want %q", tt.name, selected, tt.selected)
+		}
+		count := 0
+		for _, o := range options {
+			if o.IsSelected() {
+				count++
+			}
+		}
+		if tt.selected != "" && count != 1 || tt.selected == "" && count != 0 {
+			t.Errorf("%s: %d options selected", tt.name, count)
+		}
+	}
+
+	want := []string{"a", "b", "c", ""}
+	if len(changes) != len(want) {
+		t.Fatalf("changes %v, want %v", changes, want)
+	}
+	for i := range want {
+		if changes[i] != want[i] {
+			t.Errorf("change %d: got %q, want %q", i, changes[i], want[i])
+		}
+	}
+}
+
+func TestGroupSelectOtherGroup(t *testing.T) {
+	g, options := newTestGroup(t, "a")
+	_, others := newTestGroup(t, "x")
+	options[0].Select()
+	g.Select(others[0])
+	if g.Selected() != options[0] {
+		t.Errorf("selected %v, want a", g.Selected().Name())
+	}
+	if others[0].IsSelected() {
+		t.Errorf("option of another group is selected")
+	}
+}
+
+func TestGroupRemove(t *testing.T) {
+	g, options := newTestGroup(t, "a", "b")
+	options[1].Select()
+	options[1].SetIsDestroyed(true)
+	if g.Selected() != nil {
+		t.Errorf("selected %s after it was destroyed, want none", g.Selected().Name())
+	}
+	if len(g.Options()) != 1 || g.Options()[0] != options[0] {
+		t.Errorf("options %d after destroy, want a", len(g.Options()))
+	}
+}
+
+func TestGroupStep(t *testing.T) {
+	tests := []struct {
+		name     string
+		from     int
+		dir      int
+		disabled []int
+		hidden   []int
+		want     int
+	}{
+		{"next", 0, 1, nil, nil, 1},
+		{"previous", 1, -1, nil, nil, 0},
+		{"wraps forward", 3, 1, nil, nil, 0},
+		{"wraps back", 0, -1, nil, nil, 3},
+		{"skips disabled", 0, 1, []int{1}, nil, 2},
+		{"skips hidden", 0, -1, nil, []int{3}, 2},
+		{"stays when none can take focus", 0, 1, []int{1, 2}, []int{3}, 0},
+	}
+	for _, tt := range tests {
+		g, options := newTestGroup(t, "a", "b", "c", "d")
+		for _, i := range tt.disabled {
+			options[i].SetEnabled(false)
+		}
+		for _, i := range tt.hidden {
+			options[i].SetVisible(false)
+		}
+		if got := g.step(options[tt.from], tt.dir); got != options[tt.want] {
+			t.Errorf("%s: got %s, want %s", tt.name, got.Name(), options[tt.want].Name())
+		}
+	}
+}
+
+func TestMoveFocus(t *testing.T) {
+	g, options := newTestGroup(t, "a", "b", "c")
+	options[0].isFocused = true
+	options[0].moveFocus(1, 0)
+	if options[0].IsFocused() || !options[1].IsFocused() {
+		t.Errorf("focus did not move from a to b")
+	}
+	if g.Selected() != options[1] {
+		t.Errorf("moving focus did not select b")
+	}
+}
+
+func TestNewWithoutGroup(t *testing.T) {
+	e, err := New("solo", "scene", "solo", 0, 0, 100, 20, nil, color.White, nil, nil, "", "")
+	if err != nil {
+		t.Fatalf("new: %v", err)
+	}
+	if e.Group() == nil || len(e.Group().Options()) != 1 {
+		t.Errorf("option without a group is not in its own group")
+	}
+}
@@ -0,0 +1,87 @@
+package radio
+
+// Group is a set of radio elements where at most one is selected
+type Group struct {
+	name     string
+	options  []*Element
+	selected *Element
+	onChange func(g *Group, e *Element)
+}
+
+// NewGroup creates an empty radio group
+func NewGroup(name string) *Group {
+	return &Group{name: name}
+}
+
+// Name returns a group's name
+func (g *Group) Name() string {
+	return g.name
+}
+
+// Options returns the elements of the group in the order they were added
+func (g *Group) Options() []*Element {
+	return g.options
+}
+
+// Selected returns the selected element, or nil if none is
+func (g *Group) Selected() *Element {
+	return g.selected
+}
+
+// Select selects e and deselects the rest, calling the change function if the selection changed.
+// A nil e clears the selection
+func (g *Group) Select(e *Element) {
+	if g.selected == e {
+		return
+	}
+	if e != nil && e.group != g {
+		return
+	}
+	g.selected = e
+	if g.onChange != nil {
+		g.onChange(g, e)
+	}
+}
+
+// SetOnChange sets a function called with the newly selected element when the selection changes
+func (g *Group) SetOnChange(f func(g *Group, e *Element)) {
+	g.onChange = f
+}
+
+// add appends an element to the group
+func (g *Group) add(e *Element) {
+	g.options = append(g.options, e)
+}
+
+// remove drops an element from the group, clearing the selection if it was selected
+func (g *Group) remove(e *Element) {
+	for i, o := range g.options {
+		if o != e {
+			continue
+		}
+		g.options = append(g.options[:i], g.options[i+1:]...)
+		break
+	}
+	if g.selected == e {
+		g.Select(nil)
+	}
+}
+
+// step returns the next enabled and visible element after e in direction dir, wrapping around
+func (g *Group) step(e *Element, dir int) *Element {
+	n := len(g.options)
+	start := 0
+	for i, o := range g.options {
+		if o == e {
+			start = i
+			break
+		}
+	}
+	for i := 1; i < n; i++ {
+		o := g.options[((start+dir*i)%n+n)%n]
+		if o.isEnabled && o.isVisible {
+			return o
+		}
+	}
+	return e
+}
@@ -0,0 +1,127 @@
+package scrollview
+
+import (
+	"math"
+	"sort"
+)
+
+const (
+	// stopVelocity is the speed in pixels per second inertia stops at
+	stopVelocity = 10
+	// snapRate is how quickly scrolling eases to a snap point, per second
+	snapRate = 12
+)
+
+// axis is the scrolling state along one direction
+type axis struct {
+	scroll   float64
+	velocity float64
+	// snaps are sorted scroll positions scrolling comes to rest at
+	snaps      []float64
+	snapTarget float64
+	isSnapping bool
+}
+
+// update glides with inertia, slowing by friction per second, or eases to a snap point.
+// It returns true if scrolling moved
+func (a *axis) update(dt float64, friction float64, max float64) bool {
+	prev := a.scroll
+	switch {
+	case a.isSnapping:
+		a.scroll += (a.snapTarget - a.scroll) * math.Min(1, dt*snapRate)
+		if math.Abs(a.snapTarget-a.scroll) < 0.5 {
+			a.scroll = a.snapTarget
+			a.isSnapping = false
+		}
+	case a.velocity != 0:
+		a.scroll += a.velocity * dt
+		a.velocity *= math.Exp(-friction * dt)
+		if math.Abs(a.velocity) < stopVelocity {
+			a.velocity = 0
+		}
+	}
+	a.clamp(max)
+	return a.scroll != prev
+}
+
+// clamp keeps scrolling within 0 and max, stopping inertia at the ends
+func (a *axis) clamp(max float64) {
+	if max < 0 {
+		max = 0
+	}
+	if a.scroll < 0 {
+		a.scroll = 0
+		a.velocity = 0
+	}
+	if a.scroll > max {
+		a.scroll = max
+		a.velocity = 0
+	}
+}
+
+// release starts inertia after a drag. With snap points, scrolling eases to the point nearest where inertia would rest
+func (a *axis) release(friction float64) {
+	if len(a.snaps) == 0 {
+		return
+	}
+	rest := a.scroll
+	if friction > 0 {
+		rest += a.velocity / friction
+	}
+	a.velocity = 0
+	a.snapTo(a.nearestSnap(rest))
+}
+
+// scrollBy moves by delta, or to the next snap point in its direction if there are any
+func (a *axis) scrollBy(delta float64) {
+	a.velocity = 0
+	if len(a.snaps) == 0 || delta == 0 {
+		a.isSnapping = false
+		a.scroll += delta
+		return
+	}
+	from := a.scroll
+	if a.isSnapping {
+		from = a.snapTarget
+	}
+	target := from
+	if delta > 0 {
+		i := sort.SearchFloat64s(a.snaps, from+0.5)
+		if i < len(a.snaps) {
+			target = a.snaps[i]
+		}
+	} else {
+		i := sort.SearchFloat64s(a.snaps, from-0.5) - 1
+		if i >= 0 {
+			target = a.snaps[i]
+		}
+	}
+	a.snapTo(target)
+}
+
+// snapTo eases scrolling to pos
+func (a *axis) snapTo(pos float64) {
+	a.velocity = 0
+	a.snapTarget = pos
+	a.isSnapping = true
+}
+
+// stop ends inertia and snapping, e.g. when dragging starts
+func (a *axis) stop() {
+	a.velocity = 0
+	a.isSnapping = false
+}
+
+// nearestSnap returns the snap point closest to pos
+func (a *axis) nearestSnap(pos float64) float64 {
+	i := sort.SearchFloat64s(a.snaps, pos)
+	switch {
+	case i == 0:
+		return a.snaps[0]
+	case i == len(a.snaps):
+		return a.snaps[len(a.snaps)-1]
+	case pos-a.snaps[i-1] < a.snaps[i]-pos:
+		return a.snaps[i-1]
+	}
+	return a.snaps[i]
+}
@@ -0,0 +1,114 @@
+package scrollview
+
+import (
+	"math"
+	"testing"
+)
+
+func TestAxisUpdate(t *testing.T) {
+	tests := []struct {
+		name     string
+		axis     axis
+		dt       float64
+		max      float64
+		scroll   float64
+		velocity float64
+		isMoved  bool
+	}{
+		{"at rest", axis{scroll: 10}, 0.5, 100, 10, 0, false},
+		{"glides", axis{velocity: 100}, 0.5, 100, 50, 100 * math.Exp(-2), true},
+		{"stops when slow", axis{velocity: 15}, 0.5, 100, 7.5, 0, true},
+		{"clamped at max", axis{scroll: 20, velocity: 100}, 1, 30, 30, 0, true},
+		{"clamped at zero", axis{scroll: 10, velocity: -100}, 1, 30, 0, 0, true},
+		{"content smaller than view", axis{scroll: 10}, 1, -20, 0, 0, true},
+		{"snaps", axis{snapTarget: 100, isSnapping: true}, 1, 200, 100, 0, true},
+		{"eases to snap", axis{snapTarget: 100, isSnapping: true}, 0.05, 200, 60, 0, true},
+	}
+	for _, tt := range tests {
+		a := tt.axis
+		isMoved := a.update(tt.dt, 4, tt.max)
+		if math.Abs(a.scroll-tt.scroll) > 1e-9 || math.Abs(a.velocity-tt.velocity) > 1e-9 {
+			t.Errorf("%s: scroll %v velocity %v, want %v %v", tt.name, a.scroll, a.velocity, tt.scroll, tt.velocity)
+		}
+		if isMoved != tt.isMoved {
+			t.Errorf("%s: moved %v, want %v", tt.name, isMoved, tt.isMoved)
+		}
+	}
+}
+
+func TestAxisRelease(t *testing.T) {
+	tests := []struct {
+		name       string
+		snaps      []float64
+		scroll     float64
+		velocity   float64
+		friction   float64
+		isSnapping bool
+		target     float64
+	}{
+		{"keeps inertia without snaps", nil, 40, 400, 4, false, 0},
+		{"snaps where inertia rests", []float64{0, 100, 200}, 40, 400, 4, true, 100},
+		{"snaps backwards", []float64{0, 100, 200}, 140, -400, 4, true, 0},
+		{"no friction snaps in place", []float64{0, 100, 200}, 160, 400, 0, true, 200},
+	}
+	for _, tt := range tests {
+		a := axis{scroll: tt.scroll, velocity: tt.velocity, snaps: tt.snaps}
+		a.release(tt.friction)
+		if a.isSnapping != tt.isSnapping || a.snapTarget != tt.target {
+			t.Errorf("%s: snapping %v to %v, want %v to %v", tt.name, a.isSnapping, a.snapTarget, tt.isSnapping, tt.target)
+		}
+		if tt.isSnapping && a.velocity != 0 {
+			t.Errorf("%s: velocity %v, want 0 while snapping", tt.name, a.velocity)
+		}
+	}
+}
+
+func TestAxisScrollBy(t *testing.T) {
+	tests := []struct {
+		name   string
+		snaps  []float64
+		scroll float64
+		deltas []float64
+		want   float64
+	}{
+		{"free", nil, 0, []float64{10, 5}, 15},
+		{"next snap", []float64{0, 100, 200}, 0, []float64{1}, 100},
+		// a step while easing counts from the snap being eased to
+		{"repeated while snapping", []float64{0, 100, 200}, 0, []float64{1, 1}, 200},
+		{"previous snap", []float64{0, 100, 200}, 150, []float64{-1}, 100},
+		{"previous from a snap", []float64{0, 100, 200}, 100, []float64{-1}, 0},
+		{"past the last snap", []float64{0, 100, 200}, 200, []float64{1}, 200},
+	}
+	for _, tt := range tests {
+		a := axis{scroll: tt.scroll, velocity: 50, snaps: tt.snaps}
+		for _, d := range tt.deltas {
+			a.scrollBy(d)
+		}
+		got := a.scroll
+		if a.isSnapping {
+			got = a.snapTarget
+		}
+		if got != tt.want || a.velocity != 0 {
+			t.Errorf("%s: scroll %v velocity %v, want %v 0", tt.name, got, a.velocity, tt.want)
+		}
+	}
+}
+
+func TestAxisNearestSnap(t *testing.T) {
+	a := axis{snaps: []float64{0, 100, 200}}
+	tests := []struct {
+		pos  float64
+		want float64
+	}{
+		{-5, 0},
+		{40, 0},
+		{50, 100},
+		{160, 200},
+		{300, 200},
+	}
+	for _, tt := range tests {
+		if got := a.nearestSnap(tt.pos); got != tt.want {
+			t.Errorf("nearest to %v: got %v, want %v", tt.pos, got, tt.want)
+		}
+	}
+}
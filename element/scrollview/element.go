@@ -0,0 +1,767 @@
+package scrollview
+
+import (
+	"image"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/hajimehoshi/ebiten"
+	"github.com/xackery/egui/common"
+	"github.com/xackery/egui/element"
+)
+
+const (
+	// dragThreshold is how far a press moves before it scrolls instead of reaching children
+	dragThreshold = 6
+	// lineStep is how far a wheel notch or arrow key scrolls
+	lineStep = 40
+)
+
+// Element represents a view that clips child elements to its bounds and scrolls them.
+// A element scrolls by wheel, drag, touch fling with inertia and keyboard
+// A element may draw 9slice scrollbars and come to rest at snap points
+type Element struct {
+	name             string
+	image            *common.Image
+	x                float64
+	y                float64
+	scale            float64
+	width            int
+	height           int
+	elements         []element.Interfacer
+	contentWidth     int
+	contentHeight    int
+	horizontal       axis
+	vertical         axis
+	friction         float64
+	isEnabled        bool
+	isVisible        bool
+	isFocused        bool
+	isPressed        bool
+	isDragging       bool
+	pressX           float64
+	pressY           float64
+	lastX            float64
+	lastY            float64
+	dragBar          common.Orientation
+	isBarDragging    bool
+	renderIndex      int64
+	isDestroyed      bool
+	lerpPosition     *common.LerpPosition
+	trackSliceName   string
+	thumbSliceName   string
+	barSize          int
+	keyRepeat        *common.KeyRepeat
+	view             *ebiten.Image
+	onScroll         func(e *Element, x float64, y float64)
+	onPressFunction  func()
+	lastScrollX      float64
+	lastScrollY      float64
+	isScrollReported bool
+}
+
+// New creates a new scroll view instance. Scrollbars are drawn with trackSliceName and thumbSliceName,
+// and are hidden if thumbSliceName is empty
+func New(name string, scene string, x float64, y float64, width int, height int, img *common.Image, trackSliceName string, thumbSliceName string) (*Element, error) {
+
+	e := &Element{
+		name:           name,
+		image:          img,
+		x:              x,
+		y:              y,
+		scale:          1,
+		width:          width,
+		height:         height,
+		friction:       4,
+		isEnabled:      true,
+		isVisible:      true,
+		lerpPosition:   new(common.LerpPosition),
+		trackSliceName: trackSliceName,
+		thumbSliceName: thumbSliceName,
+		barSize:        8,
+		keyRepeat:      common.NewKeyRepeat(),
+	}
+	return e, nil
+}
+
+// Name returns a element's name
+func (e *Element) Name() string {
+	return e.name
+}
+
+// IsVisible returns true if element is visible
+func (e *Element) IsVisible() bool {
+	return e.isVisible
+}
+
+// IsEnabled returns true if a element is enabled
+func (e *Element) IsEnabled() bool {
+	return e.isEnabled
+}
+
+// SetEnabled changes if a element is enabled. Children of a disabled view are not updated
+func (e *Element) SetEnabled(isEnabled bool) {
+	e.isEnabled = isEnabled
+}
+
+// SetVisible changes the visibility of a element
+func (e *Element) SetVisible(isVisible bool) {
+	e.isVisible = isVisible
+}
+
+// RenderIndex returns the render index of element
+func (e *Element) RenderIndex() int64 {
+	return e.renderIndex
+}
+
+// SetRenderIndex sets the render index of element
+func (e *Element) SetRenderIndex(renderIndex int64) {
+	e.renderIndex = renderIndex
+}
+
+// Update is called during a game update
+func (e *Element) Update(dt float64) {
+
+	if e.lerpPosition.IsEnabled() {
+		e.x, e.y = e.lerpPosition.Lerp(dt)
+		if !e.lerpPosition.IsEnabled() {
+			if e.lerpPosition.EndFunc() != nil {
+				e.lerpPosition.EndFunc()()
+			}
+			if e.lerpPosition.IsDestroyed() {
+				e.isDestroyed = true
+				return
+			}
+		}
+	}
+
+	if !e.isEnabled || !e.isVisible {
+		return
+	}
+
+	e.updatePointer(dt)
+	if e.isFocused {
+		e.updateKeys(dt)
+	}
+	maxX, maxY := e.maxScroll()
+	if !e.isDragging {
+		e.horizontal.update(dt, e.friction, maxX)
+		e.vertical.update(dt, e.friction, maxY)
+	}
+	e.horizontal.clamp(maxX)
+	e.vertical.clamp(maxY)
+	e.reportScroll()
+
+	// children see the pointer relative to the scrolled content, and miss it outside the view or while dragging
+	w, h := e.viewSize()
+	if e.isDragging || e.isBarDragging {
+		w, h = 0, 0
+	}
+	common.PushInputView(e.x, e.y, w, h, e.x-e.horizontal.scroll, e.y-e.vertical.scroll)
+	for _, c := range e.elements {
+		c.Update(dt)
+	}
+	common.PopInputView()
+
+	for i := 0; i < len(e.elements); i++ {
+		if !e.elements[i].IsDestroyed() {
+			continue
+		}
+		e.elements = append(e.elements[:i], e.elements[i+1:]...)
+		i--
+	}
+}
+
+// updatePointer scrolls with the wheel, drags content or scrollbars and flings on release
+func (e *Element) updatePointer(dt float64) {
+	cx, cy := common.CursorPosition()
+	wx, wy := ebiten.Wheel()
+	if (wx != 0 || wy != 0) && e.contains(cx, cy) {
+		if common.IsKeyPressed(ebiten.KeyShift) {
+			wx, wy = wy, 0
+		}
+		e.horizontal.scrollBy(-wx * lineStep)
+		e.vertical.scrollBy(-wy * lineStep)
+	}
+
+	x, y, ok := common.PointerJustPressed()
+	if ok {
+		e.isPressed = e.contains(x, y)
+		e.isFocused = e.isPressed
+		if !e.isPressed {
+			return
+		}
+		e.pressX, e.pressY = x, y
+		e.lastX, e.lastY = x, y
+		e.horizontal.stop()
+		e.vertical.stop()
+		if bar, ok := e.barAt(x, y); ok {
+			e.isBarDragging = true
+			e.dragBar = bar
+			e.jumpBar(bar, x, y)
+		}
+		return
+	}
+	if !e.isPressed {
+		return
+	}
+
+	x, y, ok = common.PointerPressed()
+	if !ok {
+		if e.isDragging {
+			e.horizontal.release(e.friction)
+			e.vertical.release(e.friction)
+		} else if !e.isBarDragging && e.onPressFunction != nil && !common.IsPointerCancelled() {
+			e.onPressFunction()
+		}
+		e.isPressed = false
+		e.isDragging = false
+		e.isBarDragging = false
+		return
+	}
+
+	if e.isBarDragging {
+		e.dragBarTo(x, y)
+		return
+	}
+	if !e.isDragging && math.Hypot(x-e.pressX, y-e.pressY) >= dragThreshold {
+		e.isDragging = true
+	}
+	if !e.isDragging {
+		return
+	}
+	dx, dy := x-e.lastX, y-e.lastY
+	e.lastX, e.lastY = x, y
+	maxX, maxY := e.maxScroll()
+	if maxX > 0 {
+		e.horizontal.scroll -= dx
+		e.horizontal.velocity = flingVelocity(e.horizontal.velocity, -dx, dt)
+	}
+	if maxY > 0 {
+		e.vertical.scroll -= dy
+		e.vertical.velocity = flingVelocity(e.vertical.velocity, -dy, dt)
+	}
+}
+
+// flingVelocity smooths the drag speed so a release keeps the recent motion
+func flingVelocity(velocity float64, delta float64, dt float64) float64 {
+	if dt <= 0 {
+		return velocity
+	}
+	return velocity*0.5 + delta/dt*0.5
+}
+
+// updateKeys scrolls with arrows, page keys, home and end
+func (e *Element) updateKeys(dt float64) {
+	_, h := e.viewSize()
+	for i := e.keyRepeat.Count(ebiten.KeyUp, dt); i > 0; i-- {
+		e.vertical.scrollBy(-lineStep)
+	}
+	for i := e.keyRepeat.Count(ebiten.KeyDown, dt); i > 0; i-- {
+		e.vertical.scrollBy(lineStep)
+	}
+	for i := e.keyRepeat.Count(ebiten.KeyLeft, dt); i > 0; i-- {
+		e.horizontal.scrollBy(-lineStep)
+	}
+	for i := e.keyRepeat.Count(ebiten.KeyRight, dt); i > 0; i-- {
+		e.horizontal.scrollBy(lineStep)
+	}
+	for i := e.keyRepeat.Count(ebiten.KeyPageUp, dt); i > 0; i-- {
+		e.vertical.scrollBy(-h)
+	}
+	for i := e.keyRepeat.Count(ebiten.KeyPageDown, dt); i > 0; i-- {
+		e.vertical.scrollBy(h)
+	}
+	_, maxY := e.maxScroll()
+	if common.IsKeyJustPressed(ebiten.KeyHome) {
+		e.ScrollTo(e.horizontal.scroll, 0)
+	}
+	if common.IsKeyJustPressed(ebiten.KeyEnd) {
+		e.ScrollTo(e.horizontal.scroll, maxY)
+	}
+}
+
+// reportScroll calls the scroll function once per update if scrolling moved
+func (e *Element) reportScroll() {
+	x, y := e.horizontal.scroll, e.vertical.scroll
+	if e.isScrollReported && x == e.lastScrollX && y == e.lastScrollY {
+		return
+	}
+	e.isScrollReported = true
+	e.lastScrollX, e.lastScrollY = x, y
+	if e.onScroll != nil {
+		e.onScroll(e, x, y)
+	}
+}
+
+// contains returns true if x, y is inside the view
+func (e *Element) contains(x float64, y float64) bool {
+	return e.x <= x && x < e.x+float64(e.width)*e.scale && e.y <= y && y < e.y+float64(e.height)*e.scale
+}
+
+// ContentSize returns the size of the scrolled content, measured from children unless set
+func (e *Element) ContentSize() (int, int) {
+	w, h := e.contentWidth, e.contentHeight
+	if w > 0 && h > 0 {
+		return w, h
+	}
+	mw, mh := 0, 0
+	for _, c := range e.elements {
+		p, ok := c.(element.Positioner)
+		if !ok {
+			continue
+		}
+		s, ok := c.(element.Sizer)
+		if !ok {
+			continue
+		}
+		x, y := p.Position()
+		mw = maxInt(mw, int(math.Ceil(x))+s.Width())
+		mh = maxInt(mh, int(math.Ceil(y))+s.Height())
+	}
+	if w <= 0 {
+		w = mw
+	}
+	if h <= 0 {
+		h = mh
+	}
+	return w, h
+}
+
+// SetContentSize sets the size of the scrolled content. 0 measures that side from children
+func (e *Element) SetContentSize(width int, height int) {
+	e.contentWidth = width
+	e.contentHeight = height
+}
+
+// hasBars returns which scrollbars are shown, for content wider or taller than the view
+func (e *Element) hasBars() (bool, bool) {
+	if e.thumbSliceName == "" {
+		return false, false
+	}
+	cw, ch := e.ContentSize()
+	isVertical := ch > e.height
+	isHorizontal := cw > e.width
+	// a bar takes room from the other side, which may need its own bar
+	if isVertical && !isHorizontal {
+		isHorizontal = cw > e.width-e.barSize
+	}
+	if isHorizontal && !isVertical {
+		isVertical = ch > e.height-e.barSize
+	}
+	return isHorizontal, isVertical
+}
+
+// viewSize returns the size of the visible content area, excluding scrollbars
+func (e *Element) viewSize() (float64, float64) {
+	w, h := e.width, e.height
+	isHorizontal, isVertical := e.hasBars()
+	if isVertical {
+		w -= e.barSize
+	}
+	if isHorizontal {
+		h -= e.barSize
+	}
+	return float64(w) * e.scale, float64(h) * e.scale
+}
+
+// maxScroll returns the furthest content scrolls on each side
+func (e *Element) maxScroll() (float64, float64) {
+	cw, ch := e.ContentSize()
+	w, h := e.viewSize()
+	return math.Max(0, float64(cw)-w), math.Max(0, float64(ch)-h)
+}
+
+// barRect returns the track and thumb of a scrollbar in screen coordinates
+func (e *Element) barRect(bar common.Orientation) (image.Rectangle, image.Rectangle) {
+	w, h := e.viewSize()
+	cw, ch := e.ContentSize()
+	maxX, maxY := e.maxScroll()
+	size := float64(e.barSize) * e.scale
+	if bar == common.Vertical {
+		track := image.Rect(int(e.x+w), int(e.y), int(e.x+w+size), int(e.y+h))
+		length := math.Max(size, h*h/math.Max(1, float64(ch)))
+		top := 0.0
+		if maxY > 0 {
+			top = (h - length) * e.vertical.scroll / maxY
+		}
+		return track, image.Rect(track.Min.X, int(e.y+top), track.Max.X, int(e.y+top+length))
+	}
+	track := image.Rect(int(e.x), int(e.y+h), int(e.x+w), int(e.y+h+size))
+	length := math.Max(size, w*w/math.Max(1, float64(cw)))
+	left := 0.0
+	if maxX > 0 {
+		left = (w - length) * e.horizontal.scroll / maxX
+	}
+	return track, image.Rect(int(e.x+left), track.Min.Y, int(e.x+left+length), track.Max.Y)
+}
+
+// barAt returns the scrollbar at x, y
+func (e *Element) barAt(x float64, y float64) (common.Orientation, bool) {
+	isHorizontal, isVertical := e.hasBars()
+	p := image.Pt(int(x), int(y))
+	if isVertical {
+		track, _ := e.barRect(common.Vertical)
+		if p.In(track) {
+			return common.Vertical, true
+		}
+	}
+	if isHorizontal {
+		track, _ := e.barRect(common.Horizontal)
+		if p.In(track) {
+			return common.Horizontal, true
+		}
+	}
+	return common.Horizontal, false
+}
+
+// jumpBar pages toward x, y when the track outside the thumb is pressed
+func (e *Element) jumpBar(bar common.Orientation, x float64, y float64) {
+	_, thumb := e.barRect(bar)
+	w, h := e.viewSize()
+	if bar == common.Vertical {
+		switch {
+		case int(y) < thumb.Min.Y:
+			e.vertical.scrollBy(-h)
+		case int(y) >= thumb.Max.Y:
+			e.vertical.scrollBy(h)
+		}
+		return
+	}
+	switch {
+	case int(x) < thumb.Min.X:
+		e.horizontal.scrollBy(-w)
+	case int(x) >= thumb.Max.X:
+		e.horizontal.scrollBy(w)
+	}
+}
+
+// dragBarTo moves content with a dragged scrollbar thumb
+func (e *Element) dragBarTo(x float64, y float64) {
+	dx, dy := x-e.lastX, y-e.lastY
+	e.lastX, e.lastY = x, y
+	track, thumb := e.barRect(e.dragBar)
+	maxX, maxY := e.maxScroll()
+	if e.dragBar == common.Vertical {
+		room := float64(track.Dy() - thumb.Dy())
+		if room > 0 {
+			e.vertical.stop()
+			e.vertical.scroll += dy * maxY / room
+		}
+		return
+	}
+	room := float64(track.Dx() - thumb.Dx())
+	if room > 0 {
+		e.horizontal.stop()
+		e.horizontal.scroll += dx * maxX / room
+	}
+}
+
+// Draw is called during a game update
+func (e *Element) Draw(dst *ebiten.Image) {
+	if !e.isVisible {
+		return
+	}
+
+	op := &ebiten.DrawImageOptions{}
+	if !e.isEnabled {
+		op.ColorM.ChangeHSV(0, 0, 1)
+		op.ColorM.Scale(0.5, 0.5, 0.5, 1)
+	}
+
+	w, h := e.viewSize()
+	view := e.viewImage(int(math.Ceil(w)), int(math.Ceil(h)))
+	if view != nil {
+		view.Clear()
+		e.drawChildren(view)
+		op.GeoM.Translate(e.x, e.y)
+		dst.DrawImage(view, op)
+	}
+
+	isHorizontal, isVertical := e.hasBars()
+	if isVertical {
+		e.drawBar(dst, common.Vertical, &op.ColorM)
+	}
+	if isHorizontal {
+		e.drawBar(dst, common.Horizontal, &op.ColorM)
+	}
+}
+
+// drawBar draws a scrollbar track and thumb
+func (e *Element) drawBar(dst *ebiten.Image, bar common.Orientation, colorM *ebiten.ColorM) {
+	track, thumb := e.barRect(bar)
+	for _, part := range []struct {
+		sliceName string
+		rect      image.Rectangle
+	}{{e.trackSliceName, track}, {e.thumbSliceName, thumb}} {
+		slice, err := e.image.Slice(part.sliceName)
+		if err != nil {
+			continue
+		}
+		geoM := ebiten.GeoM{}
+		geoM.Translate(float64(part.rect.Min.X), float64(part.rect.Min.Y))
+		common.DrawNineSlicing(dst, e.image.EbitenImage, slice.Keys[0], part.rect.Dx(), part.rect.Dy(), &geoM, colorM)
+	}
+}
+
+// drawChildren draws children to the view image, moved by the scroll for the draw and skipped when out of view.
+// Children that are not a element.Positioner do not scroll
+func (e *Element) drawChildren(view *ebiten.Image) {
+	sx, sy := e.horizontal.scroll, e.vertical.scroll
+	vw, vh := view.Size()
+	for _, c := range e.elements {
+		if !c.IsVisible() {
+			continue
+		}
+		p, ok := c.(element.Positioner)
+		if !ok {
+			c.Draw(view)
+			continue
+		}
+		x, y := p.Position()
+		if s, ok := c.(element.Sizer); ok {
+			if x+float64(s.Width()) <= sx || x >= sx+float64(vw) || y+float64(s.Height()) <= sy || y >= sy+float64(vh) {
+				continue
+			}
+		}
+		p.SetPosition(x-sx, y-sy)
+		c.Draw(view)
+		p.SetPosition(x, y)
+	}
+}
+
+// viewImage returns the offscreen image children draw to, the size of the view, creating it when the view is resized
+func (e *Element) viewImage(w int, h int) *ebiten.Image {
+	if w <= 0 || h <= 0 {
+		return nil
+	}
+	if e.view != nil {
+		vw, vh := e.view.Size()
+		if vw == w && vh == h {
+			return e.view
+		}
+		e.view.Dispose()
+		e.view = nil
+	}
+	view, err := ebiten.NewImage(w, h, ebiten.FilterDefault)
+	if err != nil {
+		return nil
+	}
+	e.view = view
+	return view
+}
+
+func maxInt(a int, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
+
+// AddElement adds a child element. Children are positioned relative to the top left of the content
+func (e *Element) AddElement(c element.Interfacer) error {
+	if c.Name() == "" {
+		return common.ErrElementNameInvalid
+	}
+	_, err := e.Element(c.Name())
+	if err == nil {
+		return common.ErrElementAlreadyExists
+	}
+	e.elements = append(e.elements, c)
+	return nil
+}
+
+// RemoveElement removes a child element
+func (e *Element) RemoveElement(name string) error {
+	for i, c := range e.elements {
+		if c.Name() != name {
+			continue
+		}
+		e.elements = append(e.elements[:i], e.elements[i+1:]...)
+		return nil
+	}
+	return common.ErrElementNotFound
+}
+
+// Element returns a child element based on name
+func (e *Element) Element(name string) (element.Interfacer, error) {
+	for _, c := range e.elements {
+		if c.Name() == name {
+			return c, nil
+		}
+	}
+	return nil, common.ErrElementNotFound
+}
+
+// Elements returns the child elements
+func (e *Element) Elements() []element.Interfacer {
+	return e.elements
+}
+
+// Scroll returns how far content is scrolled
+func (e *Element) Scroll() (float64, float64) {
+	return e.horizontal.scroll, e.vertical.scroll
+}
+
+// SetScroll scrolls content to x, y immediately
+func (e *Element) SetScroll(x float64, y float64) {
+	e.horizontal.stop()
+	e.vertical.stop()
+	e.horizontal.scroll = x
+	e.vertical.scroll = y
+	maxX, maxY := e.maxScroll()
+	e.horizontal.clamp(maxX)
+	e.vertical.clamp(maxY)
+}
+
+// ScrollTo eases content to x, y
+func (e *Element) ScrollTo(x float64, y float64) {
+	maxX, maxY := e.maxScroll()
+	e.horizontal.snapTo(math.Max(0, math.Min(maxX, x)))
+	e.vertical.snapTo(math.Max(0, math.Min(maxY, y)))
+}
+
+// ScrollToElement eases content the least needed to show a child element
+func (e *Element) ScrollToElement(name string) error {
+	c, err := e.Element(name)
+	if err != nil {
+		return err
+	}
+	p, ok := c.(element.Positioner)
+	if !ok {
+		return common.ErrElementNoBounds
+	}
+	s, ok := c.(element.Sizer)
+	if !ok {
+		return common.ErrElementNoBounds
+	}
+	cx, cy := p.Position()
+	w, h := e.viewSize()
+	x := scrollToShow(e.horizontal.scroll, w, cx, float64(s.Width()))
+	y := scrollToShow(e.vertical.scroll, h, cy, float64(s.Height()))
+	e.ScrollTo(x, y)
+	return nil
+}
+
+// scrollToShow returns the scroll nearest to scroll that shows from pos to pos+size in a view of length
+func scrollToShow(scroll float64, length float64, pos float64, size float64) float64 {
+	if pos+size > scroll+length {
+		scroll = pos + size - length
+	}
+	if pos < scroll {
+		scroll = pos
+	}
+	return scroll
+}
+
+// SetSnapPoints sets content positions scrolling comes to rest at along an axis, e.g. the top of each page.
+// No points scrolls freely
+func (e *Element) SetSnapPoints(orientation common.Orientation, points ...float64) {
+	sorted := make([]float64, len(points))
+	copy(sorted, points)
+	sort.Float64s(sorted)
+	if orientation == common.Vertical {
+		e.vertical.snaps = sorted
+		return
+	}
+	e.horizontal.snaps = sorted
+}
+
+// Friction returns how quickly a fling slows, per second
+func (e *Element) Friction() float64 {
+	return e.friction
+}
+
+// SetFriction sets how quickly a fling slows, per second. Higher stops sooner
+func (e *Element) SetFriction(friction float64) {
+	e.friction = friction
+}
+
+// SetBarSize sets the thickness of scrollbars
+func (e *Element) SetBarSize(barSize int) {
+	e.barSize = barSize
+}
+
+// KeyRepeat returns the repeat timing of held keys
+func (e *Element) KeyRepeat() *common.KeyRepeat {
+	return e.keyRepeat
+}
+
+// IsFocused returns true if keys scroll the view
+func (e *Element) IsFocused() bool {
+	return e.isFocused
+}
+
+// SetIsFocused sets if keys scroll the view. Clicking the view focuses it
+func (e *Element) SetIsFocused(isFocused bool) {
+	e.isFocused = isFocused
+	e.keyRepeat.Reset()
+}
+
+// SetOnScroll sets a function called each update scrolling moves
+func (e *Element) SetOnScroll(f func(e *Element, x float64, y float64)) {
+	e.onScroll = f
+}
+
+// SetOnPressFunction lets you pass a function without the need of element handling, called when the view is tapped without dragging
+func (e *Element) SetOnPressFunction(f func()) {
+	e.onPressFunction = f
+}
+
+// SetText is not used by scroll views
+func (e *Element) SetText(text string) {
+}
+
+// IsDestroyed returns true when the element is flagged for deletion
+func (e *Element) IsDestroyed() bool {
+	return e.isDestroyed
+}
+
+// LerpPosition changes an element's position over duration
+func (e *Element) LerpPosition(endPositionX, endPositionY float64, duration time.Duration, isDestroyed bool, endFunc func()) {
+	e.lerpPosition.Init(e.x, e.y, endPositionX, endPositionY, duration, true, endFunc, isDestroyed)
+}
+
+// Position returns an element's position
+func (e *Element) Position() (float64, float64) {
+	return e.x, e.y
+}
+
+// SetPosition sets an element's position
+func (e *Element) SetPosition(x float64, y float64) {
+	e.x = x
+	e.y = y
+}
+
+// Width returns an element's width
+func (e *Element) Width() int {
+	return e.width
+}
+
+// SetWidth sets an element's width
+func (e *Element) SetWidth(width int) {
+	e.width = width
+}
+
+// Height returns an element's height
+func (e *Element) Height() int {
+	return e.height
+}
+
+// SetHeight sets an element's height
+func (e *Element) SetHeight(height int) {
+	e.height = height
+}
+
+// SetIsDestroyed sets an element and its children to be destroyed on next update
+func (e *Element) SetIsDestroyed(isDestroyed bool) {
+	e.isDestroyed = true
+	for _, c := range e.elements {
+		c.SetIsDestroyed(true)
+	}
+	if e.view != nil {
+		e.view.Dispose()
+		e.view = nil
+	}
+}
@@ -0,0 +1,156 @@
+package scrollview
+
+import (
+	"testing"
+
+	"github.com/xackery/egui/common"
+	"github.com/xackery/egui/element/progress"
+)
+
+// newTestView returns a 100x50 view with 8 pixel scrollbars if thumbSliceName is set
+func newTestView(t *testing.T, thumbSliceName string) *Element {
+	e, err := New("view", "scene", 0, 0, 100, 50, nil, "", thumbSliceName)
+	if err != nil {
+		t.Fatalf("new: %v", err)
+	}
+	return e
+}
+
+func addTestChild(t *testing.T, e *Element, name string, x float64, y float64, width int, height int) {
+	c, err := progress.New(name, "scene", "", x, y, width, height, nil, nil, nil, "", "")
+	if err != nil {
+		t.Fatalf("new child: %v", err)
+	}
+	if err := e.AddElement(c); err != nil {
+		t.Fatalf("add child: %v", err)
+	}
+}
+
+func TestContentSize(t *testing.T) {
+	e := newTestView(t, "")
+	addTestChild(t, e, "a", 10.5, 20, 40, 20)
+	addTestChild(t, e, "b", 0, 0, 30, 10)
+	if w, h := e.ContentSize(); w != 51 || h != 40 {
+		t.Errorf("measured %d, %d, want 51, 40", w, h)
+	}
+	e.SetContentSize(0, 300)
+	if w, h := e.ContentSize(); w != 51 || h != 300 {
+		t.Errorf("height set %d, %d, want 51, 300", w, h)
+	}
+}
+
+func TestBars(t *testing.T) {
+	tests := []struct {
+		name           string
+		thumbSliceName string
+		contentWidth   int
+		contentHeight  int
+		isHorizontal   bool
+		isVertical     bool
+		maxX           float64
+		maxY           float64
+	}{
+		{"fits", "thumb", 100, 50, false, false, 0, 0},
+		{"hidden bars", "", 300, 200, false, false, 200, 150},
+		{"both", "thumb", 300, 200, true, true, 208, 158},
+		{"vertical only", "thumb", 90, 200, false, true, 0, 150},
+		// the vertical bar narrows the view below the content width
+		{"vertical needs horizontal", "thumb", 95, 200, true, true, 3, 158},
+	}
+	for _, tt := range tests {
+		e := newTestView(t, tt.thumbSliceName)
+		e.SetContentSize(tt.contentWidth, tt.contentHeight)
+		isHorizontal, isVertical := e.hasBars()
+		if isHorizontal != tt.isHorizontal || isVertical != tt.isVertical {
+			t.Errorf("%s: bars %v %v, want %v %v", tt.name, isHorizontal, isVertical, tt.isHorizontal, tt.isVertical)
+		}
+		maxX, maxY := e.maxScroll()
+		if maxX != tt.maxX || maxY != tt.maxY {
+			t.Errorf("%s: max scroll %v, %v, want %v, %v", tt.name, maxX, maxY, tt.maxX, tt.maxY)
+		}
+	}
+}
+
+func TestSetScroll(t *testing.T) {
+	tests := []struct {
+		name string
+		x    float64
+		y    float64
+		want [2]float64
+	}{
+		{"within", 20, 30, [2]float64{20, 30}},
+		{"past the end", 500, 500, [2]float64{200, 150}},
+		{"negative", -5, -5, [2]float64{0, 0}},
+	}
+	for _, tt := range tests {
+		e := newTestView(t, "")
+		e.SetContentSize(300, 200)
+		e.vertical.velocity = 100
+		e.SetScroll(tt.x, tt.y)
+		x, y := e.Scroll()
+		if x != tt.want[0] || y != tt.want[1] {
+			t.Errorf("%s: scroll %v, %v, want %v", tt.name, x, y, tt.want)
+		}
+		if e.vertical.velocity != 0 {
+			t.Errorf("%s: velocity %v, want inertia stopped", tt.name, e.vertical.velocity)
+		}
+	}
+}
+
+func TestScrollTo(t *testing.T) {
+	e := newTestView(t, "")
+	e.SetContentSize(300, 200)
+	e.ScrollTo(500, 40)
+	if e.horizontal.snapTarget != 200 || e.vertical.snapTarget != 40 {
+		t.Errorf("easing to %v, %v, want 200, 40", e.horizontal.snapTarget, e.vertical.snapTarget)
+	}
+}
+
+func TestScrollToElement(t *testing.T) {
+	tests := []struct {
+		name    string
+		x       float64
+		y       float64
+		scrollY float64
+		want    [2]float64
+	}{
+		{"shown", 0, 10, 0, [2]float64{0, 0}},
+		{"below", 0, 120, 0, [2]float64{0, 90}},
+		{"above", 0, 10, 90, [2]float64{0, 10}},
+		{"right", 150, 0, 0, [2]float64{90, 0}},
+	}
+	for _, tt := range tests {
+		e := newTestView(t, "")
+		e.SetContentSize(300, 200)
+		addTestChild(t, e, "child", tt.x, tt.y, 40, 20)
+		e.SetScroll(0, tt.scrollY)
+		if err := e.ScrollToElement("child"); err != nil {
+			t.Fatalf("%s: %v", tt.name, err)
+		}
+		if e.horizontal.snapTarget != tt.want[0] || e.vertical.snapTarget != tt.want[1] {
+			t.Errorf("%s: easing to %v, %v, want %v", tt.name, e.horizontal.snapTarget, e.vertical.snapTarget, tt.want)
+		}
+	}
+
+	e := newTestView(t, "")
+	if err := e.ScrollToElement("missing"); err == nil {
+		t.Errorf("missing child: got nil error")
+	}
+}
+
+func TestSetSnapPoints(t *testing.T) {
+	e := newTestView(t, "")
+	e.SetContentSize(100, 300)
+	points := []float64{200, 0, 100}
+	e.SetSnapPoints(common.Vertical, points...)
+	e.vertical.scrollBy(1)
+	if e.vertical.snapTarget != 100 {
+		t.Errorf("snap target %v, want 100", e.vertical.snapTarget)
+	}
+	if points[0] != 200 {
+		t.Errorf("snap points sorted the caller's slice")
+	}
+	if len(e.horizontal.snaps) != 0 {
+		t.Errorf("horizontal snaps %v, want none", e.horizontal.snaps)
+	}
+}
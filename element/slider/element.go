@@ -0,0 +1,591 @@
+package slider
+
+import (
+	"image/color"
+	"math"
+	"time"
+
+	"github.com/hajimehoshi/ebiten"
+	"github.com/hajimehoshi/ebiten/inpututil"
+	"github.com/xackery/egui/common"
+)
+
+// gamepadDeadZone is how far a gamepad stick must move to step the value
+const gamepadDeadZone = 0.5
+
+// thumb identifies a slider thumb
+type thumb int
+
+const (
+	thumbNone = thumb(iota)
+	thumbLow
+	thumbHigh
+)
+
+// Element represents a draggable 9slice slider.
+// A element has one thumb, or two in range mode
+// A element can register events on change, continuously or on release
+type Element struct {
+	name            string
+	image           *common.Image
+	x               float64
+	y               float64
+	scale           float64
+	width           int
+	height          int
+	orientation     common.Orientation
+	min             float64
+	max             float64
+	step            float64
+	low             float64
+	high            float64
+	isRange         bool
+	isContinuous    bool
+	isEnabled       bool
+	isVisible       bool
+	isFocused       bool
+	renderIndex     int64
+	isDestroyed     bool
+	lerpPosition    *common.LerpPosition
+	trackSliceName  string
+	fillSliceName   string
+	thumbSliceName  string
+	fillColor       ebiten.ColorM
+	keyRepeat       *common.KeyRepeat
+	dragging        thumb
+	focusedThumb    thumb
+	isChanged       bool
+	onChange        func(e *Element)
+	onPressFunction func()
+}
+
+// New creates a new slider instance from 0 to 1. Thumbs are square, as tall as a horizontal slider is high
+func New(name string, scene string, x float64, y float64, width int, height int, orientation common.Orientation, img *common.Image, trackSliceName string, fillSliceName string, thumbSliceName string) (*Element, error) {
+
+	e := &Element{
+		name:           name,
+		image:          img,
+		x:              x,
+		y:              y,
+		scale:          1,
+		width:          width,
+		height:         height,
+		orientation:    orientation,
+		max:            1,
+		isContinuous:   true,
+		isEnabled:      true,
+		isVisible:      true,
+		lerpPosition:   new(common.LerpPosition),
+		trackSliceName: trackSliceName,
+		fillSliceName:  fillSliceName,
+		thumbSliceName: thumbSliceName,
+		keyRepeat:      common.NewKeyRepeat(),
+		focusedThumb:   thumbLow,
+	}
+	return e, nil
+}
+
+// Name returns a element's name
+func (e *Element) Name() string {
+	return e.name
+}
+
+// IsVisible returns true if element is visible
+func (e *Element) IsVisible() bool {
+	return e.isVisible
+}
+
+// IsEnabled returns true if a element is enabled
+func (e *Element) IsEnabled() bool {
+	return e.isEnabled
+}
+
+// SetEnabled changes if a element is enabled. Disabled elements lose focus
+func (e *Element) SetEnabled(isEnabled bool) {
+	e.isEnabled = isEnabled
+	if !isEnabled {
+		e.SetIsFocused(false)
+	}
+}
+
+// SetVisible changes the visibility of a element
+func (e *Element) SetVisible(isVisible bool) {
+	e.isVisible = isVisible
+}
+
+// RenderIndex returns the render index of element
+func (e *Element) RenderIndex() int64 {
+	return e.renderIndex
+}
+
+// SetRenderIndex sets the render index of element
+func (e *Element) SetRenderIndex(renderIndex int64) {
+	e.renderIndex = renderIndex
+}
+
+// Update is called during a game update
+func (e *Element) Update(dt float64) {
+
+	if e.lerpPosition.IsEnabled() {
+		e.x, e.y = e.lerpPosition.Lerp(dt)
+		if !e.lerpPosition.IsEnabled() {
+			if e.lerpPosition.EndFunc() != nil {
+				e.lerpPosition.EndFunc()()
+			}
+			if e.lerpPosition.IsDestroyed() {
+				e.isDestroyed = true
+				return
+			}
+		}
+	}
+
+	if !e.isEnabled || !e.isVisible {
+		return
+	}
+
+	e.updatePointer()
+	if e.isFocused {
+		e.updateKeys(dt)
+	}
+}
+
+// updatePointer grabs the nearest thumb on press, jumping it to the pointer, and drags it until release
+func (e *Element) updatePointer() {
+	x, y, ok := common.PointerJustPressed()
+	if ok {
+		if !e.contains(x, y) {
+			e.SetIsFocused(false)
+			return
+		}
+		e.isFocused = true
+		v := e.valueAt(x, y)
+		e.dragging = thumbLow
+		if e.isRange && (v > e.high || math.Abs(v-e.high) < math.Abs(v-e.low)) {
+			e.dragging = thumbHigh
+		}
+		e.focusedThumb = e.dragging
+		e.setThumb(e.dragging, v)
+		return
+	}
+	if e.dragging == thumbNone {
+		return
+	}
+	x, y, ok = common.PointerPressed()
+	if !ok {
+		e.dragging = thumbNone
+		e.release()
+		return
+	}
+	e.setThumb(e.dragging, e.valueAt(x, y))
+}
+
+// updateKeys steps the focused thumb with arrows, page keys, home and end, or a gamepad stick.
+// Tab switches thumbs in range mode
+func (e *Element) updateKeys(dt float64) {
+	steps := 0
+	increase, decrease := ebiten.KeyRight, ebiten.KeyLeft
+	axis := 0
+	if e.orientation == common.Vertical {
+		increase, decrease = ebiten.KeyUp, ebiten.KeyDown
+		axis = 1
+	}
+	steps += e.keyRepeat.Count(increase, dt)
+	steps -= e.keyRepeat.Count(decrease, dt)
+	steps += 10 * e.keyRepeat.Count(ebiten.KeyPageUp, dt)
+	steps -= 10 * e.keyRepeat.Count(ebiten.KeyPageDown, dt)
+	for _, id := range common.GamepadIDs() {
+		v := ebiten.GamepadAxis(id, axis)
+		if axis == 1 {
+			// sticks point down for positive y
+			v = -v
+		}
+		steps += e.keyRepeat.CountHeld([2]int{id, 1}, v > gamepadDeadZone, dt)
+		steps -= e.keyRepeat.CountHeld([2]int{id, -1}, v < -gamepadDeadZone, dt)
+	}
+
+	if e.isRange && common.IsKeyJustPressed(ebiten.KeyTab) {
+		e.focusedThumb = thumbHigh + thumbLow - e.focusedThumb
+	}
+
+	value := e.thumbValue(e.focusedThumb)
+	switch {
+	case common.IsKeyJustPressed(ebiten.KeyHome):
+		value = e.min
+	case common.IsKeyJustPressed(ebiten.KeyEnd):
+		value = e.max
+	case steps != 0:
+		value += float64(steps) * e.keyStep()
+	default:
+		if inpututil.IsKeyJustReleased(increase) || inpututil.IsKeyJustReleased(decrease) ||
+			inpututil.IsKeyJustReleased(ebiten.KeyPageUp) || inpututil.IsKeyJustReleased(ebiten.KeyPageDown) {
+			e.release()
+		}
+		return
+	}
+	e.setThumb(e.focusedThumb, value)
+	if common.IsKeyJustPressed(ebiten.KeyHome) || common.IsKeyJustPressed(ebiten.KeyEnd) {
+		e.release()
+	}
+}
+
+// keyStep returns how far a key press moves a thumb, the step or a hundredth of the range
+func (e *Element) keyStep() float64 {
+	if e.step > 0 {
+		return e.step
+	}
+	return (e.max - e.min) / 100
+}
+
+// release calls the change function after dragging or holding keys if it only fires on release
+func (e *Element) release() {
+	if !e.isChanged {
+		return
+	}
+	e.isChanged = false
+	if e.isContinuous {
+		return
+	}
+	e.changed()
+}
+
+// changed calls the change functions
+func (e *Element) changed() {
+	if e.onChange != nil {
+		e.onChange(e)
+	}
+	if e.onPressFunction != nil {
+		e.onPressFunction()
+	}
+}
+
+// contains returns true if x, y is inside the slider
+func (e *Element) contains(x float64, y float64) bool {
+	return e.x <= x && x < e.x+float64(e.width)*e.scale && e.y <= y && y < e.y+float64(e.height)*e.scale
+}
+
+// thumbSize returns the side of the square thumbs
+func (e *Element) thumbSize() float64 {
+	if e.orientation == common.Vertical {
+		return float64(e.width) * e.scale
+	}
+	return float64(e.height) * e.scale
+}
+
+// travel returns how far thumb centers move from min to max
+func (e *Element) travel() float64 {
+	length := float64(e.width) * e.scale
+	if e.orientation == common.Vertical {
+		length = float64(e.height) * e.scale
+	}
+	t := length - e.thumbSize()
+	if t < 1 {
+		t = 1
+	}
+	return t
+}
+
+// fraction returns where value lies from min 0 to max 1
+func (e *Element) fraction(value float64) float64 {
+	if e.max == e.min {
+		return 0
+	}
+	return (value - e.min) / (e.max - e.min)
+}
+
+// offset returns the distance from the start of the track to the center of a thumb at value.
+// Vertical tracks start at the bottom
+func (e *Element) offset(value float64) float64 {
+	return e.thumbSize()/2 + e.fraction(value)*e.travel()
+}
+
+// valueAt returns the value at screen x, y
+func (e *Element) valueAt(x float64, y float64) float64 {
+	d := x - e.x
+	if e.orientation == common.Vertical {
+		d = e.y + float64(e.height)*e.scale - y
+	}
+	f := (d - e.thumbSize()/2) / e.travel()
+	return e.min + f*(e.max-e.min)
+}
+
+// snap rounds value to a step from min, kept within min and max
+func (e *Element) snap(value float64) float64 {
+	if e.step > 0 {
+		value = e.min + math.Round((value-e.min)/e.step)*e.step
+	}
+	lo, hi := e.min, e.max
+	if lo > hi {
+		lo, hi = hi, lo
+	}
+	return math.Max(lo, math.Min(hi, value))
+}
+
+// thumbValue returns the value of a thumb
+func (e *Element) thumbValue(t thumb) float64 {
+	if t == thumbHigh {
+		return e.high
+	}
+	return e.low
+}
+
+// setThumb moves a thumb to value, keeping the range thumbs in order, and calls the change function
+// if continuous. Otherwise it is called on release
+func (e *Element) setThumb(t thumb, value float64) {
+	value = e.snap(value)
+	if t == thumbHigh {
+		value = math.Max(value, e.low)
+		if value == e.high {
+			return
+		}
+		e.high = value
+	} else {
+		if e.isRange {
+			value = math.Min(value, e.high)
+		}
+		if value == e.low {
+			return
+		}
+		e.low = value
+	}
+	e.isChanged = true
+	if e.isContinuous {
+		e.changed()
+	}
+}
+
+// Draw is called during a game update
+func (e *Element) Draw(dst *ebiten.Image) {
+	if !e.isVisible {
+		return
+	}
+
+	op := &ebiten.DrawImageOptions{}
+	op.GeoM.Translate(e.x, e.y)
+	op.GeoM.Scale(e.scale, e.scale)
+
+	if !e.isEnabled {
+		op.ColorM.ChangeHSV(0, 0, 1)
+		op.ColorM.Scale(0.5, 0.5, 0.5, 1)
+	}
+
+	slice, err := e.image.Slice(e.trackSliceName)
+	if err == nil {
+		common.DrawNineSlicing(dst, e.image.EbitenImage, slice.Keys[0], e.width, e.height, &op.GeoM, &op.ColorM)
+	}
+
+	length, thickness := e.width, e.height
+	if e.orientation == common.Vertical {
+		length, thickness = e.height, e.width
+	}
+	start := 0.0
+	end := e.offset(e.low)
+	if e.isRange {
+		start, end = end, e.offset(e.high)
+	}
+
+	slice, err = e.image.Slice(e.fillSliceName)
+	if err == nil && end > start {
+		// fill is drawn along the track from start, rotated to run bottom up when vertical
+		geoM := ebiten.GeoM{}
+		geoM.Translate(start, 0)
+		if e.orientation == common.Vertical {
+			geoM.Rotate(-math.Pi / 2)
+			geoM.Translate(e.x, e.y+float64(e.height)*e.scale)
+		} else {
+			geoM.Translate(e.x, e.y)
+		}
+		colorM := e.fillColor
+		colorM.Concat(op.ColorM)
+		value := (end - start) / (float64(length) * e.scale)
+		common.DrawNineSlicingProgress(dst, e.image.EbitenImage, slice.Keys[0], length, thickness, value, &geoM, &colorM)
+	}
+
+	slice, err = e.image.Slice(e.thumbSliceName)
+	if err != nil {
+		return
+	}
+	thumbs := []float64{e.low}
+	if e.isRange {
+		thumbs = append(thumbs, e.high)
+	}
+	size := e.thumbSize()
+	for _, v := range thumbs {
+		top := ebiten.GeoM{}
+		if e.orientation == common.Vertical {
+			top.Translate(e.x, e.y+float64(e.height)*e.scale-e.offset(v)-size/2)
+		} else {
+			top.Translate(e.x+e.offset(v)-size/2, e.y)
+		}
+		common.DrawNineSlicing(dst, e.image.EbitenImage, slice.Keys[0], int(size), int(size), &top, &op.ColorM)
+	}
+}
+
+// SetText is not used by sliders
+func (e *Element) SetText(text string) {
+}
+
+// Value returns the value of the thumb, or the low value in range mode
+func (e *Element) Value() float64 {
+	return e.low
+}
+
+// SetValue moves the thumb to value, or the low thumb in range mode, without calling the change function
+func (e *Element) SetValue(value float64) {
+	e.low = e.snap(value)
+	if e.isRange {
+		e.low = math.Min(e.low, e.high)
+	}
+}
+
+// Range returns the low and high values of range mode
+func (e *Element) Range() (float64, float64) {
+	return e.low, e.high
+}
+
+// SetRange moves the range thumbs to low and high, without calling the change function
+func (e *Element) SetRange(low float64, high float64) {
+	if low > high {
+		low, high = high, low
+	}
+	e.low = e.snap(low)
+	e.high = math.Max(e.low, e.snap(high))
+}
+
+// Bounds returns the min and max values
+func (e *Element) Bounds() (float64, float64) {
+	return e.min, e.max
+}
+
+// SetBounds sets the min and max values, moving thumbs inside them
+func (e *Element) SetBounds(min float64, max float64) {
+	e.min = min
+	e.max = max
+	e.low = e.snap(e.low)
+	e.high = math.Max(e.low, e.snap(e.high))
+}
+
+// Step returns the increment values snap to, 0 is continuous
+func (e *Element) Step() float64 {
+	return e.step
+}
+
+// SetStep sets the increment values snap to, 0 is continuous
+func (e *Element) SetStep(step float64) {
+	e.step = step
+	e.SetBounds(e.min, e.max)
+}
+
+// IsRange returns true if the slider has a low and high thumb
+func (e *Element) IsRange() bool {
+	return e.isRange
+}
+
+// SetIsRange sets if the slider has a low and high thumb. The high thumb starts at max
+func (e *Element) SetIsRange(isRange bool) {
+	e.isRange = isRange
+	e.high = e.max
+	if !isRange {
+		e.focusedThumb = thumbLow
+	}
+}
+
+// IsContinuous returns true if the change function is called while dragging
+func (e *Element) IsContinuous() bool {
+	return e.isContinuous
+}
+
+// SetIsContinuous sets if the change function is called while dragging, on by default,
+// or only when the thumb is released
+func (e *Element) SetIsContinuous(isContinuous bool) {
+	e.isContinuous = isContinuous
+}
+
+// Orientation returns the axis of the slider
+func (e *Element) Orientation() common.Orientation {
+	return e.orientation
+}
+
+// SetFillColor tints the filled track
+func (e *Element) SetFillColor(clr color.Color) {
+	e.fillColor.Reset()
+	e.fillColor.Scale(common.ColorToScale(clr))
+}
+
+// KeyRepeat returns the repeat timing of held keys and gamepad sticks
+func (e *Element) KeyRepeat() *common.KeyRepeat {
+	return e.keyRepeat
+}
+
+// IsFocused returns true if keys and gamepads move the slider
+func (e *Element) IsFocused() bool {
+	return e.isFocused
+}
+
+// SetIsFocused sets if keys and gamepads move the slider. Clicking the slider focuses it
+func (e *Element) SetIsFocused(isFocused bool) {
+	if e.isFocused == isFocused {
+		return
+	}
+	e.isFocused = isFocused
+	e.keyRepeat.Reset()
+	if !isFocused {
+		e.dragging = thumbNone
+		e.release()
+	}
+}
+
+// SetOnChange sets a function called when a value changes. Read Value, or Range in range mode
+func (e *Element) SetOnChange(f func(e *Element)) {
+	e.onChange = f
+}
+
+// SetOnPressFunction lets you pass a function without the need of element handling, called when a value changes
+func (e *Element) SetOnPressFunction(f func()) {
+	e.onPressFunction = f
+}
+
+// IsDestroyed returns true when the element is flagged for deletion
+func (e *Element) IsDestroyed() bool {
+	return e.isDestroyed
+}
+
+// LerpPosition changes an element's position over duration
+func (e *Element) LerpPosition(endPositionX, endPositionY float64, duration time.Duration, isDestroyed bool, endFunc func()) {
+	e.lerpPosition.Init(e.x, e.y, endPositionX, endPositionY, duration, true, endFunc, isDestroyed)
+}
+
+// Position returns an element's position
+func (e *Element) Position() (float64, float64) {
+	return e.x, e.y
+}
+
+// SetPosition sets an element's position
+func (e *Element) SetPosition(x float64, y float64) {
+	e.x = x
+	e.y = y
+}
+
+// Width returns an element's width
+func (e *Element) Width() int {
+	return e.width
+}
+
+// SetWidth sets an element's width
+func (e *Element) SetWidth(width int) {
+	e.width = width
+}
+
+// Height returns an element's height
+func (e *Element) Height() int {
+	return e.height
+}
+
+// SetHeight sets an element's height
+func (e *Element) SetHeight(height int) {
+	e.height = height
+}
+
+// SetIsDestroyed sets an element to be destroyed on next update
+func (e *Element) SetIsDestroyed(isDestroyed bool) {
+	e.isDestroyed = true
+}
@@ -0,0 +1,194 @@
+package slider
+
+import (
+	"testing"
+
+	"github.com/xackery/egui/common"
+)
+
+// newTestSlider returns a slider with a 10 pixel thumb traveling 100 pixels
+func newTestSlider(t *testing.T, orientation common.Orientation) *Element {
+	w, h := 110, 10
+	if orientation == common.Vertical {
+		w, h = h, w
+	}
+	e, err := New("slider", "scene", 0, 0, w, h, orientation, nil, "", "", "")
+	if err != nil {
+		t.Fatalf("new: %v", err)
+	}
+	return e
+}
+
+func TestSnap(t *testing.T) {
+	tests := []struct {
+		name  string
+		min   float64
+		max   float64
+		step  float64
+		value float64
+		want  float64
+	}{
+		{"continuous", 0, 1, 0, 0.375, 0.375},
+		{"below min", 0, 1, 0, -1, 0},
+		{"above max", 0, 1, 0, 2, 1},
+		{"rounds up to a step", 0, 10, 2, 3, 4},
+		{"rounds down to a step", 0, 10, 2, 4.75, 4},
+		{"steps count from min", 1, 10, 3, 5, 4},
+		{"last step past max", 1, 10, 3, 9, 10},
+		{"reversed bounds", 10, 0, 0, 20, 10},
+		{"reversed bounds below", 10, 0, 0, -5, 0},
+	}
+	for _, tt := range tests {
+		e := newTestSlider(t, common.Horizontal)
+		e.SetBounds(tt.min, tt.max)
+		e.SetStep(tt.step)
+		e.SetValue(tt.value)
+		if e.Value() != tt.want {
+			t.Errorf("%s: value %v, want %v", tt.name, e.Value(), tt.want)
+		}
+	}
+}
+
+func TestSetRange(t *testing.T) {
+	tests := []struct {
+		name string
+		step float64
+		low  float64
+		high float64
+		want [2]float64
+	}{
+		{"in order", 0, 0.25, 0.75, [2]float64{0.25, 0.75}},
+		{"swapped", 0, 0.75, 0.25, [2]float64{0.25, 0.75}},
+		{"clamped", 0, -1, 2, [2]float64{0, 1}},
+		{"snapped", 0.5, 0.25, 0.75, [2]float64{0.5, 1}},
+		{"snapped together", 0.5, 0.375, 0.5, [2]float64{0.5, 0.5}},
+	}
+	for _, tt := range tests {
+		e := newTestSlider(t, common.Horizontal)
+		e.SetIsRange(true)
+		e.SetStep(tt.step)
+		e.SetRange(tt.low, tt.high)
+		low, high := e.Range()
+		if low != tt.want[0] || high != tt.want[1] {
+			t.Errorf("%s: range %v, %v, want %v", tt.name, low, high, tt.want)
+		}
+	}
+}
+
+func TestSetBounds(t *testing.T) {
+	tests := []struct {
+		name string
+		low  float64
+		high float64
+		min  float64
+		max  float64
+		want [2]float64
+	}{
+		{"inside", 0.25, 0.75, 0, 1, [2]float64{0.25, 0.75}},
+		{"min moves thumbs up", 0.25, 0.75, 0.5, 2, [2]float64{0.5, 0.75}},
+		{"max moves thumbs down", 0.25, 0.75, -1, 0.5, [2]float64{0.25, 0.5}},
+		{"both past max", 0.25, 0.75, -1, 0, [2]float64{0, 0}},
+	}
+	for _, tt := range tests {
+		e := newTestSlider(t, common.Horizontal)
+		e.SetIsRange(true)
+		e.SetRange(tt.low, tt.high)
+		e.SetBounds(tt.min, tt.max)
+		low, high := e.Range()
+		if low != tt.want[0] || high != tt.want[1] {
+			t.Errorf("%s: range %v, %v, want %v", tt.name, low, high, tt.want)
+		}
+		min, max := e.Bounds()
+		if min != tt.min || max != tt.max {
+			t.Errorf("%s: bounds %v, %v, want %v, %v", tt.name, min, max, tt.min, tt.max)
+		}
+	}
+}
+
+func TestSetValueInRange(t *testing.T) {
+	e := newTestSlider(t, common.Horizontal)
+	e.SetIsRange(true)
+	e.SetRange(0.25, 0.5)
+	e.SetValue(0.75)
+	if low, high := e.Range(); low != 0.5 || high != 0.5 {
+		t.Errorf("range %v, %v, want low kept at high 0.5", low, high)
+	}
+}
+
+func TestSetThumb(t *testing.T) {
+	tests := []struct {
+		name         string
+		isContinuous bool
+		thumb        thumb
+		values       []float64
+		want         [2]float64
+		changes      int
+		released     int
+	}{
+		{"continuous", true, thumbLow, []float64{0.25, 0.25, 0.5}, [2]float64{0.5, 0.75}, 2, 2},
+		{"on release", false, thumbLow, []float64{0.25, 0.5}, [2]float64{0.5, 0.75}, 0, 1},
+		{"low stops at high", true, thumbLow, []float64{1}, [2]float64{0.75, 0.75}, 1, 1},
+		{"high stops at low", true, thumbHigh, []float64{0}, [2]float64{0, 0}, 1, 1},
+	}
+	for _, tt := range tests {
+		e := newTestSlider(t, common.Horizontal)
+		e.SetIsRange(true)
+		e.SetRange(0, 0.75)
+		e.SetIsContinuous(tt.isContinuous)
+		changes := 0
+		e.SetOnChange(func(e *Element) {
+			changes++
+		})
+		for _, v := range tt.values {
+			e.setThumb(tt.thumb, v)
+		}
+		if changes != tt.changes {
+			t.Errorf("%s: %d changes while dragging, want %d", tt.name, changes, tt.changes)
+		}
+		e.release()
+		if changes != tt.released {
+			t.Errorf("%s: %d changes after release, want %d", tt.name, changes, tt.released)
+		}
+		low, high := e.Range()
+		if low != tt.want[0] || high != tt.want[1] {
+			t.Errorf("%s: range %v, %v, want %v", tt.name, low, high, tt.want)
+		}
+	}
+}
+
+func TestValueAt(t *testing.T) {
+	tests := []struct {
+		name        string
+		orientation common.Orientation
+		x           float64
+		y           float64
+		want        float64
+	}{
+		{"horizontal start", common.Horizontal, 5, 5, 0},
+		{"horizontal middle", common.Horizontal, 55, 5, 0.5},
+		{"horizontal end", common.Horizontal, 105, 5, 1},
+		{"vertical starts at the bottom", common.Vertical, 5, 105, 0},
+		{"vertical end", common.Vertical, 5, 5, 1},
+	}
+	for _, tt := range tests {
+		e := newTestSlider(t, tt.orientation)
+		if got := e.valueAt(tt.x, tt.y); got != tt.want {
+			t.Errorf("%s: value %v, want %v", tt.name, got, tt.want)
+		}
+		if got := e.offset(tt.want); tt.orientation == common.Horizontal && got != tt.x {
+			t.Errorf("%s: offset %v, want %v", tt.name, got, tt.x)
+		}
+	}
+}
+
+func TestKeyStep(t *testing.T) {
+	e := newTestSlider(t, common.Horizontal)
+	e.SetBounds(0, 50)
+	if e.keyStep() != 0.5 {
+		t.Errorf("key step %v, want a hundredth of the range", e.keyStep())
+	}
+	e.SetStep(5)
+	if e.keyStep() != 5 {
+		t.Errorf("key step %v, want the step", e.keyStep())
+	}
+}
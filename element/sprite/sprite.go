@@ -97,10 +97,10 @@ func (e *Element) SetRenderIndex(renderIndex int64) {
 func (e *Element) Update(dt float64) {
 
 	if e.lerpPosition.IsEnabled() {
-		e.x, e.y = e.lerpPosition.Lerp()
+		e.x, e.y = e.lerpPosition.Lerp(dt)
 		if !e.lerpPosition.IsEnabled() {
 			if e.lerpPosition.EndFunc() != nil {
-				e.lerpPosition.EndFunc()
+				e.lerpPosition.EndFunc()()
 			}
 			if e.lerpPosition.IsDestroyed() {
 				e.isDestroyed = true
@@ -109,12 +109,16 @@ func (e *Element) Update(dt float64) {
 		}
 	}
 
+	if e.isAnimated && !e.isIdleAnimation {
+		// Speed is measured in ticks, keep it independent of frame rate
+		e.animation.Elapsed += dt
+		e.animation.Counter = int(e.animation.Elapsed * ebiten.DefaultTPS)
+	}
+
 	isRecentlyPressed := false
 	//mobile and desktop use differnet touch devices
 	for _, t := range inpututil.JustPressedTouchIDs() {
-		x, y := ebiten.TouchPosition(t)
-		fx := float64(x)
-		fy := float64(y)
+		fx, fy := common.TouchPosition(t)
 		if e.x <= fx && fx < e.x+float64(e.width) && e.y <= fy && fy < e.y+float64(e.height) {
 			e.isPressed = true
 			isRecentlyPressed = true
@@ -124,9 +128,7 @@ func (e *Element) Update(dt float64) {
 	}
 
 	if ebiten.IsMouseButtonPressed(ebiten.MouseButtonLeft) {
-		x, y := ebiten.CursorPosition()
-		fx := float64(x)
-		fy := float64(y)
+		fx, fy := common.CursorPosition()
 		if e.x <= fx && fx < e.x+float64(e.width) && e.y <= fy && fy < e.y+float64(e.height) {
 			e.isPressed = true
 			isRecentlyPressed = true
@@ -164,8 +166,6 @@ func (e *Element) Draw(screen *ebiten.Image) {
 
 	if e.isAnimated {
 		if !e.isIdleAnimation {
-			anim.Counter++
-
 			ai, ok := anim.Animations[fmt.Sprintf("%d_%s", anim.BundleIndex, anim.CurrentName)]
 			if !ok {
 				fmt.Println("anim not found")
@@ -228,7 +228,7 @@ func (e *Element) IsDestroyed() bool {
 
 // LerpPosition changes an element's position over duration
 func (e *Element) LerpPosition(endPositionX, endPositionY float64, duration time.Duration, isDestroyed bool, endFunc func()) {
-	e.lerpPosition.Init(time.Now(), e.x, e.y, endPositionX, endPositionY, duration, true, endFunc, isDestroyed)
+	e.lerpPosition.Init(e.x, e.y, endPositionX, endPositionY, duration, true, endFunc, isDestroyed)
 }
 
 // SetIsDestroyed sets an element to be destroyed on next update
@@ -302,6 +302,7 @@ func (e *Element) generateAnimation() {
 func (e *Element) SetAnimation(anim common.Animation) error {
 	e.animation = &common.Animation{
 		Counter:     anim.Counter,
+		Elapsed:     anim.Elapsed,
 		CurrentName: anim.CurrentName,
 		Speed:       anim.Speed,
 		BundleIndex: anim.BundleIndex,
@@ -395,3 +396,12 @@ func (e *Element) CellWidth() int {
 func (e *Element) CellHeight() int {
 	return int(e.animation.CellWidth)
 }
+
+// Pivot returns the feet of a sprite, the bottom center of the current animation cell
+func (e *Element) Pivot() (float64, float64) {
+	if !e.isAnimated {
+		w, h := e.image.EbitenImage.Size()
+		return e.x + float64(w)*e.scale/2, e.y + float64(h)*e.scale
+	}
+	return e.x + e.animation.CellWidth*e.scale/2, e.y + e.animation.CellHeight*e.scale
+}
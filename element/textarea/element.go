@@ -0,0 +1,841 @@
+package textarea
+
+import (
+	"image/color"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/hajimehoshi/ebiten"
+	"github.com/hajimehoshi/ebiten/ebitenutil"
+	"github.com/xackery/egui/common"
+)
+
+// caretBlinkInterval is how long the caret is shown, then hidden, in seconds
+const caretBlinkInterval = 0.5
+
+// line is a drawn line of text, from rune start to end, excluding the line break
+type line struct {
+	start int
+	end   int
+}
+
+// Element represents a multi line 9slice text area.
+// A element wraps and scrolls text, drawing only visible lines
+// A element can register events on change
+type Element struct {
+	name             string
+	image            *common.Image
+	sliceName        string
+	focusedSliceName string
+	x                float64
+	y                float64
+	scale            float64
+	width            int
+	height           int
+	padding          int
+	edit             *common.TextEdit
+	placeholder      string
+	placeholderColor color.Color
+	isEnabled        bool
+	isVisible        bool
+	isFocused        bool
+	isDragging       bool
+	isWrapped        bool
+	isReadOnly       bool
+	renderIndex      int64
+	isDestroyed      bool
+	lerpPosition     *common.LerpPosition
+	color            color.Color
+	selectionColor   color.Color
+	font             *common.Font
+	clipboard        common.Clipboard
+	keyRepeat        *common.KeyRepeat
+	blink            float64
+	scrollX          float64
+	scrollY          float64
+	// preferredX is the caret x kept while moving up and down, negative when unset
+	preferredX   float64
+	isCaretMoved bool
+	lines        []line
+	layoutRev    int
+	layoutWidth  int
+	layoutFont   *common.Font
+	// breaks caches wrap offsets of each line of text, so edits only wrap changed lines again
+	breaks   map[string][]int
+	canvas   *ebiten.Image
+	onChange func(e *Element, text string)
+}
+
+// New creates a new text area instance
+func New(name string, scene string, x float64, y float64, width int, height int, font *common.Font, textColor color.Color, img *common.Image, sliceName string, focusedSliceName string, clipboard common.Clipboard) (*Element, error) {
+	if clipboard == nil {
+		clipboard = &common.MemoryClipboard{}
+	}
+
+	e := &Element{
+		name:             name,
+		image:            img,
+		sliceName:        sliceName,
+		focusedSliceName: focusedSliceName,
+		x:                x,
+		y:                y,
+		scale:            1,
+		width:            width,
+		height:           height,
+		padding:          6,
+		edit:             common.NewTextEdit(""),
+		placeholderColor: color.RGBA{128, 128, 128, 255},
+		isEnabled:        true,
+		isVisible:        true,
+		isWrapped:        true,
+		lerpPosition:     new(common.LerpPosition),
+		color:            textColor,
+		selectionColor:   color.RGBA{51, 102, 204, 160},
+		font:             font,
+		clipboard:        clipboard,
+		keyRepeat:        common.NewKeyRepeat(),
+		preferredX:       -1,
+		layoutWidth:      -1,
+		breaks:           make(map[string][]int),
+	}
+	return e, nil
+}
+
+// Name returns a element's name
+func (e *Element) Name() string {
+	return e.name
+}
+
+// IsVisible returns true if element is visible
+func (e *Element) IsVisible() bool {
+	return e.isVisible
+}
+
+// IsEnabled returns true if a element is enabled
+func (e *Element) IsEnabled() bool {
+	return e.isEnabled
+}
+
+// SetEnabled changes if a element is enabled. Disabled areas lose focus
+func (e *Element) SetEnabled(isEnabled bool) {
+	e.isEnabled = isEnabled
+	if !isEnabled {
+		e.SetIsFocused(false)
+	}
+}
+
+// SetVisible changes the visibility of a element. Hidden areas lose focus
+func (e *Element) SetVisible(isVisible bool) {
+	e.isVisible = isVisible
+	if !isVisible {
+		e.SetIsFocused(false)
+	}
+}
+
+// RenderIndex returns the render index of element
+func (e *Element) RenderIndex() int64 {
+	return e.renderIndex
+}
+
+// SetRenderIndex sets the render index of element
+func (e *Element) SetRenderIndex(renderIndex int64) {
+	e.renderIndex = renderIndex
+}
+
+// Update is called during a game update
+func (e *Element) Update(dt float64) {
+
+	if e.lerpPosition.IsEnabled() {
+		e.x, e.y = e.lerpPosition.Lerp(dt)
+		if !e.lerpPosition.IsEnabled() {
+			if e.lerpPosition.EndFunc() != nil {
+				e.lerpPosition.EndFunc()()
+			}
+			if e.lerpPosition.IsDestroyed() {
+				e.isDestroyed = true
+				return
+			}
+		}
+	}
+
+	if !e.isEnabled || !e.isVisible {
+		return
+	}
+
+	e.updatePointer()
+	if e.isFocused {
+		e.blink += dt
+
+		revision := e.edit.Revision()
+		caret := e.edit.Caret()
+		e.updateKeys(dt)
+		if e.edit.Caret() != caret {
+			e.isCaretMoved = true
+		}
+		if e.edit.Revision() != revision {
+			e.isCaretMoved = true
+			if e.onChange != nil {
+				e.onChange(e, e.edit.Text())
+			}
+		}
+	}
+	if e.isCaretMoved {
+		e.scrollToCaret()
+		e.isCaretMoved = false
+	}
+}
+
+// updatePointer focuses the area and places the caret on click, selecting while dragging, and scrolls with the wheel
+func (e *Element) updatePointer() {
+	cx, cy := common.CursorPosition()
+	_, wheel := ebiten.Wheel()
+	if wheel != 0 && e.contains(cx, cy) {
+		e.scrollY -= wheel * float64(e.font.RenderingLineHeight) * 3
+		e.clampScroll()
+	}
+
+	x, y, ok := common.PointerJustPressed()
+	if ok {
+		if !e.contains(x, y) {
+			e.SetIsFocused(false)
+			return
+		}
+		e.SetIsFocused(true)
+		e.edit.SetCaret(e.runeAtPoint(x, y), common.IsKeyPressed(ebiten.KeyShift))
+		e.preferredX = -1
+		e.isDragging = true
+		e.blink = 0
+		return
+	}
+	if !e.isDragging {
+		return
+	}
+	x, y, ok = common.PointerPressed()
+	if !ok {
+		e.isDragging = false
+		return
+	}
+	e.edit.SetCaret(e.runeAtPoint(x, y), true)
+	e.isCaretMoved = true
+}
+
+// updateKeys applies typed characters and editing keys to the focused area
+func (e *Element) updateKeys(dt float64) {
+	isShift := common.IsKeyPressed(ebiten.KeyShift)
+	isControl := common.IsKeyPressed(ebiten.KeyControl)
+	isActive := false
+	isVertical := false
+
+	if !isControl && !e.isReadOnly {
+		var sb strings.Builder
+		for _, r := range common.InputChars() {
+			if r < 0x20 || r == 0x7f {
+				continue
+			}
+			sb.WriteRune(r)
+		}
+		for i := e.keyRepeat.Count(ebiten.KeyEnter, dt) + e.keyRepeat.Count(ebiten.KeyKPEnter, dt); i > 0; i-- {
+			sb.WriteRune('\n')
+		}
+		if sb.Len() > 0 {
+			e.edit.Insert(sb.String())
+			isActive = true
+		}
+	}
+
+	for i := e.keyRepeat.Count(ebiten.KeyLeft, dt); i > 0; i-- {
+		e.edit.MoveLeft(isShift, isControl)
+		isActive = true
+	}
+	for i := e.keyRepeat.Count(ebiten.KeyRight, dt); i > 0; i-- {
+		e.edit.MoveRight(isShift, isControl)
+		isActive = true
+	}
+	for i := e.keyRepeat.Count(ebiten.KeyUp, dt); i > 0; i-- {
+		e.moveLines(-1, isShift)
+		isActive = true
+		isVertical = true
+	}
+	for i := e.keyRepeat.Count(ebiten.KeyDown, dt); i > 0; i-- {
+		e.moveLines(1, isShift)
+		isActive = true
+		isVertical = true
+	}
+	for i := e.keyRepeat.Count(ebiten.KeyPageUp, dt); i > 0; i-- {
+		e.moveLines(-e.visibleLineCount(), isShift)
+		isActive = true
+		isVertical = true
+	}
+	for i := e.keyRepeat.Count(ebiten.KeyPageDown, dt); i > 0; i-- {
+		e.moveLines(e.visibleLineCount(), isShift)
+		isActive = true
+		isVertical = true
+	}
+	if !e.isReadOnly {
+		for i := e.keyRepeat.Count(ebiten.KeyBackspace, dt); i > 0; i-- {
+			e.edit.DeleteBackward(isControl)
+			isActive = true
+		}
+		for i := e.keyRepeat.Count(ebiten.KeyDelete, dt); i > 0; i-- {
+			e.edit.DeleteForward(isControl)
+			isActive = true
+		}
+	}
+
+	switch {
+	case common.IsKeyJustPressed(ebiten.KeyHome) && isControl:
+		e.edit.SetCaret(0, isShift)
+	case common.IsKeyJustPressed(ebiten.KeyEnd) && isControl:
+		e.edit.SetCaret(e.edit.Len(), isShift)
+	case common.IsKeyJustPressed(ebiten.KeyHome):
+		e.edit.SetCaret(e.lineStart(e.edit.Caret()), isShift)
+	case common.IsKeyJustPressed(ebiten.KeyEnd):
+		e.edit.SetCaret(e.lineEnd(e.edit.Caret()), isShift)
+	case common.IsShortcutPressed(ebiten.KeyA):
+		e.edit.SelectAll()
+	case common.IsShortcutPressed(ebiten.KeyC):
+		e.Copy()
+	case common.IsShortcutPressed(ebiten.KeyX):
+		e.Cut()
+	case common.IsShortcutPressed(ebiten.KeyV):
+		e.Paste()
+	case common.IsShortcutPressed(ebiten.KeyZ) && isShift, common.IsShortcutPressed(ebiten.KeyY):
+		if !e.isReadOnly {
+			e.edit.Redo()
+		}
+	case common.IsShortcutPressed(ebiten.KeyZ):
+		if !e.isReadOnly {
+			e.edit.Undo()
+		}
+	case common.IsKeyJustPressed(ebiten.KeyEscape):
+		e.SetIsFocused(false)
+	default:
+		if !isActive {
+			return
+		}
+	}
+	if !isVertical {
+		e.preferredX = -1
+	}
+	e.blink = 0
+}
+
+// Copy writes the selection to the clipboard
+func (e *Element) Copy() {
+	if !e.edit.HasSelection() {
+		return
+	}
+	e.clipboard.WriteText(e.edit.SelectedText())
+}
+
+// Cut writes the selection to the clipboard and removes it
+func (e *Element) Cut() {
+	if e.isReadOnly || !e.edit.HasSelection() {
+		return
+	}
+	err := e.clipboard.WriteText(e.edit.SelectedText())
+	if err != nil {
+		return
+	}
+	e.edit.DeleteBackward(false)
+}
+
+// Paste inserts the clipboard at the caret
+func (e *Element) Paste() {
+	if e.isReadOnly {
+		return
+	}
+	text, err := e.clipboard.ReadText()
+	if err != nil {
+		return
+	}
+	e.edit.Insert(strings.Replace(text, "\r\n", "\n", -1))
+}
+
+// contains returns true if x, y is inside the area
+func (e *Element) contains(x float64, y float64) bool {
+	return e.x <= x && x < e.x+float64(e.width)*e.scale && e.y <= y && y < e.y+float64(e.height)*e.scale
+}
+
+// innerSize returns the size of the box text is drawn in
+func (e *Element) innerSize() (int, int) {
+	pad := float64(e.padding) * e.scale * 2
+	return int(float64(e.width)*e.scale - pad), int(float64(e.height)*e.scale - pad)
+}
+
+// layout splits text into drawn lines when the text, width or font changed
+func (e *Element) layout() []line {
+	width, _ := e.innerSize()
+	if !e.isWrapped {
+		width = 0
+	}
+	revision := e.edit.Revision()
+	if e.lines != nil && revision == e.layoutRev && width == e.layoutWidth && e.font == e.layoutFont {
+		return e.lines
+	}
+	// edited lines leave stale entries behind, so the cache is dropped once it outgrows the text
+	if width != e.layoutWidth || e.font != e.layoutFont || len(e.breaks) > 2*len(e.lines)+64 {
+		e.breaks = make(map[string][]int)
+	}
+	e.layoutRev = revision
+	e.layoutWidth = width
+	e.layoutFont = e.font
+
+	e.lines = e.lines[:0]
+	start := 0
+	for _, l := range strings.Split(e.edit.Text(), "\n") {
+		n := len([]rune(l))
+		if width > 0 {
+			breaks, ok := e.breaks[l]
+			if !ok {
+				breaks = e.font.WrapBreaks(l, width)
+				e.breaks[l] = breaks
+			}
+			prev := 0
+			for _, b := range breaks {
+				e.lines = append(e.lines, line{start: start + prev, end: start + b})
+				prev = b
+			}
+			e.lines = append(e.lines, line{start: start + prev, end: start + n})
+		} else {
+			e.lines = append(e.lines, line{start: start, end: start + n})
+		}
+		// skip the line break
+		start += n + 1
+	}
+	return e.lines
+}
+
+// lineIndex returns the drawn line containing rune pos
+func (e *Element) lineIndex(pos int) int {
+	lines := e.layout()
+	i := sort.Search(len(lines), func(i int) bool {
+		return lines[i].start > pos
+	}) - 1
+	if i < 0 {
+		i = 0
+	}
+	return i
+}
+
+// lineStart returns the start of the drawn line containing pos
+func (e *Element) lineStart(pos int) int {
+	return e.layout()[e.lineIndex(pos)].start
+}
+
+// lineEnd returns the end of the drawn line containing pos. Wrapped lines end before their last rune,
+// as the offset after it is the start of the next line
+func (e *Element) lineEnd(pos int) int {
+	lines := e.layout()
+	i := e.lineIndex(pos)
+	if i+1 < len(lines) && lines[i+1].start == lines[i].end && lines[i].end > lines[i].start {
+		return lines[i].end - 1
+	}
+	return lines[i].end
+}
+
+// offset returns the x offset of rune pos within its drawn line
+func (e *Element) offset(l line, pos int) float64 {
+	w, _ := e.font.MeasureSize(e.edit.Slice(l.start, pos))
+	return float64(w)
+}
+
+// runeAtX returns the position in a drawn line nearest to x, relative to the line start
+func (e *Element) runeAtX(index int, x float64) int {
+	lines := e.layout()
+	l := lines[index]
+	end := l.end
+	if index+1 < len(lines) && lines[index+1].start == l.end && l.end > l.start {
+		end--
+	}
+	n := end - l.start
+	i := sort.Search(n+1, func(i int) bool {
+		return e.offset(l, l.start+i) >= x
+	})
+	if i > 0 && (i > n || x-e.offset(l, l.start+i-1) < e.offset(l, l.start+i)-x) {
+		i--
+	}
+	return l.start + i
+}
+
+// runeAtPoint returns the caret position nearest to screen x, y
+func (e *Element) runeAtPoint(x float64, y float64) int {
+	pad := float64(e.padding) * e.scale
+	lineHeight := float64(e.font.RenderingLineHeight)
+	index := int(math.Floor((y - e.y - pad + e.scrollY) / lineHeight))
+	lines := e.layout()
+	if index < 0 {
+		return 0
+	}
+	if index >= len(lines) {
+		return e.edit.Len()
+	}
+	return e.runeAtX(index, x-e.x-pad+e.scrollX)
+}
+
+// moveLines moves the caret count drawn lines up or down, keeping its x position
+func (e *Element) moveLines(count int, isSelecting bool) {
+	lines := e.layout()
+	caret := e.edit.Caret()
+	index := e.lineIndex(caret)
+	if e.preferredX < 0 {
+		e.preferredX = e.offset(lines[index], caret)
+	}
+	target := index + count
+	switch {
+	case target < 0:
+		e.edit.SetCaret(0, isSelecting)
+	case target >= len(lines):
+		e.edit.SetCaret(e.edit.Len(), isSelecting)
+	default:
+		e.edit.SetCaret(e.runeAtX(target, e.preferredX), isSelecting)
+	}
+}
+
+// visibleLineCount returns how many whole lines fit in the area
+func (e *Element) visibleLineCount() int {
+	_, h := e.innerSize()
+	n := h / e.font.RenderingLineHeight
+	if n < 1 {
+		n = 1
+	}
+	return n
+}
+
+// clampScroll keeps scrolling within the text
+func (e *Element) clampScroll() {
+	_, h := e.innerSize()
+	max := float64(len(e.layout())*e.font.RenderingLineHeight - h)
+	if e.scrollY > max {
+		e.scrollY = max
+	}
+	if e.scrollY < 0 {
+		e.scrollY = 0
+	}
+}
+
+// scrollToCaret scrolls the least needed to show the caret
+func (e *Element) scrollToCaret() {
+	w, h := e.innerSize()
+	lines := e.layout()
+	caret := e.edit.Caret()
+	index := e.lineIndex(caret)
+	lineHeight := float64(e.font.RenderingLineHeight)
+	top := float64(index) * lineHeight
+	if top < e.scrollY {
+		e.scrollY = top
+	}
+	if top+lineHeight > e.scrollY+float64(h) {
+		e.scrollY = top + lineHeight - float64(h)
+	}
+	e.clampScroll()
+
+	if e.isWrapped {
+		e.scrollX = 0
+		return
+	}
+	caretX := e.offset(lines[index], caret)
+	if caretX-e.scrollX > float64(w-1) {
+		e.scrollX = caretX - float64(w-1)
+	}
+	if caretX < e.scrollX {
+		e.scrollX = caretX
+	}
+}
+
+// Draw is called during a game update
+func (e *Element) Draw(dst *ebiten.Image) {
+	if !e.isVisible {
+		return
+	}
+
+	op := &ebiten.DrawImageOptions{}
+	op.GeoM.Translate(e.x, e.y)
+	op.GeoM.Scale(e.scale, e.scale)
+
+	if !e.isEnabled {
+		op.ColorM.ChangeHSV(0, 0, 1)
+		op.ColorM.Scale(0.5, 0.5, 0.5, 1)
+	}
+
+	sliceName := e.sliceName
+	if e.isFocused && e.focusedSliceName != "" {
+		sliceName = e.focusedSliceName
+	}
+	slice, err := e.image.Slice(sliceName)
+	if err == nil {
+		common.DrawNineSlicing(dst, e.image.EbitenImage, slice.Keys[0], e.width, e.height, &op.GeoM, &op.ColorM)
+	}
+
+	w, h := e.innerSize()
+	if w <= 0 || h <= 0 {
+		return
+	}
+	canvas := e.canvasImage(w, h)
+	if canvas == nil {
+		return
+	}
+	canvas.Clear()
+
+	lineHeight := float64(e.font.RenderingLineHeight)
+	if e.edit.Len() == 0 {
+		e.font.DrawText(canvas, e.placeholder, 0, 0, &common.TextLayout{Width: float64(w), IsWrapped: true}, e.placeholderColor, -1)
+	}
+
+	lines := e.layout()
+	caret := e.edit.Caret()
+	caretLine := e.lineIndex(caret)
+	start, end := e.edit.Selection()
+	first := int(e.scrollY / lineHeight)
+	last := int(math.Ceil((e.scrollY + float64(h)) / lineHeight))
+	if last > len(lines) {
+		last = len(lines)
+	}
+	for i := first; i < last; i++ {
+		l := lines[i]
+		y := float64(i)*lineHeight - e.scrollY
+
+		if start < end && start <= l.end && end >= l.start {
+			sx := e.offset(l, maxInt(start, l.start)) - e.scrollX
+			ex := e.offset(l, minInt(end, l.end)) - e.scrollX
+			if end > l.end && i+1 < len(lines) && lines[i+1].start > l.end {
+				// show the selected line break
+				ex += lineHeight / 3
+			}
+			ebitenutil.DrawRect(canvas, sx, y, ex-sx, lineHeight, e.selectionColor)
+		}
+
+		e.font.DrawText(canvas, e.edit.Slice(l.start, l.end), -e.scrollX, y, nil, e.color, -1)
+
+		if e.isFocused && i == caretLine && int(e.blink/caretBlinkInterval)%2 == 0 {
+			ebitenutil.DrawRect(canvas, e.offset(l, caret)-e.scrollX, y, 1, lineHeight, e.color)
+		}
+	}
+
+	pad := float64(e.padding) * e.scale
+	cop := &ebiten.DrawImageOptions{}
+	cop.ColorM = op.ColorM
+	cop.GeoM.Translate(e.x+pad, e.y+pad)
+	dst.DrawImage(canvas, cop)
+}
+
+func minInt(a int, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+func maxInt(a int, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
+
+// canvasImage returns the offscreen image text is clipped to, creating it when the size changes
+func (e *Element) canvasImage(w int, h int) *ebiten.Image {
+	if e.canvas != nil {
+		cw, ch := e.canvas.Size()
+		if cw == w && ch == h {
+			return e.canvas
+		}
+		e.canvas.Dispose()
+		e.canvas = nil
+	}
+	canvas, err := ebiten.NewImage(w, h, ebiten.FilterDefault)
+	if err != nil {
+		return nil
+	}
+	e.canvas = canvas
+	return canvas
+}
+
+// SetText changes the text of the area, moving the caret to the end and clearing undo history
+func (e *Element) SetText(text string) {
+	e.edit.SetText(text)
+	e.isCaretMoved = true
+}
+
+// Text returns the text of the area
+func (e *Element) Text() string {
+	return e.edit.Text()
+}
+
+// Edit returns the caret, selection and undo state of the area
+func (e *Element) Edit() *common.TextEdit {
+	return e.edit
+}
+
+// LineCount returns how many lines are drawn after wrapping
+func (e *Element) LineCount() int {
+	return len(e.layout())
+}
+
+// CaretLine returns the line and column of the caret, counted in drawn lines and runes from 0
+func (e *Element) CaretLine() (int, int) {
+	caret := e.edit.Caret()
+	index := e.lineIndex(caret)
+	return index, caret - e.layout()[index].start
+}
+
+// ScrollY returns how many pixels text is scrolled up
+func (e *Element) ScrollY() float64 {
+	return e.scrollY
+}
+
+// SetScrollY scrolls text up by scrollY pixels, kept within the text
+func (e *Element) SetScrollY(scrollY float64) {
+	e.scrollY = scrollY
+	e.clampScroll()
+}
+
+// ScrollToEnd scrolls to the last line, e.g. after a console prints
+func (e *Element) ScrollToEnd() {
+	e.scrollY = math.MaxFloat64
+	e.clampScroll()
+}
+
+// Placeholder returns the text shown while the area is empty
+func (e *Element) Placeholder() string {
+	return e.placeholder
+}
+
+// SetPlaceholder sets the text shown while the area is empty
+func (e *Element) SetPlaceholder(placeholder string) {
+	e.placeholder = placeholder
+}
+
+// SetPlaceholderColor sets the color of placeholder text
+func (e *Element) SetPlaceholderColor(placeholderColor color.Color) {
+	e.placeholderColor = placeholderColor
+}
+
+// SetSelectionColor sets the color drawn behind selected text
+func (e *Element) SetSelectionColor(selectionColor color.Color) {
+	e.selectionColor = selectionColor
+}
+
+// IsWrapped returns true if lines wrap to the area width
+func (e *Element) IsWrapped() bool {
+	return e.isWrapped
+}
+
+// SetIsWrapped sets if lines wrap to the area width, on by default. Unwrapped lines scroll horizontally
+func (e *Element) SetIsWrapped(isWrapped bool) {
+	e.isWrapped = isWrapped
+	e.isCaretMoved = true
+}
+
+// IsReadOnly returns true if text can be selected and copied but not edited
+func (e *Element) IsReadOnly() bool {
+	return e.isReadOnly
+}
+
+// SetIsReadOnly sets if text can be selected and copied but not edited, e.g. for console output
+func (e *Element) SetIsReadOnly(isReadOnly bool) {
+	e.isReadOnly = isReadOnly
+}
+
+// MaxLength returns the most runes the area accepts, 0 is unlimited
+func (e *Element) MaxLength() int {
+	return e.edit.MaxLength()
+}
+
+// SetMaxLength sets the most runes the area accepts, 0 is unlimited
+func (e *Element) SetMaxLength(maxLength int) {
+	e.edit.SetMaxLength(maxLength)
+}
+
+// SetFilter sets a filter typed and pasted text must pass. nil allows anything
+func (e *Element) SetFilter(filter common.TextFilter) {
+	e.edit.SetFilter(filter)
+}
+
+// SetClipboard sets the clipboard used for cut, copy and paste
+func (e *Element) SetClipboard(clipboard common.Clipboard) {
+	if clipboard == nil {
+		clipboard = &common.MemoryClipboard{}
+	}
+	e.clipboard = clipboard
+}
+
+// KeyRepeat returns the repeat timing of held keys
+func (e *Element) KeyRepeat() *common.KeyRepeat {
+	return e.keyRepeat
+}
+
+// IsFocused returns true if the area receives typed text
+func (e *Element) IsFocused() bool {
+	return e.isFocused
+}
+
+// SetIsFocused sets if the area receives typed text. Clicking the area focuses it, clicking elsewhere or escape unfocuses it
+func (e *Element) SetIsFocused(isFocused bool) {
+	if e.isFocused == isFocused {
+		return
+	}
+	e.isFocused = isFocused
+	e.isDragging = false
+	e.blink = 0
+	e.keyRepeat.Reset()
+}
+
+// SetOnChange sets a function called when the text is edited
+func (e *Element) SetOnChange(f func(e *Element, text string)) {
+	e.onChange = f
+}
+
+// IsDestroyed returns true when the element is flagged for deletion
+func (e *Element) IsDestroyed() bool {
+	return e.isDestroyed
+}
+
+// LerpPosition changes an element's position over duration
+func (e *Element) LerpPosition(endPositionX, endPositionY float64, duration time.Duration, isDestroyed bool, endFunc func()) {
+	e.lerpPosition.Init(e.x, e.y, endPositionX, endPositionY, duration, true, endFunc, isDestroyed)
+}
+
+// Position returns an element's position
+func (e *Element) Position() (float64, float64) {
+	return e.x, e.y
+}
+
+// SetPosition sets an element's position
+func (e *Element) SetPosition(x float64, y float64) {
+	e.x = x
+	e.y = y
+}
+
+// Width returns an element's width
+func (e *Element) Width() int {
+	return e.width
+}
+
+// SetWidth sets an element's width
+func (e *Element) SetWidth(width int) {
+	e.width = width
+	e.isCaretMoved = true
+}
+
+// Height returns an element's height
+func (e *Element) Height() int {
+	return e.height
+}
+
+// SetHeight sets an element's height
+func (e *Element) SetHeight(height int) {
+	e.height = height
+	e.isCaretMoved = true
+}
+
+// SetIsDestroyed sets an element to be destroyed on next update
+func (e *Element) SetIsDestroyed(isDestroyed bool) {
+	e.isDestroyed = true
+	if e.canvas != nil {
+		e.canvas.Dispose()
+		e.canvas = nil
+	}
+}
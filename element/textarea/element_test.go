@@ -0,0 +1,256 @@
+package textarea
+
+import (
+	"image/color"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/xackery/egui/common"
+	"golang.org/x/image/font/basicfont"
+)
+
+// newTestArea returns an area fitting 10 runes 7 pixels wide per line, and 3 lines 13 pixels tall
+func newTestArea(t *testing.T) *Element {
+	font := common.NewFontFromFace("basic", basicfont.Face7x13)
+	e, err := New("area", "scene", 0, 0, 82, 51, font, color.White, nil, "", "", nil)
+	if err != nil {
+		t.Fatalf("new: %v", err)
+	}
+	return e
+}
+
+func TestLayout(t *testing.T) {
+	tests := []struct {
+		name      string
+		text      string
+		isWrapped bool
+		want      []line
+	}{
+		{"empty", "", true, []line{{0, 0}}},
+		{"line breaks", "ab\ncd", true, []line{{0, 2}, {3, 5}}},
+		{"wrapped at spaces", "hello world foo", true, []line{{0, 6}, {6, 15}}},
+		{"wrapped long word", "abcdefghijklmn", true, []line{{0, 10}, {10, 14}}},
+		{"not wrapped", "hello world foo", false, []line{{0, 15}}},
+		{"blank lines", "a\n\nb", true, []line{{0, 1}, {2, 2}, {3, 4}}},
+	}
+	for _, tt := range tests {
+		e := newTestArea(t)
+		e.SetIsWrapped(tt.isWrapped)
+		e.SetText(tt.text)
+		if got := e.layout(); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s: lines %v, want %v", tt.name, got, tt.want)
+		}
+		if e.LineCount() != len(tt.want) {
+			t.Errorf("%s: line count %d, want %d", tt.name, e.LineCount(), len(tt.want))
+		}
+	}
+}
+
+func TestLayoutAfterEdit(t *testing.T) {
+	e := newTestArea(t)
+	e.SetText("hello")
+	if e.LineCount() != 1 {
+		t.Fatalf("line count %d, want 1", e.LineCount())
+	}
+	e.Edit().Insert(" world foo")
+	if e.LineCount() != 2 {
+		t.Errorf("line count after insert %d, want 2", e.LineCount())
+	}
+	e.SetIsWrapped(false)
+	if e.LineCount() != 1 {
+		t.Errorf("line count unwrapped %d, want 1", e.LineCount())
+	}
+}
+
+func TestCaretLine(t *testing.T) {
+	tests := []struct {
+		text   string
+		caret  int
+		line   int
+		column int
+	}{
+		{"ab\ncd", 0, 0, 0},
+		{"ab\ncd", 2, 0, 2},
+		{"ab\ncd", 3, 1, 0},
+		{"ab\ncd", 5, 1, 2},
+		// the offset between wrapped lines belongs to the next line
+		{"hello world foo", 6, 1, 0},
+		{"hello world foo", 5, 0, 5},
+	}
+	for _, tt := range tests {
+		e := newTestArea(t)
+		e.SetText(tt.text)
+		e.Edit().SetCaret(tt.caret, false)
+		line, column := e.CaretLine()
+		if line != tt.line || column != tt.column {
+			t.Errorf("%q at %d: line %d column %d, want %d %d", tt.text, tt.caret, line, column, tt.line, tt.column)
+		}
+	}
+}
+
+func TestLineEnd(t *testing.T) {
+	e := newTestArea(t)
+	e.SetText("hello world foo\nab")
+	tests := []struct {
+		pos  int
+		want int
+	}{
+		// wrapped lines end before the space they break after
+		{0, 5},
+		{8, 15},
+		{16, 18},
+	}
+	for _, tt := range tests {
+		if got := e.lineEnd(tt.pos); got != tt.want {
+			t.Errorf("line end of %d: got %d, want %d", tt.pos, got, tt.want)
+		}
+	}
+}
+
+func TestMoveLines(t *testing.T) {
+	tests := []struct {
+		name   string
+		caret  int
+		counts []int
+		want   int
+	}{
+		{"down", 2, []int{1}, 9},
+		{"down to a shorter line", 5, []int{1}, 9},
+		{"keeps x across a shorter line", 5, []int{1, 1}, 15},
+		{"up", 12, []int{-1}, 9},
+		{"past the top", 12, []int{-5}, 0},
+		{"past the bottom", 2, []int{5}, 16},
+	}
+	for _, tt := range tests {
+		e := newTestArea(t)
+		e.SetText("abcdef\nab\nabcdef")
+		e.Edit().SetCaret(tt.caret, false)
+		for _, c := range tt.counts {
+			e.moveLines(c, false)
+		}
+		if got := e.Edit().Caret(); got != tt.want {
+			t.Errorf("%s: caret %d, want %d", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestRuneAtPoint(t *testing.T) {
+	tests := []struct {
+		x       float64
+		y       float64
+		scrollY float64
+		want    int
+	}{
+		{0, 0, 0, 0},
+		{6 + 14, 6, 0, 2},
+		{6 + 14, 6 + 13, 0, 9},
+		{6 + 500, 6 + 13, 0, 9},
+		{0, 6 + 100, 0, 16},
+		// scrolled text maps to the line drawn under y
+		{6, 6, 13, 7},
+	}
+	for _, tt := range tests {
+		e := newTestArea(t)
+		e.SetText("abcdef\nab\nabcdef")
+		e.scrollY = tt.scrollY
+		if got := e.runeAtPoint(tt.x, tt.y); got != tt.want {
+			t.Errorf("%v, %v scroll %v: got %d, want %d", tt.x, tt.y, tt.scrollY, got, tt.want)
+		}
+	}
+}
+
+func TestScroll(t *testing.T) {
+	// 10 lines 13 pixels tall, 3 shown, so scroll stops at 91
+	text := strings.Repeat("line\n", 9) + "line"
+	tests := []struct {
+		name    string
+		scrollY float64
+		want    float64
+	}{
+		{"within", 20, 20},
+		{"past the end", 1000, 91},
+		{"negative", -5, 0},
+	}
+	for _, tt := range tests {
+		e := newTestArea(t)
+		e.SetText(text)
+		e.SetScrollY(tt.scrollY)
+		if e.ScrollY() != tt.want {
+			t.Errorf("%s: scroll %v, want %v", tt.name, e.ScrollY(), tt.want)
+		}
+	}
+
+	e := newTestArea(t)
+	e.SetText(text)
+	e.ScrollToEnd()
+	if e.ScrollY() != 91 {
+		t.Errorf("scroll to end %v, want 91", e.ScrollY())
+	}
+}
+
+func TestScrollToCaret(t *testing.T) {
+	text := strings.Repeat("line\n", 9) + "line"
+	tests := []struct {
+		name    string
+		caret   int
+		scrollY float64
+		want    float64
+	}{
+		{"caret shown", 0, 0, 0},
+		{"caret below", 49, 0, 91},
+		{"caret above", 0, 91, 0},
+		{"caret on the line below the view", 15, 0, 13},
+	}
+	for _, tt := range tests {
+		e := newTestArea(t)
+		e.SetText(text)
+		e.Edit().SetCaret(tt.caret, false)
+		e.scrollY = tt.scrollY
+		e.scrollToCaret()
+		if e.ScrollY() != tt.want {
+			t.Errorf("%s: scroll %v, want %v", tt.name, e.ScrollY(), tt.want)
+		}
+	}
+}
+
+func TestReadOnly(t *testing.T) {
+	tests := []struct {
+		name       string
+		isReadOnly bool
+		action     func(e *Element)
+		text       string
+		clipboard  string
+	}{
+		{"copy", true, (*Element).Copy, "hello world", "hello"},
+		{"cut", false, (*Element).Cut, " world", "hello"},
+		{"read only cut", true, (*Element).Cut, "hello world", "old"},
+		{"paste", false, (*Element).Paste, "old world", "old"},
+		{"read only paste", true, (*Element).Paste, "hello world", "old"},
+	}
+	for _, tt := range tests {
+		e := newTestArea(t)
+		cb := &common.MemoryClipboard{}
+		cb.WriteText("old")
+		e.SetClipboard(cb)
+		e.SetText("hello world")
+		e.SetIsReadOnly(tt.isReadOnly)
+		e.Edit().SetSelection(0, 5)
+		tt.action(e)
+		if e.Text() != tt.text {
+			t.Errorf("%s: text %q, want %q", tt.name, e.Text(), tt.text)
+		}
+		if got, _ := cb.ReadText(); got != tt.clipboard {
+			t.Errorf("%s: clipboard %q, want %q", tt.name, got, tt.clipboard)
+		}
+	}
+}
+
+func TestPasteLineBreaks(t *testing.T) {
+	e := newTestArea(t)
+	e.clipboard.WriteText("a\r\nb")
+	e.Paste()
+	if e.Text() != "a\nb" {
+		t.Errorf("text %q, want %q", e.Text(), "a\nb")
+	}
+}
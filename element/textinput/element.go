@@ -0,0 +1,578 @@
+package textinput
+
+import (
+	"image/color"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/hajimehoshi/ebiten"
+	"github.com/hajimehoshi/ebiten/ebitenutil"
+	"github.com/xackery/egui/common"
+)
+
+// caretBlinkInterval is how long the caret is shown, then hidden, in seconds
+const caretBlinkInterval = 0.5
+
+// Element represents a single line 9slice text input.
+// A element has a caret and selection
+// A element can register events on change and submit
+type Element struct {
+	name             string
+	image            *common.Image
+	sliceName        string
+	focusedSliceName string
+	x                float64
+	y                float64
+	scale            float64
+	width            int
+	height           int
+	padding          int
+	edit             *common.TextEdit
+	placeholder      string
+	placeholderColor color.Color
+	isPassword       bool
+	mask             rune
+	isEnabled        bool
+	isVisible        bool
+	isFocused        bool
+	isDragging       bool
+	renderIndex      int64
+	isDestroyed      bool
+	lerpPosition     *common.LerpPosition
+	color            color.Color
+	selectionColor   color.Color
+	font             *common.Font
+	clipboard        common.Clipboard
+	keyRepeat        *common.KeyRepeat
+	blink            float64
+	scrollX          float64
+	canvas           *ebiten.Image
+	onChange         func(e *Element, text string)
+	onSubmit         func(e *Element, text string)
+}
+
+// New creates a new text input instance
+func New(name string, scene string, x float64, y float64, width int, height int, font *common.Font, textColor color.Color, img *common.Image, sliceName string, focusedSliceName string, clipboard common.Clipboard) (*Element, error) {
+	if clipboard == nil {
+		clipboard = &common.MemoryClipboard{}
+	}
+
+	e := &Element{
+		name:             name,
+		image:            img,
+		sliceName:        sliceName,
+		focusedSliceName: focusedSliceName,
+		x:                x,
+		y:                y,
+		scale:            1,
+		width:            width,
+		height:           height,
+		padding:          6,
+		edit:             common.NewTextEdit(""),
+		placeholderColor: color.RGBA{128, 128, 128, 255},
+		mask:             '•',
+		isEnabled:        true,
+		isVisible:        true,
+		lerpPosition:     new(common.LerpPosition),
+		color:            textColor,
+		selectionColor:   color.RGBA{51, 102, 204, 160},
+		font:             font,
+		clipboard:        clipboard,
+		keyRepeat:        common.NewKeyRepeat(),
+	}
+	return e, nil
+}
+
+// Name returns a element's name
+func (e *Element) Name() string {
+	return e.name
+}
+
+// IsVisible returns true if element is visible
+func (e *Element) IsVisible() bool {
+	return e.isVisible
+}
+
+// IsEnabled returns true if a element is enabled
+func (e *Element) IsEnabled() bool {
+	return e.isEnabled
+}
+
+// SetEnabled changes if a element is enabled. Disabled inputs lose focus
+func (e *Element) SetEnabled(isEnabled bool) {
+	e.isEnabled = isEnabled
+	if !isEnabled {
+		e.SetIsFocused(false)
+	}
+}
+
+// SetVisible changes the visibility of a element. Hidden inputs lose focus
+func (e *Element) SetVisible(isVisible bool) {
+	e.isVisible = isVisible
+	if !isVisible {
+		e.SetIsFocused(false)
+	}
+}
+
+// RenderIndex returns the render index of element
+func (e *Element) RenderIndex() int64 {
+	return e.renderIndex
+}
+
+// SetRenderIndex sets the render index of element
+func (e *Element) SetRenderIndex(renderIndex int64) {
+	e.renderIndex = renderIndex
+}
+
+// Update is called during a game update
+func (e *Element) Update(dt float64) {
+
+	if e.lerpPosition.IsEnabled() {
+		e.x, e.y = e.lerpPosition.Lerp(dt)
+		if !e.lerpPosition.IsEnabled() {
+			if e.lerpPosition.EndFunc() != nil {
+				e.lerpPosition.EndFunc()()
+			}
+			if e.lerpPosition.IsDestroyed() {
+				e.isDestroyed = true
+				return
+			}
+		}
+	}
+
+	if !e.isEnabled || !e.isVisible {
+		return
+	}
+
+	e.updatePointer()
+	if e.isFocused {
+		e.blink += dt
+
+		text := e.edit.Text()
+		e.updateKeys(dt)
+		if e.edit.Text() != text && e.onChange != nil {
+			e.onChange(e, e.edit.Text())
+		}
+	}
+	e.scrollToCaret()
+}
+
+// scrollToCaret scrolls the text so the caret stays inside the input
+func (e *Element) scrollToCaret() {
+	// same width as the canvas text is drawn on
+	w := float64(int(float64(e.width)*e.scale - float64(e.padding)*e.scale*2))
+	if w <= 0 {
+		return
+	}
+	caretX := e.offset(e.edit.Caret())
+	if caretX-e.scrollX > w-1 {
+		e.scrollX = caretX - (w - 1)
+	}
+	if caretX < e.scrollX {
+		e.scrollX = caretX
+	}
+	textWidth := e.offset(e.edit.Len())
+	if e.scrollX > 0 && textWidth-e.scrollX < w-1 {
+		e.scrollX = textWidth - (w - 1)
+		if e.scrollX < 0 {
+			e.scrollX = 0
+		}
+	}
+}
+
+// updatePointer focuses the input and places the caret on click, selecting while dragging
+func (e *Element) updatePointer() {
+	x, y, ok := common.PointerJustPressed()
+	if ok {
+		if !e.contains(x, y) {
+			e.SetIsFocused(false)
+			return
+		}
+		e.SetIsFocused(true)
+		e.edit.SetCaret(e.runeAt(x), common.IsKeyPressed(ebiten.KeyShift))
+		e.isDragging = true
+		e.blink = 0
+		return
+	}
+	if !e.isDragging {
+		return
+	}
+	x, _, ok = common.PointerPressed()
+	if !ok {
+		e.isDragging = false
+		return
+	}
+	e.edit.SetCaret(e.runeAt(x), true)
+}
+
+// updateKeys applies typed characters and editing keys to the focused input
+func (e *Element) updateKeys(dt float64) {
+	isShift := common.IsKeyPressed(ebiten.KeyShift)
+	isControl := common.IsKeyPressed(ebiten.KeyControl)
+	isActive := false
+
+	if !isControl {
+		var sb strings.Builder
+		for _, r := range common.InputChars() {
+			if r < 0x20 || r == 0x7f {
+				continue
+			}
+			sb.WriteRune(r)
+		}
+		if sb.Len() > 0 {
+			e.edit.Insert(sb.String())
+			isActive = true
+		}
+	}
+
+	for i := e.keyRepeat.Count(ebiten.KeyLeft, dt); i > 0; i-- {
+		e.edit.MoveLeft(isShift, isControl)
+		isActive = true
+	}
+	for i := e.keyRepeat.Count(ebiten.KeyRight, dt); i > 0; i-- {
+		e.edit.MoveRight(isShift, isControl)
+		isActive = true
+	}
+	for i := e.keyRepeat.Count(ebiten.KeyBackspace, dt); i > 0; i-- {
+		e.edit.DeleteBackward(isControl)
+		isActive = true
+	}
+	for i := e.keyRepeat.Count(ebiten.KeyDelete, dt); i > 0; i-- {
+		e.edit.DeleteForward(isControl)
+		isActive = true
+	}
+
+	switch {
+	case common.IsKeyJustPressed(ebiten.KeyHome):
+		e.edit.SetCaret(0, isShift)
+	case common.IsKeyJustPressed(ebiten.KeyEnd):
+		e.edit.SetCaret(e.edit.Len(), isShift)
+	case common.IsShortcutPressed(ebiten.KeyA):
+		e.edit.SelectAll()
+	case common.IsShortcutPressed(ebiten.KeyC):
+		e.Copy()
+	case common.IsShortcutPressed(ebiten.KeyX):
+		e.Cut()
+	case common.IsShortcutPressed(ebiten.KeyV):
+		e.Paste()
+	case common.IsShortcutPressed(ebiten.KeyZ) && isShift, common.IsShortcutPressed(ebiten.KeyY):
+		e.edit.Redo()
+	case common.IsShortcutPressed(ebiten.KeyZ):
+		e.edit.Undo()
+	case common.IsKeyJustPressed(ebiten.KeyEnter), common.IsKeyJustPressed(ebiten.KeyKPEnter):
+		if e.onSubmit != nil {
+			e.onSubmit(e, e.edit.Text())
+		}
+	case common.IsKeyJustPressed(ebiten.KeyEscape):
+		e.SetIsFocused(false)
+	default:
+		if !isActive {
+			return
+		}
+	}
+	e.blink = 0
+}
+
+// Copy writes the selection to the clipboard. Password inputs never copy
+func (e *Element) Copy() {
+	if e.isPassword || !e.edit.HasSelection() {
+		return
+	}
+	e.clipboard.WriteText(e.edit.SelectedText())
+}
+
+// Cut writes the selection to the clipboard and removes it. Password inputs never cut
+func (e *Element) Cut() {
+	if e.isPassword || !e.edit.HasSelection() {
+		return
+	}
+	err := e.clipboard.WriteText(e.edit.SelectedText())
+	if err != nil {
+		return
+	}
+	e.edit.DeleteBackward(false)
+}
+
+// Paste inserts the first line of the clipboard at the caret
+func (e *Element) Paste() {
+	text, err := e.clipboard.ReadText()
+	if err != nil {
+		return
+	}
+	i := strings.IndexAny(text, "\r\n")
+	if i >= 0 {
+		text = text[:i]
+	}
+	e.edit.Insert(text)
+}
+
+// contains returns true if x, y is inside the input
+func (e *Element) contains(x float64, y float64) bool {
+	return e.x <= x && x < e.x+float64(e.width)*e.scale && e.y <= y && y < e.y+float64(e.height)*e.scale
+}
+
+// displayText returns the text as drawn, masked for passwords
+func (e *Element) displayText() string {
+	if e.isPassword {
+		return strings.Repeat(string(e.mask), e.edit.Len())
+	}
+	return e.edit.Text()
+}
+
+// offset returns the x offset of the rune index pos in the drawn text
+func (e *Element) offset(pos int) float64 {
+	runes := []rune(e.displayText())
+	if pos > len(runes) {
+		pos = len(runes)
+	}
+	w, _ := e.font.MeasureSize(string(runes[:pos]))
+	return float64(w)
+}
+
+// runeAt returns the caret position nearest to screen x
+func (e *Element) runeAt(x float64) int {
+	x = x - e.x - float64(e.padding)*e.scale + e.scrollX
+	n := e.edit.Len()
+	i := sort.Search(n+1, func(i int) bool {
+		return e.offset(i) >= x
+	})
+	if i > 0 && (i > n || x-e.offset(i-1) < e.offset(i)-x) {
+		i--
+	}
+	return i
+}
+
+// Draw is called during a game update
+func (e *Element) Draw(dst *ebiten.Image) {
+	if !e.isVisible {
+		return
+	}
+
+	op := &ebiten.DrawImageOptions{}
+	op.GeoM.Translate(e.x, e.y)
+	op.GeoM.Scale(e.scale, e.scale)
+
+	if !e.isEnabled {
+		op.ColorM.ChangeHSV(0, 0, 1)
+		op.ColorM.Scale(0.5, 0.5, 0.5, 1)
+	}
+
+	sliceName := e.sliceName
+	if e.isFocused && e.focusedSliceName != "" {
+		sliceName = e.focusedSliceName
+	}
+	slice, err := e.image.Slice(sliceName)
+	if err == nil {
+		common.DrawNineSlicing(dst, e.image.EbitenImage, slice.Keys[0], e.width, e.height, &op.GeoM, &op.ColorM)
+	}
+
+	pad := float64(e.padding) * e.scale
+	w := int(float64(e.width)*e.scale - pad*2)
+	h := int(float64(e.height) * e.scale)
+	if w <= 0 || h <= 0 {
+		return
+	}
+	canvas := e.canvasImage(w, h)
+	if canvas == nil {
+		return
+	}
+	canvas.Clear()
+
+	caretX := e.offset(e.edit.Caret())
+	lineHeight := float64(e.font.RenderingLineHeight)
+	lineY := (float64(h) - lineHeight) / 2
+	if e.edit.HasSelection() {
+		start, end := e.edit.Selection()
+		sx := e.offset(start) - e.scrollX
+		ebitenutil.DrawRect(canvas, sx, lineY, e.offset(end)-e.scrollX-sx, lineHeight, e.selectionColor)
+	}
+
+	layout := &common.TextLayout{Height: float64(h), VAlign: common.TextAlignMiddle}
+	if e.edit.Len() == 0 {
+		e.font.DrawText(canvas, e.placeholder, 0, 0, layout, e.placeholderColor, -1)
+	} else {
+		e.font.DrawText(canvas, e.displayText(), -e.scrollX, 0, layout, e.color, -1)
+	}
+
+	if e.isFocused && int(e.blink/caretBlinkInterval)%2 == 0 {
+		ebitenutil.DrawRect(canvas, caretX-e.scrollX, lineY, 1, lineHeight, e.color)
+	}
+
+	cop := &ebiten.DrawImageOptions{}
+	cop.ColorM = op.ColorM
+	cop.GeoM.Translate(e.x+pad, e.y)
+	dst.DrawImage(canvas, cop)
+}
+
+// canvasImage returns the offscreen image text is clipped to, creating it when the size changes
+func (e *Element) canvasImage(w int, h int) *ebiten.Image {
+	if e.canvas != nil {
+		cw, ch := e.canvas.Size()
+		if cw == w && ch == h {
+			return e.canvas
+		}
+		e.canvas.Dispose()
+		e.canvas = nil
+	}
+	canvas, err := ebiten.NewImage(w, h, ebiten.FilterDefault)
+	if err != nil {
+		return nil
+	}
+	e.canvas = canvas
+	return canvas
+}
+
+// SetText changes the text of the input, moving the caret to the end. Filters are not applied
+func (e *Element) SetText(text string) {
+	e.edit.SetText(text)
+}
+
+// Text returns the text of the input
+func (e *Element) Text() string {
+	return e.edit.Text()
+}
+
+// Edit returns the caret and selection state of the input
+func (e *Element) Edit() *common.TextEdit {
+	return e.edit
+}
+
+// Placeholder returns the text shown while the input is empty
+func (e *Element) Placeholder() string {
+	return e.placeholder
+}
+
+// SetPlaceholder sets the text shown while the input is empty
+func (e *Element) SetPlaceholder(placeholder string) {
+	e.placeholder = placeholder
+}
+
+// SetPlaceholderColor sets the color of placeholder text
+func (e *Element) SetPlaceholderColor(placeholderColor color.Color) {
+	e.placeholderColor = placeholderColor
+}
+
+// SetSelectionColor sets the color drawn behind selected text
+func (e *Element) SetSelectionColor(selectionColor color.Color) {
+	e.selectionColor = selectionColor
+}
+
+// MaxLength returns the most runes the input accepts, 0 is unlimited
+func (e *Element) MaxLength() int {
+	return e.edit.MaxLength()
+}
+
+// SetMaxLength sets the most runes the input accepts, 0 is unlimited
+func (e *Element) SetMaxLength(maxLength int) {
+	e.edit.SetMaxLength(maxLength)
+}
+
+// SetFilter sets a filter typed and pasted text must pass, e.g. common.FilterNumeric. nil allows anything
+func (e *Element) SetFilter(filter common.TextFilter) {
+	e.edit.SetFilter(filter)
+}
+
+// IsPassword returns true if text is drawn masked
+func (e *Element) IsPassword() bool {
+	return e.isPassword
+}
+
+// SetIsPassword sets if text is drawn masked. Password inputs cannot be copied or cut
+func (e *Element) SetIsPassword(isPassword bool) {
+	e.isPassword = isPassword
+}
+
+// SetMask sets the rune drawn for each rune of a password
+func (e *Element) SetMask(mask rune) {
+	e.mask = mask
+}
+
+// SetClipboard sets the clipboard used for cut, copy and paste
+func (e *Element) SetClipboard(clipboard common.Clipboard) {
+	if clipboard == nil {
+		clipboard = &common.MemoryClipboard{}
+	}
+	e.clipboard = clipboard
+}
+
+// KeyRepeat returns the repeat timing of held keys
+func (e *Element) KeyRepeat() *common.KeyRepeat {
+	return e.keyRepeat
+}
+
+// IsFocused returns true if the input receives typed text
+func (e *Element) IsFocused() bool {
+	return e.isFocused
+}
+
+// SetIsFocused sets if the input receives typed text. Clicking the input focuses it, clicking elsewhere or escape unfocuses it
+func (e *Element) SetIsFocused(isFocused bool) {
+	if e.isFocused == isFocused {
+		return
+	}
+	e.isFocused = isFocused
+	e.isDragging = false
+	e.blink = 0
+	e.keyRepeat.Reset()
+}
+
+// SetOnChange sets a function called when the text is edited
+func (e *Element) SetOnChange(f func(e *Element, text string)) {
+	e.onChange = f
+}
+
+// SetOnSubmit sets a function called when enter is pressed
+func (e *Element) SetOnSubmit(f func(e *Element, text string)) {
+	e.onSubmit = f
+}
+
+// IsDestroyed returns true when the element is flagged for deletion
+func (e *Element) IsDestroyed() bool {
+	return e.isDestroyed
+}
+
+// LerpPosition changes an element's position over duration
+func (e *Element) LerpPosition(endPositionX, endPositionY float64, duration time.Duration, isDestroyed bool, endFunc func()) {
+	e.lerpPosition.Init(e.x, e.y, endPositionX, endPositionY, duration, true, endFunc, isDestroyed)
+}
+
+// Position returns an element's position
+func (e *Element) Position() (float64, float64) {
+	return e.x, e.y
+}
+
+// SetPosition sets an element's position
+func (e *Element) SetPosition(x float64, y float64) {
+	e.x = x
+	e.y = y
+}
+
+// Width returns an element's width
+func (e *Element) Width() int {
+	return e.width
+}
+
+// SetWidth sets an element's width
+func (e *Element) SetWidth(width int) {
+	e.width = width
+}
+
+// Height returns an element's height
+func (e *Element) Height() int {
+	return e.height
+}
+
+// SetHeight sets an element's height
+func (e *Element) SetHeight(height int) {
+	e.height = height
+}
+
+// SetIsDestroyed sets an element to be destroyed on next update
+func (e *Element) SetIsDestroyed(isDestroyed bool) {
+	e.isDestroyed = true
+	if e.canvas != nil {
+		e.canvas.Dispose()
+		e.canvas = nil
+	}
+}
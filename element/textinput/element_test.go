@@ -0,0 +1,160 @@
+package textinput
+
+import (
+	"image/color"
+	"testing"
+
+	"github.com/xackery/egui/common"
+	"golang.org/x/image/font/basicfont"
+)
+
+// newTestInput returns an input 38 pixels wide inside its padding, drawing runes 7 pixels wide
+func newTestInput(t *testing.T) *Element {
+	font := common.NewFontFromFace("basic", basicfont.Face7x13)
+	e, err := New("input", "scene", 0, 0, 50, 20, font, color.White, nil, "", "", nil)
+	if err != nil {
+		t.Fatalf("new: %v", err)
+	}
+	return e
+}
+
+func TestClipboard(t *testing.T) {
+	tests := []struct {
+		name       string
+		text       string
+		start      int
+		end        int
+		isPassword bool
+		action     func(e *Element)
+		clipboard  string
+		want       string
+	}{
+		{"copy", "hello world", 0, 5, false, (*Element).Copy, "hello", "hello world"},
+		{"cut", "hello world", 5, 11, false, (*Element).Cut, " world", "hello"},
+		{"copy without selection", "hello", 2, 2, false, (*Element).Copy, "old", "hello"},
+		{"password copy", "secret", 0, 6, true, (*Element).Copy, "old", "secret"},
+		{"password cut", "secret", 0, 6, true, (*Element).Cut, "old", "secret"},
+		{"paste replaces selection", "hello world", 6, 11, false, (*Element).Paste, "old", "hello old"},
+	}
+	for _, tt := range tests {
+		e := newTestInput(t)
+		cb := &common.MemoryClipboard{}
+		cb.WriteText("old")
+		e.SetClipboard(cb)
+		e.SetIsPassword(tt.isPassword)
+		e.SetText(tt.text)
+		e.Edit().SetSelection(tt.start, tt.end)
+		tt.action(e)
+		if got, _ := cb.ReadText(); got != tt.clipboard {
+			t.Errorf("%s: clipboard %q, want %q", tt.name, got, tt.clipboard)
+		}
+		if e.Text() != tt.want {
+			t.Errorf("%s: text %q, want %q", tt.name, e.Text(), tt.want)
+		}
+	}
+}
+
+func TestPaste(t *testing.T) {
+	tests := []struct {
+		name      string
+		text      string
+		clipboard string
+		maxLength int
+		filter    common.TextFilter
+		want      string
+	}{
+		{"first line only", "ab", "one\ntwo", 0, nil, "abone"},
+		{"carriage return", "ab", "one\r\ntwo", 0, nil, "abone"},
+		{"cut to max length", "ab", "12345", 4, nil, "ab12"},
+		{"filtered", "12", "x1", 0, common.FilterNumeric, "12"},
+		{"filter passes", "12", ".5", 0, common.FilterNumeric, "12.5"},
+	}
+	for _, tt := range tests {
+		e := newTestInput(t)
+		e.SetText(tt.text)
+		e.SetMaxLength(tt.maxLength)
+		e.SetFilter(tt.filter)
+		e.clipboard.WriteText(tt.clipboard)
+		e.Paste()
+		if e.Text() != tt.want {
+			t.Errorf("%s: text %q, want %q", tt.name, e.Text(), tt.want)
+		}
+	}
+}
+
+func TestDisplayText(t *testing.T) {
+	e := newTestInput(t)
+	e.SetText("abc")
+	if e.displayText() != "abc" {
+		t.Errorf("display %q, want abc", e.displayText())
+	}
+	e.SetIsPassword(true)
+	if e.displayText() != "•••" {
+		t.Errorf("password display %q, want •••", e.displayText())
+	}
+	e.SetMask('*')
+	if e.displayText() != "***" {
+		t.Errorf("masked display %q, want ***", e.displayText())
+	}
+}
+
+func TestScrollToCaret(t *testing.T) {
+	tests := []struct {
+		name    string
+		text    string
+		caret   int
+		scrollX float64
+		want    float64
+	}{
+		{"fits", "abc", 3, 0, 0},
+		{"caret past the right edge", "abcdefghij", 10, 0, 33},
+		{"caret before the left edge", "abcdefghij", 2, 33, 14},
+		{"caret inside the view", "abcdefghij", 7, 33, 33},
+		// deleting text pulls the scroll back so the input stays full
+		{"text shorter than scroll", "abcdefg", 7, 33, 12},
+	}
+	for _, tt := range tests {
+		e := newTestInput(t)
+		e.SetText(tt.text)
+		e.Edit().SetCaret(tt.caret, false)
+		e.scrollX = tt.scrollX
+		e.Update(0)
+		if e.scrollX != tt.want {
+			t.Errorf("%s: scroll %v, want %v", tt.name, e.scrollX, tt.want)
+		}
+	}
+}
+
+func TestRuneAt(t *testing.T) {
+	tests := []struct {
+		x       float64
+		scrollX float64
+		want    int
+	}{
+		{0, 0, 0},
+		{6 + 10, 0, 1},
+		{6 + 11, 0, 2},
+		{6 + 500, 0, 10},
+		// scrolled text maps to the runes drawn under x
+		{6, 33, 5},
+		{6 + 10, 33, 6},
+	}
+	for _, tt := range tests {
+		e := newTestInput(t)
+		e.SetText("abcdefghij")
+		e.scrollX = tt.scrollX
+		if got := e.runeAt(tt.x); got != tt.want {
+			t.Errorf("x %v scroll %v: got %d, want %d", tt.x, tt.scrollX, got, tt.want)
+		}
+	}
+}
+
+func TestSetIsFocused(t *testing.T) {
+	e := newTestInput(t)
+	e.isDragging = true
+	e.blink = 1
+	e.SetIsFocused(true)
+	if !e.IsFocused() || e.isDragging || e.blink != 0 {
+		t.Errorf("focused %v dragging %v blink %v, want true false 0", e.IsFocused(), e.isDragging, e.blink)
+	}
+}
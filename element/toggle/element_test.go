@@ -0,0 +1,62 @@
+package toggle
+
+import (
+	"image/color"
+	"testing"
+
+	"github.com/xackery/egui/common"
+)
+
+func newTestToggle(t *testing.T) *Element {
+	e, err := New("toggle", "scene", "label", 0, 0, 100, 20, nil, color.White, nil, "", "", "")
+	if err != nil {
+		t.Fatalf("new: %v", err)
+	}
+	return e
+}
+
+func TestToggle(t *testing.T) {
+	e := newTestToggle(t)
+	var changes int
+	e.SetOnChange(func(e *Element, state common.CheckState) {
+		changes++
+	})
+	e.Toggle()
+	if !e.IsOn() {
+		t.Errorf("toggled off, want on")
+	}
+	e.SetIsOn(true)
+	e.Toggle()
+	if e.IsOn() {
+		t.Errorf("toggled on, want off")
+	}
+	if changes != 2 {
+		t.Errorf("changes %d, want 2", changes)
+	}
+}
+
+func TestSlideKnob(t *testing.T) {
+	tests := []struct {
+		name  string
+		state common.CheckState
+		knob  float64
+		dt    float64
+		want  float64
+	}{
+		{"on slides right", common.CheckChecked, 0, knobDuration / 2, 0.5},
+		{"stops at the end", common.CheckChecked, 0.9, knobDuration, 1},
+		{"off slides left", common.CheckUnchecked, 1, knobDuration / 4, 0.75},
+		{"stops at the start", common.CheckUnchecked, 0.1, knobDuration, 0},
+		{"indeterminate rests in the middle", common.CheckIndeterminate, 0, knobDuration, 0.5},
+		{"at rest", common.CheckChecked, 1, knobDuration, 1},
+	}
+	for _, tt := range tests {
+		e := newTestToggle(t)
+		e.SetState(tt.state)
+		e.knob = tt.knob
+		e.slideKnob(tt.dt)
+		if e.knob != tt.want {
+			t.Errorf("%s: knob %v, want %v", tt.name, e.knob, tt.want)
+		}
+	}
+}
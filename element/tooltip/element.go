@@ -0,0 +1,471 @@
+package tooltip
+
+import (
+	"image/color"
+	"math"
+	"time"
+
+	"github.com/hajimehoshi/ebiten"
+	"github.com/hajimehoshi/ebiten/inpututil"
+	"github.com/xackery/egui/common"
+	"github.com/xackery/egui/element"
+)
+
+// Placement is where a tooltip is shown
+type Placement int
+
+const (
+	// PlaceCursor shows a tooltip below and right of the cursor
+	PlaceCursor = Placement(0)
+	// PlaceBelow shows a tooltip below its target
+	PlaceBelow = Placement(1)
+	// PlaceAbove shows a tooltip above its target
+	PlaceAbove = Placement(2)
+)
+
+func (p Placement) String() string {
+	switch p {
+	case PlaceBelow:
+		return "below"
+	case PlaceAbove:
+		return "above"
+	default:
+		return "cursor"
+	}
+}
+
+// cursorOffset is how far from the cursor a tooltip is shown
+const cursorOffset = 16
+
+// longPressSlop is how far a touch may move and still count as a long press
+const longPressSlop = 10
+
+// Element represents a 9slice tooltip attached to a target element.
+// A element shows text, rich text or another element after the target is hovered or long pressed
+// A element hides on scroll, press or scene change
+type Element struct {
+	name              string
+	image             *common.Image
+	target            element.Interfacer
+	text              string
+	content           element.Interfacer
+	isEnabled         bool
+	isVisible         bool
+	isShown           bool
+	isFollowingCursor bool
+	isRichText        bool
+	richText          *common.RichText
+	richStyle         common.RichTextStyle
+	richLayout        *common.RichTextLayout
+	renderIndex       int64
+	isDestroyed       bool
+	color             color.Color
+	font              *common.Font
+	sliceName         string
+	placement         Placement
+	delay             float64
+	hoverTime         float64
+	// isHoverBlocked is true after a press or scroll, until the pointer leaves the target
+	isHoverBlocked bool
+	touchID        int
+	isTouching     bool
+	touchX         float64
+	touchY         float64
+	cursorX        float64
+	cursorY        float64
+	maxWidth       int
+	padding        int
+	textImage      common.TextImage
+}
+
+// New creates a new tooltip instance for target, which must implement element.Positioner and element.Sizer
+func New(name string, scene string, target element.Interfacer, text string, font *common.Font, textColor color.Color, img *common.Image, sliceName string) (*Element, error) {
+	_, isPositioner := target.(element.Positioner)
+	_, isSizer := target.(element.Sizer)
+	if !isPositioner || !isSizer {
+		return nil, common.ErrElementNoBounds
+	}
+
+	e := &Element{
+		name:      name,
+		image:     img,
+		target:    target,
+		text:      text,
+		isEnabled: true,
+		isVisible: true,
+		color:     textColor,
+		font:      font,
+		sliceName: sliceName,
+		delay:     0.5,
+		maxWidth:  240,
+		padding:   6,
+		richStyle: common.RichTextStyle{Font: font},
+	}
+	return e, nil
+}
+
+// Name returns a element's name
+func (e *Element) Name() string {
+	return e.name
+}
+
+// IsVisible returns true if element may be shown
+func (e *Element) IsVisible() bool {
+	return e.isVisible
+}
+
+// IsEnabled returns true if a element is enabled
+func (e *Element) IsEnabled() bool {
+	return e.isEnabled
+}
+
+// SetEnabled changes if a element is enabled. Disabled tooltips are never shown
+func (e *Element) SetEnabled(isEnabled bool) {
+	e.isEnabled = isEnabled
+	if !isEnabled {
+		e.Hide()
+	}
+}
+
+// SetVisible changes the visibility of a element
+func (e *Element) SetVisible(isVisible bool) {
+	e.isVisible = isVisible
+	if !isVisible {
+		e.Hide()
+	}
+}
+
+// RenderIndex returns the render index of element
+func (e *Element) RenderIndex() int64 {
+	return e.renderIndex
+}
+
+// SetRenderIndex sets the render index of element
+func (e *Element) SetRenderIndex(renderIndex int64) {
+	e.renderIndex = renderIndex
+}
+
+// Update is called during a game update
+func (e *Element) Update(dt float64) {
+	if e.target.IsDestroyed() {
+		e.isDestroyed = true
+		return
+	}
+	if !e.isEnabled || !e.isVisible || !e.target.IsVisible() {
+		e.Hide()
+		return
+	}
+
+	if e.isShown && e.content != nil {
+		e.content.Update(dt)
+	}
+
+	_, wheel := ebiten.Wheel()
+	if _, _, ok := common.PointerJustPressed(); ok || wheel != 0 {
+		e.Hide()
+		e.isHoverBlocked = true
+	}
+
+	if e.updateTouch(dt) {
+		return
+	}
+
+	x, y := common.CursorPosition()
+	if !e.contains(x, y) {
+		e.Hide()
+		e.isHoverBlocked = false
+		return
+	}
+	if e.isHoverBlocked {
+		return
+	}
+	if !e.isShown || e.isFollowingCursor {
+		e.cursorX, e.cursorY = x, y
+	}
+	e.hoverTime += dt
+	if e.hoverTime >= e.delay {
+		e.isShown = true
+	}
+}
+
+// updateTouch shows the tooltip while the target is long pressed. It returns true while a touch is held
+func (e *Element) updateTouch(dt float64) bool {
+	for _, id := range inpututil.JustPressedTouchIDs() {
+		x, y := common.TouchPosition(id)
+		if e.contains(x, y) {
+			e.touchID = id
+			e.isTouching = true
+			e.touchX, e.touchY = x, y
+			e.hoverTime = 0
+		}
+	}
+	if !e.isTouching {
+		return false
+	}
+	if inpututil.IsTouchJustReleased(e.touchID) {
+		e.isTouching = false
+		e.Hide()
+		return true
+	}
+	x, y := common.TouchPosition(e.touchID)
+	if math.Abs(x-e.touchX) > longPressSlop || math.Abs(y-e.touchY) > longPressSlop {
+		// a touch that moves is a drag or scroll, not a long press
+		e.isTouching = false
+		e.Hide()
+		return true
+	}
+	e.cursorX, e.cursorY = x, y
+	e.hoverTime += dt
+	if e.hoverTime >= e.delay {
+		e.isShown = true
+	}
+	return true
+}
+
+// contains returns true if x, y is inside the target
+func (e *Element) contains(x float64, y float64) bool {
+	tx, ty := e.target.(element.Positioner).Position()
+	s := e.target.(element.Sizer)
+	return tx <= x && x < tx+float64(s.Width()) && ty <= y && y < ty+float64(s.Height())
+}
+
+// Draw is called during a game update
+func (e *Element) Draw(dst *ebiten.Image) {
+	if !e.isShown {
+		return
+	}
+
+	cw, ch := e.contentSize()
+	w, h := cw+e.padding*2, ch+e.padding*2
+	screenWidth, screenHeight := dst.Size()
+	x, y := e.place(float64(w), float64(h), float64(screenWidth), float64(screenHeight))
+
+	slice, err := e.image.Slice(e.sliceName)
+	if err == nil {
+		op := &ebiten.DrawImageOptions{}
+		op.GeoM.Translate(x, y)
+		common.DrawNineSlicing(dst, e.image.EbitenImage, slice.Keys[0], w, h, &op.GeoM, &op.ColorM)
+	}
+
+	cx, cy := x+float64(e.padding), y+float64(e.padding)
+	switch {
+	case e.content != nil:
+		if p, ok := e.content.(element.Positioner); ok {
+			p.SetPosition(cx, cy)
+		}
+		e.content.Draw(dst)
+	case e.isRichText:
+		e.textImage.DrawRichText(dst, e.richTextLayout(), e.richStyle.Icons, cx, cy, e.color)
+	default:
+		e.textImage.DrawText(dst, e.font, e.text, cx, cy, e.textLayout(), e.color)
+	}
+}
+
+// place returns the top left of a w by h tooltip, flipped and then clamped to stay on a screen of size sw, sh
+func (e *Element) place(w float64, h float64, sw float64, sh float64) (float64, float64) {
+	var x, y float64
+	switch e.placement {
+	case PlaceBelow, PlaceAbove:
+		tx, ty := e.target.(element.Positioner).Position()
+		s := e.target.(element.Sizer)
+		tw, th := float64(s.Width()), float64(s.Height())
+		x = tx + tw/2 - w/2
+		below, above := ty+th, ty-h
+		y = below
+		if e.placement == PlaceAbove {
+			y = above
+		}
+		if y+h > sh {
+			y = above
+		}
+		if y < 0 {
+			y = below
+		}
+	default:
+		x, y = e.cursorX+cursorOffset, e.cursorY+cursorOffset
+		if x+w > sw {
+			x = e.cursorX - cursorOffset - w
+		}
+		if y+h > sh {
+			y = e.cursorY - cursorOffset - h
+		}
+	}
+	x = math.Max(0, math.Min(x, sw-w))
+	y = math.Max(0, math.Min(y, sh-h))
+	return x, y
+}
+
+// contentSize returns the size of the text or content element
+func (e *Element) contentSize() (int, int) {
+	switch {
+	case e.content != nil:
+		if s, ok := e.content.(element.Sizer); ok {
+			return s.Width(), s.Height()
+		}
+		return 0, 0
+	case e.isRichText:
+		rl := e.richTextLayout()
+		return int(math.Ceil(rl.Width)), int(math.Ceil(rl.Height))
+	case e.font == nil:
+		return 0, 0
+	}
+	lines := e.font.LayoutText(e.text, e.textLayout())
+	w := 0
+	for _, line := range lines {
+		lw, _ := e.font.MeasureSize(line)
+		if lw > w {
+			w = lw
+		}
+	}
+	return w, len(lines) * e.font.RenderingLineHeight
+}
+
+// textLayout returns the box text wraps in
+func (e *Element) textLayout() *common.TextLayout {
+	return &common.TextLayout{
+		Width:     float64(e.maxWidth),
+		IsWrapped: e.maxWidth > 0,
+	}
+}
+
+// richTextLayout returns the laid out rich text, laying it out again if anything changed
+func (e *Element) richTextLayout() *common.RichTextLayout {
+	if e.richLayout == nil {
+		e.richLayout = e.richText.Layout(&e.richStyle, e.textLayout())
+	}
+	return e.richLayout
+}
+
+// Show shows the tooltip at once, as if hovered at x, y
+func (e *Element) Show(x float64, y float64) {
+	if !e.isEnabled || !e.isVisible {
+		return
+	}
+	e.cursorX, e.cursorY = x, y
+	e.isShown = true
+}
+
+// Hide hides the tooltip until its target is hovered again
+func (e *Element) Hide() {
+	e.isShown = false
+	e.hoverTime = 0
+}
+
+// IsShown returns true if the tooltip is showing
+func (e *Element) IsShown() bool {
+	return e.isShown
+}
+
+// OnSceneExit hides the tooltip when its scene stops being shown
+func (e *Element) OnSceneExit() {
+	e.Hide()
+	e.isTouching = false
+}
+
+// Target returns the element the tooltip is attached to
+func (e *Element) Target() element.Interfacer {
+	return e.target
+}
+
+// Text returns the text of the tooltip
+func (e *Element) Text() string {
+	return e.text
+}
+
+// SetText changes the text of the tooltip. Rich text markup is parsed here, once
+func (e *Element) SetText(text string) {
+	e.text = text
+	e.richText = nil
+	if e.isRichText {
+		e.richText = common.ParseRichText(text)
+	}
+	e.richLayout = nil
+}
+
+// IsRichText returns true if text is parsed as markup
+func (e *Element) IsRichText() bool {
+	return e.isRichText
+}
+
+// SetIsRichText sets if text is parsed as markup, e.g. "[b]Sword[/b]\n[color=#f00]+5 fire[/color]"
+func (e *Element) SetIsRichText(isRichText bool) {
+	e.isRichText = isRichText
+	e.SetText(e.text)
+}
+
+// SetIcons sets the image containing slices drawn by icon markup
+func (e *Element) SetIcons(img *common.Image) {
+	e.richStyle.Icons = img
+	e.richLayout = nil
+}
+
+// Content returns the element shown instead of text, nil if none
+func (e *Element) Content() element.Interfacer {
+	return e.content
+}
+
+// SetContent shows an element instead of text, nil to show text again.
+// Content is sized by element.Sizer and placed by element.Positioner
+func (e *Element) SetContent(content element.Interfacer) {
+	e.content = content
+}
+
+// Delay returns how long the target is hovered or pressed before the tooltip shows
+func (e *Element) Delay() time.Duration {
+	return time.Duration(e.delay * float64(time.Second))
+}
+
+// SetDelay sets how long the target is hovered or pressed before the tooltip shows
+func (e *Element) SetDelay(delay time.Duration) {
+	e.delay = delay.Seconds()
+}
+
+// Placement returns where the tooltip is shown
+func (e *Element) Placement() Placement {
+	return e.placement
+}
+
+// SetPlacement sets where the tooltip is shown. It flips to the other side if it would leave the screen
+func (e *Element) SetPlacement(placement Placement) {
+	e.placement = placement
+}
+
+// IsFollowingCursor returns true if a tooltip placed at the cursor moves with it
+func (e *Element) IsFollowingCursor() bool {
+	return e.isFollowingCursor
+}
+
+// SetIsFollowingCursor sets if a tooltip placed at the cursor moves with it
+func (e *Element) SetIsFollowingCursor(isFollowingCursor bool) {
+	e.isFollowingCursor = isFollowingCursor
+}
+
+// MaxWidth returns the width text wraps at
+func (e *Element) MaxWidth() int {
+	return e.maxWidth
+}
+
+// SetMaxWidth sets the width text wraps at, 0 to never wrap
+func (e *Element) SetMaxWidth(maxWidth int) {
+	e.maxWidth = maxWidth
+	e.richLayout = nil
+}
+
+// SetPadding sets the space between the frame and its content
+func (e *Element) SetPadding(padding int) {
+	e.padding = padding
+}
+
+// IsDestroyed returns true when the element is flagged for deletion
+func (e *Element) IsDestroyed() bool {
+	return e.isDestroyed
+}
+
+// LerpPosition is not used by tooltips, which are placed by their target and the cursor
+func (e *Element) LerpPosition(endPositionX, endPositionY float64, duration time.Duration, isDestroyed bool, endFunc func()) {
+}
+
+// SetIsDestroyed sets an element to be destroyed on next update
+func (e *Element) SetIsDestroyed(isDestroyed bool) {
+	e.isDestroyed = true
+}
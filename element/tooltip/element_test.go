@@ -0,0 +1,134 @@
+package tooltip
+
+import (
+	"testing"
+
+	"github.com/xackery/egui/common"
+	"github.com/xackery/egui/element/progress"
+	"golang.org/x/image/font/basicfont"
+)
+
+// newTestTooltip returns a tooltip for a 40x20 target at x, y
+func newTestTooltip(t *testing.T, x float64, y float64) *Element {
+	target, err := progress.New("target", "scene", "", x, y, 40, 20, nil, nil, nil, "", "")
+	if err != nil {
+		t.Fatalf("new target: %v", err)
+	}
+	font := common.NewFontFromFace("basic", basicfont.Face7x13)
+	e, err := New("tooltip", "scene", target, "tip", font, nil, nil, "")
+	if err != nil {
+		t.Fatalf("new: %v", err)
+	}
+	return e
+}
+
+func TestNewTarget(t *testing.T) {
+	// tooltips have no bounds of their own, so cannot be a target
+	tip := newTestTooltip(t, 0, 0)
+	if _, err := New("tooltip", "scene", tip, "", nil, nil, nil, ""); err != common.ErrElementNoBounds {
+		t.Errorf("got %v, want %v", err, common.ErrElementNoBounds)
+	}
+}
+
+func TestPlace(t *testing.T) {
+	// tooltips are 60x30 on a 320x240 screen
+	tests := []struct {
+		name      string
+		placement Placement
+		targetX   float64
+		targetY   float64
+		cursorX   float64
+		cursorY   float64
+		want      [2]float64
+	}{
+		{"below", PlaceBelow, 100, 100, 0, 0, [2]float64{90, 120}},
+		{"above", PlaceAbove, 100, 100, 0, 0, [2]float64{90, 70}},
+		{"below flips above", PlaceBelow, 100, 220, 0, 0, [2]float64{90, 190}},
+		{"above flips below", PlaceAbove, 100, 10, 0, 0, [2]float64{90, 30}},
+		{"clamped left", PlaceBelow, 0, 100, 0, 0, [2]float64{0, 120}},
+		{"clamped right", PlaceBelow, 300, 100, 0, 0, [2]float64{260, 120}},
+		{"cursor", PlaceCursor, 0, 0, 50, 50, [2]float64{66, 66}},
+		{"cursor flips left", PlaceCursor, 0, 0, 300, 50, [2]float64{224, 66}},
+		{"cursor flips up", PlaceCursor, 0, 0, 50, 230, [2]float64{66, 184}},
+		{"cursor flips both", PlaceCursor, 0, 0, 300, 230, [2]float64{224, 184}},
+	}
+	for _, tt := range tests {
+		e := newTestTooltip(t, tt.targetX, tt.targetY)
+		e.SetPlacement(tt.placement)
+		e.Show(tt.cursorX, tt.cursorY)
+		x, y := e.place(60, 30, 320, 240)
+		if x != tt.want[0] || y != tt.want[1] {
+			t.Errorf("%s: placed at %v, %v, want %v", tt.name, x, y, tt.want)
+		}
+	}
+}
+
+func TestPlaceSmallScreen(t *testing.T) {
+	// flipped off the other edge, the tooltip is kept on screen
+	e := newTestTooltip(t, 0, 0)
+	e.Show(20, 20)
+	if x, y := e.place(60, 30, 50, 40); x != 0 || y != 0 {
+		t.Errorf("placed at %v, %v, want 0, 0", x, y)
+	}
+}
+
+func TestContentSize(t *testing.T) {
+	tests := []struct {
+		name     string
+		text     string
+		maxWidth int
+		want     [2]int
+	}{
+		{"one line", "tip", 240, [2]int{21, 13}},
+		{"line breaks", "tip\nlonger", 240, [2]int{42, 26}},
+		{"wrapped", "aaaa bbbb", 35, [2]int{28, 26}},
+		{"not wrapped", "aaaa bbbb", 0, [2]int{63, 13}},
+	}
+	for _, tt := range tests {
+		e := newTestTooltip(t, 0, 0)
+		e.SetText(tt.text)
+		e.SetMaxWidth(tt.maxWidth)
+		if w, h := e.contentSize(); w != tt.want[0] || h != tt.want[1] {
+			t.Errorf("%s: size %d, %d, want %v", tt.name, w, h, tt.want)
+		}
+	}
+
+	e := newTestTooltip(t, 0, 0)
+	content, err := progress.New("content", "scene", "", 0, 0, 80, 12, nil, nil, nil, "", "")
+	if err != nil {
+		t.Fatalf("new content: %v", err)
+	}
+	e.SetContent(content)
+	if w, h := e.contentSize(); w != 80 || h != 12 {
+		t.Errorf("content size %d, %d, want 80, 12", w, h)
+	}
+}
+
+func TestShow(t *testing.T) {
+	tests := []struct {
+		name      string
+		isEnabled bool
+		hide      func(e *Element)
+		want      bool
+	}{
+		{"shown", true, nil, true},
+		{"disabled", false, nil, false},
+		{"hidden", true, (*Element).Hide, false},
+		{"scene exit", true, (*Element).OnSceneExit, false},
+	}
+	for _, tt := range tests {
+		e := newTestTooltip(t, 0, 0)
+		e.SetEnabled(tt.isEnabled)
+		e.hoverTime = 1
+		e.Show(10, 10)
+		if tt.hide != nil {
+			tt.hide(e)
+			if e.hoverTime != 0 {
+				t.Errorf("%s: hover time %v, want reset", tt.name, e.hoverTime)
+			}
+		}
+		if e.IsShown() != tt.want {
+			t.Errorf("%s: shown %v, want %v", tt.name, e.IsShown(), tt.want)
+		}
+	}
+}
@@ -0,0 +1,760 @@
+package window
+
+import (
+	"image/color"
+	"math"
+	"time"
+
+	"github.com/hajimehoshi/ebiten"
+	"github.com/xackery/egui/common"
+	"github.com/xackery/egui/element"
+)
+
+// edges of a window a resize moves
+const (
+	edgeLeft   = 1
+	edgeRight  = 2
+	edgeTop    = 4
+	edgeBottom = 8
+)
+
+// dragMode is what a press on a window is doing
+type dragMode int
+
+const (
+	dragNone = dragMode(iota)
+	dragMove
+	dragResize
+	dragClose
+	dragMinimize
+)
+
+// Element represents a 9slice window with a title bar and child elements.
+// A element is dragged by its title bar and resized by its edges
+// A element is brought to the front of its stack when pressed
+type Element struct {
+	name              string
+	image             *common.Image
+	x                 float64
+	y                 float64
+	width             int
+	height            int
+	title             string
+	isEnabled         bool
+	isVisible         bool
+	isMinimized       bool
+	isDraggable       bool
+	isResizable       bool
+	renderIndex       int64
+	isDestroyed       bool
+	lerpPosition      *common.LerpPosition
+	color             color.Color
+	font              *common.Font
+	sliceName         string
+	titleSliceName    string
+	closeSliceName    string
+	minimizeSliceName string
+	titleHeight       int
+	padding           int
+	handleSize        int
+	minWidth          int
+	minHeight         int
+	maxWidth          int
+	maxHeight         int
+	stack             *Stack
+	elements          []element.Interfacer
+	drag              dragMode
+	edges             int
+	pressX            float64
+	pressY            float64
+	startX            float64
+	startY            float64
+	startWidth        int
+	startHeight       int
+	isCaptured        bool
+	content           *ebiten.Image
+	textImage         common.TextImage
+	onClose           func(e *Element)
+	onMinimize        func(e *Element, isMinimized bool)
+	onMove            func(e *Element)
+}
+
+// New creates a new window instance at the front of stack. A saved layout in stack for name replaces its position and size
+func New(name string, scene string, title string, x float64, y float64, width int, height int, font *common.Font, textColor color.Color, img *common.Image, stack *Stack, sliceName string, titleSliceName string) (*Element, error) {
+
+	e := &Element{
+		name:           name,
+		image:          img,
+		title:          title,
+		isEnabled:      true,
+		isVisible:      true,
+		isDraggable:    true,
+		isResizable:    true,
+		lerpPosition:   new(common.LerpPosition),
+		color:          textColor,
+		x:              x,
+		y:              y,
+		width:          width,
+		height:         height,
+		font:           font,
+		sliceName:      sliceName,
+		titleSliceName: titleSliceName,
+		titleHeight:    24,
+		padding:        4,
+		handleSize:     6,
+		minWidth:       80,
+		minHeight:      48,
+		stack:          stack,
+	}
+	if stack != nil {
+		stack.add(e)
+	}
+	return e, nil
+}
+
+// Name returns a element's name
+func (e *Element) Name() string {
+	return e.name
+}
+
+// IsVisible returns true if element is visible
+func (e *Element) IsVisible() bool {
+	return e.isVisible
+}
+
+// IsEnabled returns true if a element is enabled
+func (e *Element) IsEnabled() bool {
+	return e.isEnabled
+}
+
+// SetEnabled changes if a element is enabled
+func (e *Element) SetEnabled(isEnabled bool) {
+	e.isEnabled = isEnabled
+	if !isEnabled {
+		e.drag = dragNone
+		e.release()
+	}
+}
+
+// SetVisible changes the visibility of a element. Showing a window brings it to the front
+func (e *Element) SetVisible(isVisible bool) {
+	if isVisible && !e.isVisible && e.stack != nil {
+		e.stack.BringToFront(e)
+	}
+	e.isVisible = isVisible
+	e.drag = dragNone
+	e.release()
+}
+
+// RenderIndex returns the render index of element
+func (e *Element) RenderIndex() int64 {
+	return e.renderIndex
+}
+
+// SetRenderIndex sets the render index of element. Windows in a stack are given render indexes by the stack
+func (e *Element) SetRenderIndex(renderIndex int64) {
+	e.renderIndex = renderIndex
+}
+
+// Update is called during a game update
+func (e *Element) Update(dt float64) {
+
+	if e.lerpPosition.IsEnabled() {
+		e.x, e.y = e.lerpPosition.Lerp(dt)
+		if !e.lerpPosition.IsEnabled() {
+			if e.lerpPosition.EndFunc() != nil {
+				e.lerpPosition.EndFunc()()
+			}
+			if e.lerpPosition.IsDestroyed() {
+				e.SetIsDestroyed(true)
+				return
+			}
+		}
+	}
+
+	if !e.isEnabled || !e.isVisible {
+		return
+	}
+
+	if e.isCaptured {
+		common.BeginCapturedInput(e)
+		defer common.EndCapturedInput()
+	}
+	e.updatePointer()
+	if e.isMinimized {
+		return
+	}
+
+	// children only see the pointer over the part of the window in front of other windows
+	cx, cy, cw, ch := e.contentRect()
+	x, y := common.CursorPosition()
+	if e.drag != dragNone || (e.stack != nil && e.stack.topAt(x, y) != e) {
+		cw, ch = 0, 0
+	}
+	common.PushInputView(cx, cy, cw, ch, cx, cy)
+	for _, c := range e.elements {
+		c.Update(dt)
+	}
+	common.PopInputView()
+
+	for i := 0; i < len(e.elements); i++ {
+		if !e.elements[i].IsDestroyed() {
+			continue
+		}
+		e.elements = append(e.elements[:i], e.elements[i+1:]...)
+		i--
+	}
+}
+
+// updatePointer brings the window to the front when pressed, and drags, resizes or presses its buttons
+func (e *Element) updatePointer() {
+	x, y, ok := common.PointerJustPressed()
+	if ok {
+		e.drag = dragNone
+		if !e.contains(x, y) || (e.stack != nil && e.stack.topAt(x, y) != e) {
+			return
+		}
+		if e.stack != nil {
+			e.stack.BringToFront(e)
+		}
+		e.pressX, e.pressY = x, y
+		e.startX, e.startY = e.x, e.y
+		e.startWidth, e.startHeight = e.width, e.height
+		e.edges = 0
+		switch {
+		case e.buttonAt(x, y) != dragNone:
+			e.drag = e.buttonAt(x, y)
+		case e.isResizable && !e.isMinimized && e.edgesAt(x, y) != 0:
+			e.drag = dragResize
+			e.edges = e.edgesAt(x, y)
+		case e.isDraggable && y < e.y+float64(e.titleHeight):
+			e.drag = dragMove
+		}
+		return
+	}
+	if e.drag == dragNone {
+		if _, _, ok = common.PointerPressed(); !ok {
+			e.release()
+		}
+		return
+	}
+
+	x, y, ok = common.PointerPressed()
+	if !ok {
+		e.release()
+		// buttons act on release over the button that was pressed
+		drag := e.drag
+		e.drag = dragNone
+		cx, cy := common.CursorPosition()
+		if drag == dragClose && e.buttonAt(cx, cy) == dragClose {
+			e.Close()
+		}
+		if drag == dragMinimize && e.buttonAt(cx, cy) == dragMinimize {
+			e.SetIsMinimized(!e.isMinimized)
+		}
+		return
+	}
+
+	dx, dy := x-e.pressX, y-e.pressY
+	switch e.drag {
+	case dragMove:
+		e.x, e.y = e.startX+dx, e.startY+dy
+	case dragResize:
+		e.resize(dx, dy)
+	default:
+		return
+	}
+	if e.onMove != nil {
+		e.onMove(e)
+	}
+}
+
+// ClaimPointer captures input for a press on the window until it is released, so elements under it miss the press
+func (e *Element) ClaimPointer(x float64, y float64) bool {
+	if !e.isEnabled || !e.isVisible || !e.contains(x, y) || (e.stack != nil && e.stack.topAt(x, y) != e) {
+		return false
+	}
+	e.isCaptured = true
+	common.CaptureInput(e)
+	return true
+}
+
+// release ends a press captured by ClaimPointer
+func (e *Element) release() {
+	if !e.isCaptured {
+		return
+	}
+	e.isCaptured = false
+	common.ReleaseInput(e)
+}
+
+// resize moves the pressed edges by dx, dy, keeping the opposite edges in place
+func (e *Element) resize(dx float64, dy float64) {
+	if e.edges&edgeRight != 0 {
+		e.width = e.clampWidth(e.startWidth + int(dx))
+	}
+	if e.edges&edgeLeft != 0 {
+		e.width = e.clampWidth(e.startWidth - int(dx))
+		e.x = e.startX + float64(e.startWidth-e.width)
+	}
+	if e.edges&edgeBottom != 0 {
+		e.height = e.clampHeight(e.startHeight + int(dy))
+	}
+	if e.edges&edgeTop != 0 {
+		e.height = e.clampHeight(e.startHeight - int(dy))
+		e.y = e.startY + float64(e.startHeight-e.height)
+	}
+}
+
+// clampWidth returns width within the minimum and maximum width
+func (e *Element) clampWidth(width int) int {
+	if e.maxWidth > 0 && width > e.maxWidth {
+		width = e.maxWidth
+	}
+	if width < e.minWidth {
+		width = e.minWidth
+	}
+	return width
+}
+
+// clampHeight returns height within the minimum and maximum height
+func (e *Element) clampHeight(height int) int {
+	if e.maxHeight > 0 && height > e.maxHeight {
+		height = e.maxHeight
+	}
+	if height < e.minHeight {
+		height = e.minHeight
+	}
+	return height
+}
+
+// shownHeight returns the height drawn, only the title bar while minimized
+func (e *Element) shownHeight() int {
+	if e.isMinimized {
+		return e.titleHeight
+	}
+	return e.height
+}
+
+// contains returns true if x, y is inside the window
+func (e *Element) contains(x float64, y float64) bool {
+	return e.x <= x && x < e.x+float64(e.width) && e.y <= y && y < e.y+float64(e.shownHeight())
+}
+
+// edgesAt returns the edges whose resize handle is at x, y
+func (e *Element) edgesAt(x float64, y float64) int {
+	handle := float64(e.handleSize)
+	edges := 0
+	if x < e.x+handle {
+		edges |= edgeLeft
+	}
+	if x >= e.x+float64(e.width)-handle {
+		edges |= edgeRight
+	}
+	if y < e.y+handle {
+		edges |= edgeTop
+	}
+	if y >= e.y+float64(e.height)-handle {
+		edges |= edgeBottom
+	}
+	return edges
+}
+
+// buttonRect returns the bounds of the i-th title bar button from the right
+func (e *Element) buttonRect(i int) (float64, float64, float64, float64) {
+	inset := float64(e.padding)
+	size := float64(e.titleHeight) - inset*2
+	x := e.x + float64(e.width) - inset - size - float64(i)*(size+inset)
+	return x, e.y + inset, size, size
+}
+
+// buttons returns the slice names of title bar buttons from the right, and what they do
+func (e *Element) buttons() ([]string, []dragMode) {
+	var names []string
+	var modes []dragMode
+	if e.closeSliceName != "" {
+		names = append(names, e.closeSliceName)
+		modes = append(modes, dragClose)
+	}
+	if e.minimizeSliceName != "" {
+		names = append(names, e.minimizeSliceName)
+		modes = append(modes, dragMinimize)
+	}
+	return names, modes
+}
+
+// buttonAt returns what the title bar button at x, y does, or dragNone if there is none
+func (e *Element) buttonAt(x float64, y float64) dragMode {
+	_, modes := e.buttons()
+	for i, mode := range modes {
+		bx, by, w, h := e.buttonRect(i)
+		if bx <= x && x < bx+w && by <= y && y < by+h {
+			return mode
+		}
+	}
+	return dragNone
+}
+
+// contentRect returns the bounds children are placed and clipped in
+func (e *Element) contentRect() (float64, float64, float64, float64) {
+	w := math.Max(0, float64(e.width-e.padding*2))
+	h := math.Max(0, float64(e.height-e.titleHeight-e.padding))
+	return e.x + float64(e.padding), e.y + float64(e.titleHeight), w, h
+}
+
+// Draw is called during a game update
+func (e *Element) Draw(dst *ebiten.Image) {
+	if !e.isVisible {
+		return
+	}
+
+	op := &ebiten.DrawImageOptions{}
+	op.GeoM.Translate(e.x, e.y)
+
+	textColor := e.color
+	if !e.isEnabled {
+		op.ColorM.ChangeHSV(0, 0, 1)
+		op.ColorM.Scale(0.5, 0.5, 0.5, 1)
+		textColor = common.DisabledColor(e.color)
+	}
+
+	slice, err := e.image.Slice(e.sliceName)
+	if err == nil {
+		common.DrawNineSlicing(dst, e.image.EbitenImage, slice.Keys[0], e.width, e.shownHeight(), &op.GeoM, &op.ColorM)
+	}
+	slice, err = e.image.Slice(e.titleSliceName)
+	if err == nil {
+		common.DrawNineSlicing(dst, e.image.EbitenImage, slice.Keys[0], e.width, e.titleHeight, &op.GeoM, &op.ColorM)
+	}
+
+	names, _ := e.buttons()
+	for i, name := range names {
+		slice, err = e.image.Slice(name)
+		if err != nil {
+			continue
+		}
+		bx, by, w, h := e.buttonRect(i)
+		bop := &ebiten.DrawImageOptions{}
+		bop.GeoM.Translate(bx, by)
+		bop.ColorM = op.ColorM
+		common.DrawNineSlicing(dst, e.image.EbitenImage, slice.Keys[0], int(w), int(h), &bop.GeoM, &bop.ColorM)
+	}
+
+	padding := float64(e.padding) * 2
+	layout := &common.TextLayout{
+		Width:      float64(e.width) - padding*2 - float64(len(names)*e.titleHeight),
+		Height:     float64(e.titleHeight),
+		VAlign:     common.TextAlignMiddle,
+		IsEllipsis: true,
+	}
+	e.textImage.DrawText(dst, e.font, e.title, e.x+padding, e.y, layout, textColor)
+
+	if e.isMinimized {
+		return
+	}
+	cx, cy, cw, ch := e.contentRect()
+	content := e.contentImage(int(cw), int(ch))
+	if content == nil {
+		return
+	}
+	content.Clear()
+	for _, c := range e.elements {
+		if !c.IsVisible() {
+			continue
+		}
+		c.Draw(content)
+	}
+	cop := &ebiten.DrawImageOptions{}
+	cop.GeoM.Translate(cx, cy)
+	cop.ColorM = op.ColorM
+	dst.DrawImage(content, cop)
+}
+
+// contentImage returns the offscreen image children are clipped to, creating it when the size changes
+func (e *Element) contentImage(w int, h int) *ebiten.Image {
+	if w <= 0 || h <= 0 {
+		return nil
+	}
+	if e.content != nil {
+		cw, ch := e.content.Size()
+		if cw == w && ch == h {
+			return e.content
+		}
+		e.content.Dispose()
+		e.content = nil
+	}
+	content, err := ebiten.NewImage(w, h, ebiten.FilterDefault)
+	if err != nil {
+		return nil
+	}
+	e.content = content
+	return content
+}
+
+// AddElement adds a child element, positioned relative to the top left of the window content
+func (e *Element) AddElement(c element.Interfacer) error {
+	if c.Name() == "" {
+		return common.ErrElementNameInvalid
+	}
+	_, err := e.Element(c.Name())
+	if err == nil {
+		return common.ErrElementAlreadyExists
+	}
+	e.elements = append(e.elements, c)
+	return nil
+}
+
+// RemoveElement removes a child element
+func (e *Element) RemoveElement(name string) error {
+	for i, c := range e.elements {
+		if c.Name() != name {
+			continue
+		}
+		e.elements = append(e.elements[:i], e.elements[i+1:]...)
+		return nil
+	}
+	return common.ErrElementNotFound
+}
+
+// Element returns a child element based on name
+func (e *Element) Element(name string) (element.Interfacer, error) {
+	for _, c := range e.elements {
+		if c.Name() == name {
+			return c, nil
+		}
+	}
+	return nil, common.ErrElementNotFound
+}
+
+// Elements returns the child elements
+func (e *Element) Elements() []element.Interfacer {
+	return e.elements
+}
+
+// SetText changes the title of the window
+func (e *Element) SetText(text string) {
+	e.title = text
+}
+
+// Title returns the title of the window
+func (e *Element) Title() string {
+	return e.title
+}
+
+// Stack returns the stack the window is ordered in, nil if none
+func (e *Element) Stack() *Stack {
+	return e.stack
+}
+
+// BringToFront draws the window above every other window of its stack
+func (e *Element) BringToFront() {
+	if e.stack != nil {
+		e.stack.BringToFront(e)
+	}
+}
+
+// Close hides the window, calling the close function
+func (e *Element) Close() {
+	e.SetVisible(false)
+	if e.onClose != nil {
+		e.onClose(e)
+	}
+}
+
+// IsMinimized returns true if only the title bar is shown
+func (e *Element) IsMinimized() bool {
+	return e.isMinimized
+}
+
+// SetIsMinimized collapses the window to its title bar, or expands it, calling the minimize function if it changed
+func (e *Element) SetIsMinimized(isMinimized bool) {
+	if e.isMinimized == isMinimized {
+		return
+	}
+	e.isMinimized = isMinimized
+	if e.onMinimize != nil {
+		e.onMinimize(e, isMinimized)
+	}
+}
+
+// IsDraggable returns true if the title bar drags the window
+func (e *Element) IsDraggable() bool {
+	return e.isDraggable
+}
+
+// SetIsDraggable sets if the title bar drags the window
+func (e *Element) SetIsDraggable(isDraggable bool) {
+	e.isDraggable = isDraggable
+}
+
+// IsResizable returns true if the edges resize the window
+func (e *Element) IsResizable() bool {
+	return e.isResizable
+}
+
+// SetIsResizable sets if the edges resize the window
+func (e *Element) SetIsResizable(isResizable bool) {
+	e.isResizable = isResizable
+}
+
+// MinSize returns the smallest size the window resizes to
+func (e *Element) MinSize() (int, int) {
+	return e.minWidth, e.minHeight
+}
+
+// SetMinSize sets the smallest size the window resizes to
+func (e *Element) SetMinSize(minWidth int, minHeight int) {
+	e.minWidth = minWidth
+	e.minHeight = minHeight
+	e.width = e.clampWidth(e.width)
+	e.height = e.clampHeight(e.height)
+}
+
+// MaxSize returns the largest size the window resizes to, 0 if unbounded
+func (e *Element) MaxSize() (int, int) {
+	return e.maxWidth, e.maxHeight
+}
+
+// SetMaxSize sets the largest size the window resizes to, 0 for unbounded
+func (e *Element) SetMaxSize(maxWidth int, maxHeight int) {
+	e.maxWidth = maxWidth
+	e.maxHeight = maxHeight
+	e.width = e.clampWidth(e.width)
+	e.height = e.clampHeight(e.height)
+}
+
+// SetButtons sets the slices of the close and minimize buttons, empty to leave a button out
+func (e *Element) SetButtons(closeSliceName string, minimizeSliceName string) {
+	e.closeSliceName = closeSliceName
+	e.minimizeSliceName = minimizeSliceName
+}
+
+// TitleHeight returns the height of the title bar
+func (e *Element) TitleHeight() int {
+	return e.titleHeight
+}
+
+// SetTitleHeight sets the height of the title bar
+func (e *Element) SetTitleHeight(titleHeight int) {
+	e.titleHeight = titleHeight
+}
+
+// SetPadding sets the space between the frame and the content
+func (e *Element) SetPadding(padding int) {
+	e.padding = padding
+}
+
+// SetOnClose sets a function called when the window is closed
+func (e *Element) SetOnClose(f func(e *Element)) {
+	e.onClose = f
+}
+
+// SetOnMinimize sets a function called when the window is minimized or expanded
+func (e *Element) SetOnMinimize(f func(e *Element, isMinimized bool)) {
+	e.onMinimize = f
+}
+
+// SetOnMove sets a function called while the window is dragged or resized
+func (e *Element) SetOnMove(f func(e *Element)) {
+	e.onMove = f
+}
+
+// Layout returns the state of the window, to be saved
+func (e *Element) Layout() Layout {
+	return Layout{
+		Name:        e.name,
+		X:           e.x,
+		Y:           e.y,
+		Width:       e.width,
+		Height:      e.height,
+		IsMinimized: e.isMinimized,
+		IsVisible:   e.isVisible,
+	}
+}
+
+// applyLayout restores a saved state of the window, moving it onto a screen of screenWidth by screenHeight.
+// A screen size of 0 leaves the position as saved
+func (e *Element) applyLayout(l Layout, screenWidth int, screenHeight int) {
+	e.width = e.clampWidth(l.Width)
+	e.height = e.clampHeight(l.Height)
+	e.isMinimized = l.IsMinimized
+	e.isVisible = l.IsVisible
+	e.x, e.y = l.X, l.Y
+	if screenWidth > 0 {
+		e.x = math.Max(0, math.Min(e.x, float64(screenWidth-e.width)))
+	}
+	if screenHeight > 0 {
+		e.y = math.Max(0, math.Min(e.y, float64(screenHeight-e.shownHeight())))
+	}
+}
+
+// IsDestroyed returns true when the element is flagged for deletion
+func (e *Element) IsDestroyed() bool {
+	return e.isDestroyed
+}
+
+// LerpPosition changes an element's position over duration
+func (e *Element) LerpPosition(endPositionX, endPositionY float64, duration time.Duration, isDestroyed bool, endFunc func()) {
+	e.lerpPosition.Init(e.x, e.y, endPositionX, endPositionY, duration, true, endFunc, isDestroyed)
+}
+
+// Position returns an element's position
+func (e *Element) Position() (float64, float64) {
+	return e.x, e.y
+}
+
+// SetPosition sets an element's position
+func (e *Element) SetPosition(x float64, y float64) {
+	e.x = x
+	e.y = y
+}
+
+// Width returns an element's width
+func (e *Element) Width() int {
+	return e.width
+}
+
+// SetWidth sets an element's width
+func (e *Element) SetWidth(width int) {
+	e.width = e.clampWidth(width)
+}
+
+// Height returns an element's height
+func (e *Element) Height() int {
+	return e.height
+}
+
+// SetHeight sets an element's height
+func (e *Element) SetHeight(height int) {
+	e.height = e.clampHeight(height)
+}
+
+// Pivot returns the bottom center of an element, used for depth sorting
+func (e *Element) Pivot() (float64, float64) {
+	return e.x + float64(e.width)/2, e.y + float64(e.shownHeight())
+}
+
+// SetIsDestroyed sets an element to be destroyed on next update, keeping its layout in its stack
+func (e *Element) SetIsDestroyed(isDestroyed bool) {
+	e.isDestroyed = true
+	e.OnRemove()
+}
+
+// OnRemove drops the window from its stack when it is removed from its scene, keeping its layout
+func (e *Element) OnRemove() {
+	e.drag = dragNone
+	e.release()
+	if e.stack != nil {
+		e.stack.remove(e)
+	}
+	if e.content != nil {
+		e.content.Dispose()
+		e.content = nil
+	}
+}
+
+// OnSceneExit ends a press when the scene stops being shown
+func (e *Element) OnSceneExit() {
+	e.drag = dragNone
+	e.release()
+}
@@ -0,0 +1,174 @@
+package window
+
+import (
+	"testing"
+
+	"github.com/xackery/egui/common"
+)
+
+// newTestElement returns a 200x150 window at 100, 100 outside any stack
+func newTestElement(t *testing.T) *Element {
+	return newTestWindow(t, nil, "window", 100, 100, 200, 150)
+}
+
+func TestResize(t *testing.T) {
+	tests := []struct {
+		name      string
+		edges     int
+		dx        float64
+		dy        float64
+		maxHeight int
+		want      Layout
+	}{
+		{"right", edgeRight, 50, 0, 0, Layout{X: 100, Y: 100, Width: 250, Height: 150}},
+		{"left", edgeLeft, 50, 0, 0, Layout{X: 150, Y: 100, Width: 150, Height: 150}},
+		{"left past min width", edgeLeft, 300, 0, 0, Layout{X: 220, Y: 100, Width: 80, Height: 150}},
+		{"top", edgeTop, 0, -20, 0, Layout{X: 100, Y: 80, Width: 200, Height: 170}},
+		{"bottom past max height", edgeBottom, 0, 50, 160, Layout{X: 100, Y: 100, Width: 200, Height: 160}},
+		{"corner", edgeRight | edgeBottom, 10, 10, 0, Layout{X: 100, Y: 100, Width: 210, Height: 160}},
+	}
+	for _, tt := range tests {
+		e := newTestElement(t)
+		e.SetMaxSize(0, tt.maxHeight)
+		e.edges = tt.edges
+		e.startX, e.startY = e.x, e.y
+		e.startWidth, e.startHeight = e.width, e.height
+		e.resize(tt.dx, tt.dy)
+		tt.want.Name = "window"
+		tt.want.IsVisible = true
+		if got := e.Layout(); got != tt.want {
+			t.Errorf("%s: layout %+v, want %+v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestEdgesAt(t *testing.T) {
+	tests := []struct {
+		name string
+		x    float64
+		y    float64
+		want int
+	}{
+		{"top left", 101, 101, edgeLeft | edgeTop},
+		{"bottom right", 299, 249, edgeRight | edgeBottom},
+		{"right", 295, 175, edgeRight},
+		{"inside", 200, 175, 0},
+	}
+	for _, tt := range tests {
+		e := newTestElement(t)
+		if got := e.edgesAt(tt.x, tt.y); got != tt.want {
+			t.Errorf("%s: edges %d, want %d", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestButtonAt(t *testing.T) {
+	// buttons are 16 pixels square, inset 4 from the right of the title bar
+	tests := []struct {
+		name     string
+		close    string
+		minimize string
+		x        float64
+		y        float64
+		want     dragMode
+	}{
+		{"close", "close", "minimize", 285, 110, dragClose},
+		{"minimize", "close", "minimize", 265, 110, dragMinimize},
+		{"between", "close", "minimize", 278, 110, dragNone},
+		{"title", "close", "minimize", 200, 110, dragNone},
+		{"minimize alone", "", "minimize", 285, 110, dragMinimize},
+		{"no buttons", "", "", 285, 110, dragNone},
+	}
+	for _, tt := range tests {
+		e := newTestElement(t)
+		e.SetButtons(tt.close, tt.minimize)
+		if got := e.buttonAt(tt.x, tt.y); got != tt.want {
+			t.Errorf("%s: button %d, want %d", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestContentRect(t *testing.T) {
+	e := newTestElement(t)
+	if x, y, w, h := e.contentRect(); x != 104 || y != 124 || w != 192 || h != 122 {
+		t.Errorf("content %v, %v, %v, %v, want 104, 124, 192, 122", x, y, w, h)
+	}
+}
+
+func TestMinimize(t *testing.T) {
+	e := newTestElement(t)
+	var calls []bool
+	e.SetOnMinimize(func(e *Element, isMinimized bool) {
+		calls = append(calls, isMinimized)
+	})
+	e.SetIsMinimized(true)
+	e.SetIsMinimized(true)
+	if len(calls) != 1 || !calls[0] {
+		t.Errorf("minimize calls %v, want [true]", calls)
+	}
+	if e.contains(150, 130) {
+		t.Errorf("minimized window contains a point below its title bar")
+	}
+	if _, y := e.Pivot(); y != 124 {
+		t.Errorf("minimized pivot y %v, want 124", y)
+	}
+	e.SetIsMinimized(false)
+	if !e.contains(150, 130) {
+		t.Errorf("expanded window does not contain a point below its title bar")
+	}
+}
+
+func TestSetSizeLimits(t *testing.T) {
+	e := newTestElement(t)
+	e.SetMaxSize(150, 100)
+	if e.Width() != 150 || e.Height() != 100 {
+		t.Errorf("size %d, %d after max, want 150, 100", e.Width(), e.Height())
+	}
+	e.SetMinSize(160, 50)
+	if e.Width() != 160 || e.Height() != 100 {
+		t.Errorf("size %d, %d after min, want 160, 100", e.Width(), e.Height())
+	}
+}
+
+func TestClaimPointer(t *testing.T) {
+	s := NewStack("stack")
+	back := newTestWindow(t, s, "back", 0, 0, 100, 100)
+	front := newTestWindow(t, s, "front", 50, 50, 100, 100)
+	tests := []struct {
+		name   string
+		window *Element
+		x      float64
+		y      float64
+		want   bool
+	}{
+		{"back", back, 10, 10, true},
+		{"back under front", back, 60, 60, false},
+		{"front", front, 60, 60, true},
+		{"outside", front, 10, 10, false},
+	}
+	for _, tt := range tests {
+		got := tt.window.ClaimPointer(tt.x, tt.y)
+		if got != tt.want {
+			t.Errorf("%s: claimed %v, want %v", tt.name, got, tt.want)
+		}
+		if got != (common.InputCaptor() == tt.window) {
+			t.Errorf("%s: input captured %v, want %v", tt.name, !got, got)
+		}
+		tt.window.OnSceneExit()
+		if common.InputCaptor() == tt.window {
+			t.Errorf("%s: input captured after scene exit", tt.name)
+		}
+	}
+}
+
+func TestClose(t *testing.T) {
+	e := newTestElement(t)
+	closes := 0
+	e.SetOnClose(func(e *Element) {
+		closes++
+	})
+	e.Close()
+	if e.IsVisible() || closes != 1 {
+		t.Errorf("visible %v with %d close calls, want false 1", e.IsVisible(), closes)
+	}
+}
@@ -0,0 +1,234 @@
+package window
+
+import (
+	"encoding/json"
+	"io"
+	"sort"
+)
+
+// Stack is a set of windows drawn in z-order. Pressing a window brings it to the front
+type Stack struct {
+	name      string
+	windows   []*Element
+	baseIndex int64
+	// screenWidth and screenHeight are what restored windows are moved onto, 0 if unknown
+	screenWidth  int
+	screenHeight int
+	// layouts are saved window states, applied to windows as they are added
+	layouts map[string]Layout
+	// order is where each loaded window goes from back to front, starting at 1
+	order   map[string]int
+	onFront func(s *Stack, e *Element)
+}
+
+// Layout is the saved state of a window
+type Layout struct {
+	Name        string  `json:"name"`
+	X           float64 `json:"x"`
+	Y           float64 `json:"y"`
+	Width       int     `json:"width"`
+	Height      int     `json:"height"`
+	IsMinimized bool    `json:"isMinimized,omitempty"`
+	IsVisible   bool    `json:"isVisible"`
+}
+
+// NewStack creates an empty window stack. Windows get render indexes from 1000 up
+func NewStack(name string) *Stack {
+	return &Stack{
+		name:      name,
+		baseIndex: 1000,
+		layouts:   make(map[string]Layout),
+		order:     make(map[string]int),
+	}
+}
+
+// Name returns a stack's name
+func (s *Stack) Name() string {
+	return s.name
+}
+
+// Windows returns the windows of the stack from back to front
+func (s *Stack) Windows() []*Element {
+	return s.windows
+}
+
+// Front returns the frontmost window, or nil if there is none
+func (s *Stack) Front() *Element {
+	for i := len(s.windows) - 1; i >= 0; i-- {
+		if s.windows[i].isVisible {
+			return s.windows[i]
+		}
+	}
+	return nil
+}
+
+// BringToFront draws e above every other window, calling the front function if it moved
+func (s *Stack) BringToFront(e *Element) {
+	if e.stack != s || len(s.windows) == 0 || s.windows[len(s.windows)-1] == e {
+		return
+	}
+	for i, w := range s.windows {
+		if w != e {
+			continue
+		}
+		copy(s.windows[i:], s.windows[i+1:])
+		s.windows[len(s.windows)-1] = e
+		break
+	}
+	s.reindex()
+	if s.onFront != nil {
+		s.onFront(s, e)
+	}
+}
+
+// BaseIndex returns the render index of the backmost window
+func (s *Stack) BaseIndex() int64 {
+	return s.baseIndex
+}
+
+// SetBaseIndex sets the render index of the backmost window, the rest counting up from it
+func (s *Stack) SetBaseIndex(baseIndex int64) {
+	s.baseIndex = baseIndex
+	s.reindex()
+}
+
+// ScreenSize returns the size restored windows are kept on, 0 if unknown
+func (s *Stack) ScreenSize() (int, int) {
+	return s.screenWidth, s.screenHeight
+}
+
+// SetScreenSize sets the size restored windows are kept on, so a layout saved on a larger screen stays reachable.
+// 0 leaves positions as saved
+func (s *Stack) SetScreenSize(width int, height int) {
+	s.screenWidth = width
+	s.screenHeight = height
+}
+
+// SetOnFront sets a function called with a window brought to the front
+func (s *Stack) SetOnFront(f func(s *Stack, e *Element)) {
+	s.onFront = f
+}
+
+// reindex gives windows render indexes in stack order
+func (s *Stack) reindex() {
+	for i, w := range s.windows {
+		w.renderIndex = s.baseIndex + int64(i)
+	}
+}
+
+// add puts a window at the front, or where it was when its layout was loaded, applying its saved layout
+func (s *Stack) add(e *Element) {
+	if l, ok := s.layouts[e.name]; ok {
+		e.applyLayout(l, s.screenWidth, s.screenHeight)
+	}
+	i := len(s.windows)
+	if rank := s.order[e.name]; rank > 0 {
+		for j, w := range s.windows {
+			if s.order[w.name] == 0 || s.order[w.name] > rank {
+				i = j
+				break
+			}
+		}
+	}
+	s.windows = append(s.windows, nil)
+	copy(s.windows[i+1:], s.windows[i:])
+	s.windows[i] = e
+	s.reindex()
+}
+
+// remove drops a window from the stack, keeping its layout
+func (s *Stack) remove(e *Element) {
+	for i, w := range s.windows {
+		if w != e {
+			continue
+		}
+		s.layouts[e.name] = e.Layout()
+		s.windows = append(s.windows[:i], s.windows[i+1:]...)
+		s.reindex()
+		return
+	}
+}
+
+// topAt returns the frontmost visible window containing x, y, or nil if there is none
+func (s *Stack) topAt(x float64, y float64) *Element {
+	for i := len(s.windows) - 1; i >= 0; i-- {
+		w := s.windows[i]
+		if w.isVisible && w.contains(x, y) {
+			return w
+		}
+	}
+	return nil
+}
+
+// Layouts returns the state of every window from back to front, including removed windows
+func (s *Stack) Layouts() []Layout {
+	var layouts []Layout
+	isAdded := make(map[string]bool)
+	for _, w := range s.windows {
+		isAdded[w.name] = true
+	}
+	// removed windows come first, by name so saved files do not change between runs
+	var names []string
+	for name := range s.layouts {
+		if !isAdded[name] {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		layouts = append(layouts, s.layouts[name])
+	}
+	for _, w := range s.windows {
+		layouts = append(layouts, w.Layout())
+	}
+	return layouts
+}
+
+// SetLayouts restores window states in z-order from back to front. Windows added later are placed as they are added
+func (s *Stack) SetLayouts(layouts []Layout) {
+	s.order = make(map[string]int)
+	for i, l := range layouts {
+		s.layouts[l.Name] = l
+		s.order[l.Name] = i + 1
+	}
+	order := s.order
+	for _, w := range s.windows {
+		if l, ok := s.layouts[w.name]; ok {
+			w.applyLayout(l, s.screenWidth, s.screenHeight)
+		}
+	}
+	// windows without a layout keep their place in front of those with one
+	sorted := make([]*Element, 0, len(s.windows))
+	for i := 1; i <= len(layouts); i++ {
+		for _, w := range s.windows {
+			if order[w.name] == i {
+				sorted = append(sorted, w)
+			}
+		}
+	}
+	for _, w := range s.windows {
+		if order[w.name] == 0 {
+			sorted = append(sorted, w)
+		}
+	}
+	s.windows = sorted
+	s.reindex()
+}
+
+// SaveLayout writes the state of every window as JSON
+func (s *Stack) SaveLayout(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "\t")
+	return enc.Encode(s.Layouts())
+}
+
+// LoadLayout restores window states written by SaveLayout
+func (s *Stack) LoadLayout(r io.Reader) error {
+	var layouts []Layout
+	err := json.NewDecoder(r).Decode(&layouts)
+	if err != nil {
+		return err
+	}
+	s.SetLayouts(layouts)
+	return nil
+}
@@ -0,0 +1,195 @@
+package window
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+)
+
+// newTestWindow returns a window in stack, failing the test on error
+func newTestWindow(t *testing.T, stack *Stack, name string, x float64, y float64, width int, height int) *Element {
+	e, err := New(name, "scene", name, x, y, width, height, nil, nil, nil, stack, "", "")
+	if err != nil {
+		t.Fatalf("new %s: %v", name, err)
+	}
+	return e
+}
+
+// names returns the names of the stack's windows from back to front
+func names(s *Stack) []string {
+	var names []string
+	for _, w := range s.Windows() {
+		names = append(names, w.Name())
+	}
+	return names
+}
+
+func TestStackOrder(t *testing.T) {
+	s := NewStack("stack")
+	a := newTestWindow(t, s, "a", 0, 0, 100, 100)
+	b := newTestWindow(t, s, "b", 0, 0, 100, 100)
+	c := newTestWindow(t, s, "c", 0, 0, 100, 100)
+	var fronts []string
+	s.SetOnFront(func(s *Stack, e *Element) {
+		fronts = append(fronts, e.Name())
+	})
+
+	tests := []struct {
+		name   string
+		action func()
+		want   []string
+		index  []int64
+		front  *Element
+	}{
+		{"added to the front", func() {}, []string{"a", "b", "c"}, []int64{1000, 1001, 1002}, c},
+		{"brought to front", a.BringToFront, []string{"b", "c", "a"}, []int64{1002, 1000, 1001}, a},
+		{"already in front", a.BringToFront, []string{"b", "c", "a"}, []int64{1002, 1000, 1001}, a},
+		{"hidden front", func() { a.SetVisible(false) }, []string{"b", "c", "a"}, []int64{1002, 1000, 1001}, c},
+		{"shown brings to front", func() { b.SetVisible(true); b.SetVisible(false); b.SetVisible(true) }, []string{"c", "a", "b"}, []int64{1001, 1002, 1000}, b},
+		{"base index", func() { s.SetBaseIndex(50) }, []string{"c", "a", "b"}, []int64{51, 52, 50}, b},
+		{"removed", b.OnRemove, []string{"c", "a"}, []int64{51, 52, 50}, c},
+	}
+	for _, tt := range tests {
+		tt.action()
+		if got := names(s); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s: order %v, want %v", tt.name, got, tt.want)
+		}
+		index := []int64{a.RenderIndex(), b.RenderIndex(), c.RenderIndex()}
+		if !reflect.DeepEqual(index, tt.index) {
+			t.Errorf("%s: render indexes %v, want %v", tt.name, index, tt.index)
+		}
+		if s.Front() != tt.front {
+			t.Errorf("%s: front %v, want %s", tt.name, s.Front(), tt.front.Name())
+		}
+	}
+	if want := []string{"a", "b"}; !reflect.DeepEqual(fronts, want) {
+		t.Errorf("front calls %v, want %v", fronts, want)
+	}
+}
+
+func TestStackTopAt(t *testing.T) {
+	s := NewStack("stack")
+	back := newTestWindow(t, s, "back", 0, 0, 100, 100)
+	front := newTestWindow(t, s, "front", 50, 50, 100, 100)
+	tests := []struct {
+		name string
+		x    float64
+		y    float64
+		want *Element
+	}{
+		{"back only", 10, 10, back},
+		{"overlap", 60, 60, front},
+		{"front only", 120, 120, front},
+		{"outside", 200, 10, nil},
+	}
+	for _, tt := range tests {
+		if got := s.topAt(tt.x, tt.y); got != tt.want {
+			t.Errorf("%s: got %v, want %v", tt.name, got, tt.want)
+		}
+	}
+	front.SetVisible(false)
+	if got := s.topAt(60, 60); got != back {
+		t.Errorf("hidden front: got %v, want back", got)
+	}
+}
+
+func TestStackLayouts(t *testing.T) {
+	s := NewStack("stack")
+	a := newTestWindow(t, s, "a", 10, 20, 100, 80)
+	newTestWindow(t, s, "z", 0, 0, 100, 80).OnRemove()
+	newTestWindow(t, s, "y", 0, 0, 100, 80).OnRemove()
+	b := newTestWindow(t, s, "b", 30, 40, 120, 90)
+	b.SetIsMinimized(true)
+	a.BringToFront()
+
+	got := s.Layouts()
+	want := []Layout{
+		// removed windows come first, by name
+		{Name: "y", X: 0, Y: 0, Width: 100, Height: 80, IsVisible: true},
+		{Name: "z", X: 0, Y: 0, Width: 100, Height: 80, IsVisible: true},
+		{Name: "b", X: 30, Y: 40, Width: 120, Height: 90, IsMinimized: true, IsVisible: true},
+		{Name: "a", X: 10, Y: 20, Width: 100, Height: 80, IsVisible: true},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("layouts %+v, want %+v", got, want)
+	}
+}
+
+func TestStackSaveLoad(t *testing.T) {
+	s := NewStack("stack")
+	newTestWindow(t, s, "a", 10, 20, 100, 80)
+	b := newTestWindow(t, s, "b", 30, 40, 120, 90)
+	b.SetIsMinimized(true)
+	c := newTestWindow(t, s, "c", 50, 60, 140, 100)
+	c.SetVisible(false)
+	var buf bytes.Buffer
+	if err := s.SaveLayout(&buf); err != nil {
+		t.Fatalf("save: %v", err)
+	}
+
+	restored := NewStack("stack")
+	if err := restored.LoadLayout(&buf); err != nil {
+		t.Fatalf("load: %v", err)
+	}
+	// windows created in another order or with other sizes go back where they were saved
+	newTestWindow(t, restored, "new", 0, 0, 100, 100)
+	newTestWindow(t, restored, "c", 0, 0, 100, 100)
+	newTestWindow(t, restored, "a", 0, 0, 100, 100)
+	newTestWindow(t, restored, "b", 0, 0, 100, 100)
+	if got, want := names(restored), []string{"a", "b", "c", "new"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("order %v, want %v", got, want)
+	}
+	got := restored.Layouts()
+	want := append(s.Layouts(), Layout{Name: "new", Width: 100, Height: 100, IsVisible: true})
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("layouts %+v, want %+v", got, want)
+	}
+
+	if err := restored.LoadLayout(bytes.NewBufferString("{")); err == nil {
+		t.Errorf("bad json: got nil error")
+	}
+}
+
+func TestStackSetLayouts(t *testing.T) {
+	s := NewStack("stack")
+	newTestWindow(t, s, "a", 0, 0, 100, 100)
+	b := newTestWindow(t, s, "b", 0, 0, 100, 100)
+	newTestWindow(t, s, "c", 0, 0, 100, 100)
+	s.SetLayouts([]Layout{
+		{Name: "c", Width: 100, Height: 100, IsVisible: true},
+		{Name: "a", Width: 100, Height: 100, IsVisible: true},
+	})
+	// windows without a layout stay in front
+	if got, want := names(s), []string{"c", "a", "b"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("order %v, want %v", got, want)
+	}
+	if b.RenderIndex() != 1002 {
+		t.Errorf("render index %d, want 1002", b.RenderIndex())
+	}
+}
+
+func TestStackScreenSize(t *testing.T) {
+	tests := []struct {
+		name   string
+		layout Layout
+		want   Layout
+	}{
+		{"on screen", Layout{X: 10, Y: 20, Width: 100, Height: 80, IsVisible: true}, Layout{X: 10, Y: 20, Width: 100, Height: 80, IsVisible: true}},
+		{"past the right", Layout{X: 500, Y: 20, Width: 100, Height: 80, IsVisible: true}, Layout{X: 100, Y: 20, Width: 100, Height: 80, IsVisible: true}},
+		{"above the top", Layout{X: 10, Y: -20, Width: 100, Height: 80, IsVisible: true}, Layout{X: 10, Y: 0, Width: 100, Height: 80, IsVisible: true}},
+		// only the title bar of a minimized window needs to fit
+		{"minimized", Layout{X: 10, Y: 300, Width: 100, Height: 80, IsMinimized: true}, Layout{X: 10, Y: 126, Width: 100, Height: 80, IsMinimized: true}},
+		{"below min size", Layout{X: 10, Y: 20, Width: 10, Height: 10, IsVisible: true}, Layout{X: 10, Y: 20, Width: 80, Height: 48, IsVisible: true}},
+	}
+	for _, tt := range tests {
+		s := NewStack("stack")
+		s.SetScreenSize(200, 150)
+		tt.layout.Name = "w"
+		tt.want.Name = "w"
+		s.SetLayouts([]Layout{tt.layout})
+		e := newTestWindow(t, s, "w", 0, 0, 100, 100)
+		if got := e.Layout(); got != tt.want {
+			t.Errorf("%s: layout %+v, want %+v", tt.name, got, tt.want)
+		}
+	}
+}
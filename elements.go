@@ -4,6 +4,29 @@ import (
 	"github.com/xackery/egui/element"
 )
 
+// SortMode is how a scene orders elements before drawing
+type SortMode int
+
+const (
+	// SortRenderIndex orders elements by RenderIndex
+	SortRenderIndex = SortMode(0)
+	// SortY orders elements by the Y position of their pivot, ties fall back to RenderIndex
+	SortY = SortMode(1)
+	// SortCustom orders elements with a user provided less function
+	SortCustom = SortMode(2)
+)
+
+func (m SortMode) String() string {
+	switch m {
+	case SortY:
+		return "y"
+	case SortCustom:
+		return "custom"
+	default:
+		return "render index"
+	}
+}
+
 type elements []element.Interfacer
 
 // Len is part of sort.Interface.
@@ -20,3 +43,51 @@ func (e elements) Swap(i, j int) {
 func (e elements) Less(i, j int) bool {
 	return e[i].RenderIndex() < e[j].RenderIndex()
 }
+
+// sortBy is a stable insertion sort. Element order rarely changes between frames,
+// so this runs close to linear when re-evaluated every update
+func (e elements) sortBy(less func(a, b element.Interfacer) bool) {
+	for i := 1; i < len(e); i++ {
+		for j := i; j > 0 && less(e[j], e[j-1]); j-- {
+			e[j], e[j-1] = e[j-1], e[j]
+		}
+	}
+}
+
+// lessRenderIndex orders by render index
+func lessRenderIndex(a, b element.Interfacer) bool {
+	return a.RenderIndex() < b.RenderIndex()
+}
+
+// lessY orders by pivot Y, then render index
+func lessY(a, b element.Interfacer) bool {
+	ay := pivotY(a)
+	by := pivotY(b)
+	if ay != by {
+		return ay < by
+	}
+	return a.RenderIndex() < b.RenderIndex()
+}
+
+// pivotY returns the Y used for depth sorting an element.
+// Elements without a pivot use the bottom of their position and size
+func pivotY(e element.Interfacer) float64 {
+	if p, ok := e.(element.Pivoter); ok {
+		_, y := p.Pivot()
+		return y
+	}
+	p, ok := e.(interface{ Position() (float64, float64) })
+	if !ok {
+		return 0
+	}
+	_, y := p.Position()
+	s, ok := e.(element.Sizer)
+	if !ok {
+		return y
+	}
+	scale := 1.0
+	if sc, ok := e.(interface{ Scale() float64 }); ok {
+		scale = sc.Scale()
+	}
+	return y + float64(s.Height())*scale
+}
@@ -11,7 +11,6 @@ import (
 	"github.com/hajimehoshi/ebiten"
 	"github.com/xackery/egui"
 	"github.com/xackery/egui/aseprite"
-	"github.com/xackery/egui/common"
 	"github.com/xackery/egui/element/label"
 	"golang.org/x/image/colornames"
 )
@@ -105,6 +104,7 @@ func main() {
 	}
 
 	randomBounce()
+	ui.Every(3*time.Second, randomBounce)
 	err = ebiten.Run(ui.Update, screenResolution.X, screenResolution.Y, 2, "Complete Example")
 	if err != nil {
 		fmt.Println("failed to run")
@@ -113,8 +113,7 @@ func main() {
 }
 
 func randomBounce() {
-
 	x := float64(rand.Intn(screenResolution.X - int(lblHello.Width())))
 	y := float64(rand.Intn(screenResolution.Y - int(lblHello.Height())))
-	lblHello.LerpPosition(common.Vect(x, y), 3*time.Second, false, randomBounce)
+	lblHello.LerpPosition(x, y, 3*time.Second, false, nil)
 }
@@ -1,36 +1,39 @@
 package egui
 
 import (
-	"fmt"
+	"image"
+	"io"
 
 	"github.com/golang/freetype/truetype"
 	"github.com/pkg/errors"
+	"github.com/xackery/egui/bmfont"
 	"github.com/xackery/egui/common"
-	"golang.org/x/image/font"
-	"golang.org/x/image/font/gofont/goregular"
 )
 
-// NewFontTTF instantiates a truetype font. truetype.Parse() can be used to load a TTF to fontData
-func (u *UI) NewFontTTF(name string, fontData []byte, opts *truetype.Options, r rune) (*common.Font, error) {
+// NewFont instantiates a font from TTF or OTF data. If opts is nil, common.DefaultFaceOptions are used
+func (u *UI) NewFont(name string, fontData []byte, opts *common.FaceOptions) (*common.Font, error) {
+	return u.NewFontCollection(name, fontData, 0, opts)
+}
+
+// NewFontCollection instantiates a font from TTC or OTC data, index selects which font of the collection to use
+func (u *UI) NewFontCollection(name string, fontData []byte, index int, opts *common.FaceOptions) (*common.Font, error) {
 	if opts == nil {
-		opts = &truetype.Options{Size: 12, DPI: 72, Hinting: font.HintingFull}
+		o := common.DefaultFaceOptions()
+		opts = &o
 	}
 
-	tt, err := truetype.Parse(goregular.TTF)
+	src, err := common.ParseFontSource(fontData, index)
 	if err != nil {
-		return nil, errors.Wrap(err, "parse ttf font")
+		return nil, errors.Wrap(err, "parse font")
 	}
-	f := &common.Font{
-		Name:                name,
-		BoundStringCache:    make(map[font.Face]map[string]*common.BoundStringCacheEntry),
-		RenderingLineHeight: 18,
+	f, err := common.NewFont(name, src, *opts)
+	if err != nil {
+		return nil, errors.Wrap(err, "new font")
 	}
-	f.Face = truetype.NewFace(tt, opts)
-	b, _, ok := f.Face.GlyphBounds(r)
-	if !ok {
-		return nil, fmt.Errorf("calibrate glyph bounds failed")
+	err = f.SetScale(u.textScale)
+	if err != nil {
+		return nil, errors.Wrap(err, "scale font")
 	}
-	f.Height = (b.Max.Y - b.Min.Y).Ceil()
 
 	err = u.AddFont(f)
 	if err != nil {
@@ -38,3 +41,104 @@ func (u *UI) NewFontTTF(name string, fontData []byte, opts *truetype.Options, r
 	}
 	return f, nil
 }
+
+// NewFontBMFont instantiates an AngelCode bitmap font from a text, XML or binary .fnt descriptor.
+// pages are the page images, e.g. PNGs, in page id order. Bitmap fonts cannot be resized
+func (u *UI) NewFontBMFont(name string, fnt io.Reader, pages []io.Reader) (*common.Font, error) {
+	desc, err := bmfont.NewReader(fnt).Read()
+	if err != nil {
+		return nil, errors.Wrap(err, "read descriptor")
+	}
+
+	imgs := make([]image.Image, len(pages))
+	for i, p := range pages {
+		imgs[i], _, err = image.Decode(p)
+		if err != nil {
+			return nil, errors.Wrapf(err, "decode page %d", i)
+		}
+	}
+
+	face, err := bmfont.NewFace(desc, imgs)
+	if err != nil {
+		return nil, errors.Wrap(err, "new face")
+	}
+	f := common.NewFontFromFace(name, face)
+	err = u.AddFont(f)
+	if err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+// NewFontTTF instantiates a truetype font.
+//
+// Deprecated: use NewFont, which also loads OTF data. r is no longer used, height is measured from font metrics
+func (u *UI) NewFontTTF(name string, fontData []byte, opts *truetype.Options, r rune) (*common.Font, error) {
+	o := common.DefaultFaceOptions()
+	if opts != nil {
+		// truetype treats a zero size as 12pt, which the default already is
+		if opts.Size > 0 {
+			o.Size = opts.Size
+		}
+		o.DPI = opts.DPI
+		o.Hinting = opts.Hinting
+	}
+	return u.NewFont(name, fontData, &o)
+}
+
+// SetFontFallbacks sets the named fonts to draw runes the font has no glyph for, tried in order,
+// e.g. a CJK font after a latin one
+func (u *UI) SetFontFallbacks(name string, fallbacks ...string) error {
+	f, err := u.Font(name)
+	if err != nil {
+		return err
+	}
+	fonts := make([]*common.Font, len(fallbacks))
+	for i, fb := range fallbacks {
+		fonts[i], err = u.Font(fb)
+		if err != nil {
+			return errors.Wrap(err, fb)
+		}
+	}
+	f.SetFallbacks(fonts...)
+	return nil
+}
+
+// FontSize returns the named font at size points. Sizes are cached and rescale with the UI
+func (u *UI) FontSize(name string, size float64) (*common.Font, error) {
+	f, err := u.Font(name)
+	if err != nil {
+		return nil, err
+	}
+	return f.Size(size)
+}
+
+// SetScale changes the scale of the UI, rasterizing every font at the new scale
+func (u *UI) SetScale(scale float64) error {
+	if scale <= 0 {
+		scale = 1
+	}
+	u.tileScale = scale
+	u.textScale = scale
+	for _, f := range u.fonts {
+		err := f.SetScale(scale)
+		if err != nil {
+			return errors.Wrap(err, f.Name)
+		}
+	}
+	return nil
+}
+
+// Scale returns the scale of the UI
+func (u *UI) Scale() float64 {
+	return u.textScale
+}
+
+// TextCacheStats returns measured string cache statistics summed over every font
+func (u *UI) TextCacheStats() common.CacheStats {
+	var stats common.CacheStats
+	for _, f := range u.fonts {
+		stats = stats.Add(f.CacheStats())
+	}
+	return stats
+}
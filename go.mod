@@ -3,11 +3,12 @@ module github.com/xackery/egui
 go 1.12
 
 require (
+	github.com/go-text/typesetting v0.2.1
 	github.com/golang/freetype v0.0.0-20170609003504-e2365dfdc4a0
 	github.com/hajimehoshi/ebiten v1.11.0-alpha.3.0.20200123132807-94d0f1137c4e
 	github.com/pkg/errors v0.9.1
 	golang.org/x/exp v0.0.0-20200119233911-0405dc783f0a // indirect
-	golang.org/x/image v0.0.0-20200119044424-58c23975cae1
+	golang.org/x/image v0.18.0
 	golang.org/x/mobile v0.0.0-20200123024942-82c397c4c527 // indirect
-	golang.org/x/text v0.3.8
+	golang.org/x/text v0.16.0
 )
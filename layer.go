@@ -0,0 +1,282 @@
+package egui
+
+import (
+	"image"
+
+	"github.com/hajimehoshi/ebiten"
+	"github.com/xackery/egui/common"
+	"github.com/xackery/egui/element"
+)
+
+const (
+	// LayerBackground is drawn first, behind everything else
+	LayerBackground = "background"
+	// LayerWorld contains maps, sprites and other game objects
+	LayerWorld = "world"
+	// LayerWorldOverlay is drawn above the world, e.g. name plates and effects
+	LayerWorldOverlay = "world-overlay"
+	// LayerHUD contains heads up display elements
+	LayerHUD = "hud"
+	// LayerPopup contains menus and dialogs drawn above the HUD
+	LayerPopup = "popup"
+	// LayerDebug is drawn last, above everything else
+	LayerDebug = "debug"
+)
+
+// defaultLayers are created on every scene, in draw order
+var defaultLayers = []string{LayerBackground, LayerWorld, LayerWorldOverlay, LayerHUD, LayerPopup, LayerDebug}
+
+// Layer is a named group of elements inside a scene.
+// Each layer sorts, shows and fades independently
+type Layer struct {
+	name                      string
+	isVisible                 bool
+	opacity                   float64
+	camera                    *common.Camera
+	sortMode                  SortMode
+	sortFunc                  func(a, b element.Interfacer) bool
+	isElementsNextUpdateDirty bool
+	elementsNextUpdate        elements
+	elements                  elements
+	size                      image.Point
+	buffer                    *ebiten.Image
+}
+
+// newLayer initializes a layer
+func newLayer(name string, sortMode SortMode, size image.Point) *Layer {
+	return &Layer{
+		name:      name,
+		isVisible: true,
+		opacity:   1,
+		sortMode:  sortMode,
+		size:      size,
+	}
+}
+
+// Name returns the name of a layer
+func (l *Layer) Name() string {
+	return l.name
+}
+
+// IsVisible returns true if a layer is drawn
+func (l *Layer) IsVisible() bool {
+	return l.isVisible
+}
+
+// SetVisible changes if a layer is drawn. Hidden layers still update
+func (l *Layer) SetVisible(isVisible bool) {
+	l.isVisible = isVisible
+}
+
+// Opacity returns the opacity of a layer, 0 to 1
+func (l *Layer) Opacity() float64 {
+	return l.opacity
+}
+
+// SetOpacity sets the opacity of a layer, ceil(1), floor(0)
+func (l *Layer) SetOpacity(opacity float64) {
+	if opacity < 0 {
+		opacity = 0
+	}
+	if opacity > 1 {
+		opacity = 1
+	}
+	l.opacity = opacity
+}
+
+// Camera returns the camera of a layer, nil if none is set
+func (l *Layer) Camera() *common.Camera {
+	return l.camera
+}
+
+// SetCamera sets a camera to view the layer through, nil to disable
+func (l *Layer) SetCamera(camera *common.Camera) {
+	l.camera = camera
+}
+
+// Size returns the size of the layer canvas
+func (l *Layer) Size() image.Point {
+	return l.size
+}
+
+// SetSize sets the size of the layer canvas. Defaults to the screen resolution,
+// a larger size lets a camera pan across a world bigger than the screen
+func (l *Layer) SetSize(size image.Point) {
+	l.size = size
+	l.disposeBuffer()
+}
+
+// SetSortMode changes how elements in the layer are ordered before drawing
+func (l *Layer) SetSortMode(mode SortMode) {
+	l.sortMode = mode
+}
+
+// SortMode returns how elements in the layer are ordered before drawing
+func (l *Layer) SortMode() SortMode {
+	return l.sortMode
+}
+
+// SetSortFunc sets a custom less function and switches the layer to SortCustom
+func (l *Layer) SetSortFunc(less func(a, b element.Interfacer) bool) {
+	l.sortFunc = less
+	l.sortMode = SortCustom
+}
+
+// Element returns an element in the layer based on name
+func (l *Layer) Element(name string) (element.Interfacer, error) {
+	if name == "" {
+		return nil, common.ErrElementNameInvalid
+	}
+	for _, le := range l.elementsNextUpdate {
+		if le.Name() != name {
+			continue
+		}
+		return le, nil
+	}
+	return nil, common.ErrElementNotFound
+}
+
+// addElement appends an element to the layer
+func (l *Layer) addElement(e element.Interfacer) {
+	l.elementsNextUpdate = append(l.elementsNextUpdate, e)
+	l.isElementsNextUpdateDirty = true
+	l.sort(l.elementsNextUpdate)
+}
+
+// removeElement flags an element to be removed next update
+func (l *Layer) removeElement(name string) error {
+	var isFound bool
+	for i := range l.elementsNextUpdate {
+		if l.elementsNextUpdate[i].Name() != name {
+			continue
+		}
+		isFound = true
+		l.elementsNextUpdate[i] = l.elementsNextUpdate[len(l.elementsNextUpdate)-1]
+		l.elementsNextUpdate = l.elementsNextUpdate[:len(l.elementsNextUpdate)-1]
+		break
+	}
+	if !isFound {
+		return common.ErrElementNotFound
+	}
+	l.sort(l.elementsNextUpdate)
+	l.isElementsNextUpdateDirty = true
+	return nil
+}
+
+// sort orders provided elements based on the layer sort mode
+func (l *Layer) sort(e elements) {
+	switch l.sortMode {
+	case SortY:
+		e.sortBy(lessY)
+	case SortCustom:
+		if l.sortFunc == nil {
+			e.sortBy(lessRenderIndex)
+			return
+		}
+		e.sortBy(l.sortFunc)
+	default:
+		e.sortBy(lessRenderIndex)
+	}
+}
+
+// update is called during a frame update
+func (l *Layer) update(dt float64) {
+	if l.isElementsNextUpdateDirty {
+		l.elements = make(elements, len(l.elementsNextUpdate))
+		copy(l.elements, l.elementsNextUpdate)
+		l.isElementsNextUpdateDirty = false
+	}
+
+	l.claimPointer()
+	for _, e := range l.elements {
+		e.Update(dt)
+		if !e.IsDestroyed() {
+			continue
+		}
+		if l.removeElement(e.Name()) != nil {
+			continue
+		}
+		if r, ok := e.(element.Remover); ok {
+			r.OnRemove()
+		}
+	}
+
+	// render index and positions may have changed during update
+	l.sort(l.elements)
+}
+
+// claimPointer offers a press that started this update to the frontmost element claiming it
+func (l *Layer) claimPointer() {
+	x, y, ok := common.PointerJustPressed()
+	if !ok {
+		return
+	}
+	for i := len(l.elements) - 1; i >= 0; i-- {
+		c, ok := l.elements[i].(element.PointerClaimer)
+		if ok && c.ClaimPointer(x, y) {
+			return
+		}
+	}
+}
+
+// draw renders the layer on a destination image
+func (l *Layer) draw(screen *ebiten.Image) {
+	if !l.isVisible || l.opacity == 0 {
+		return
+	}
+	if l.camera == nil && l.opacity >= 1 {
+		l.drawElements(screen)
+		return
+	}
+
+	if l.buffer == nil {
+		if l.size.X < 1 || l.size.Y < 1 {
+			return
+		}
+		var err error
+		l.buffer, err = ebiten.NewImage(l.size.X, l.size.Y, ebiten.FilterDefault)
+		if err != nil {
+			//TODO: handle this error elegantly
+			return
+		}
+	}
+	l.buffer.Clear()
+	l.drawElements(l.buffer)
+
+	op := &ebiten.DrawImageOptions{}
+	if l.camera != nil {
+		op.GeoM = l.camera.GeoM()
+	}
+	op.ColorM.Scale(1, 1, 1, l.opacity)
+	screen.DrawImage(l.buffer, op)
+}
+
+func (l *Layer) drawElements(dst *ebiten.Image) {
+	for _, e := range l.elements {
+		if !e.IsVisible() {
+			continue
+		}
+		e.Draw(dst)
+	}
+}
+
+func (l *Layer) disposeBuffer() {
+	if l.buffer == nil {
+		return
+	}
+	l.buffer.Dispose()
+	l.buffer = nil
+}
+
+func (l *Layer) onResolutionChange(resolution image.Point) {
+	if l.size.X >= resolution.X && l.size.Y >= resolution.Y {
+		return
+	}
+	if l.size.X < resolution.X {
+		l.size.X = resolution.X
+	}
+	if l.size.Y < resolution.Y {
+		l.size.Y = resolution.Y
+	}
+	l.disposeBuffer()
+}
@@ -0,0 +1,166 @@
+package egui
+
+import (
+	"image"
+	"reflect"
+	"testing"
+
+	"github.com/xackery/egui/common"
+)
+
+func TestSceneDefaultLayers(t *testing.T) {
+	s := &Scene{}
+	if s.DefaultLayer() == nil || s.DefaultLayer().Name() != LayerWorld {
+		t.Fatalf("default layer %v, want %s", s.DefaultLayer(), LayerWorld)
+	}
+	if _, err := s.NewLayer("effects"); err != nil {
+		t.Fatalf("new layer: %v", err)
+	}
+	var names []string
+	for _, l := range s.layers {
+		names = append(names, l.Name())
+	}
+	want := append(append([]string{}, defaultLayers...), "effects")
+	if !reflect.DeepEqual(names, want) {
+		t.Errorf("layers %v, want %v", names, want)
+	}
+}
+
+func TestSceneLayerErrors(t *testing.T) {
+	s := &Scene{}
+	tests := []struct {
+		name string
+		err  error
+		want error
+	}{
+		{"new empty name", newLayerErr(s, ""), common.ErrLayerNameInvalid},
+		{"new existing", newLayerErr(s, LayerHUD), common.ErrLayerAlreadyExists},
+		{"missing", layerErr(s, "missing"), common.ErrLayerNotFound},
+		{"remove missing", s.RemoveLayer("missing"), common.ErrLayerNotFound},
+		{"default missing", s.SetDefaultLayer("missing"), common.ErrLayerNotFound},
+		{"visible missing", s.SetLayerVisible("missing", false), common.ErrLayerNotFound},
+	}
+	for _, tt := range tests {
+		if tt.err != tt.want {
+			t.Errorf("%s: got %v, want %v", tt.name, tt.err, tt.want)
+		}
+	}
+}
+
+func newLayerErr(s *Scene, name string) error {
+	_, err := s.NewLayer(name)
+	return err
+}
+
+func layerErr(s *Scene, name string) error {
+	_, err := s.Layer(name)
+	return err
+}
+
+func TestSceneLayerDrawOrder(t *testing.T) {
+	var drawn []string
+	s := &Scene{}
+	add := func(name string, renderIndex int64, layer string) {
+		e := &testElement{name: name, renderIndex: renderIndex, drawn: &drawn}
+		if err := s.AddElementToLayer(e, layer); err != nil {
+			t.Fatalf("add %s: %v", name, err)
+		}
+	}
+	// render index only orders elements inside a layer
+	add("debug", 0, LayerDebug)
+	add("hud", 1, LayerHUD)
+	add("background", 2, LayerBackground)
+	add("world", 3, LayerWorld)
+
+	tests := []struct {
+		name  string
+		setup func()
+		want  []string
+	}{
+		{"all", func() {}, []string{"background", "world", "hud", "debug"}},
+		{"hidden hud", func() { s.SetLayerVisible(LayerHUD, false) }, []string{"background", "world", "debug"}},
+		{"shown hud", func() { s.SetLayerVisible(LayerHUD, true) }, []string{"background", "world", "hud", "debug"}},
+		{"transparent world", func() {
+			l, _ := s.Layer(LayerWorld)
+			l.SetOpacity(0)
+		}, []string{"background", "hud", "debug"}},
+		{"removed debug", func() { s.RemoveLayer(LayerDebug) }, []string{"background", "hud"}},
+	}
+	for _, tt := range tests {
+		tt.setup()
+		drawn = nil
+		s.Update(0)
+		s.Draw(nil)
+		if !reflect.DeepEqual(drawn, tt.want) {
+			t.Errorf("%s: drew %v, want %v", tt.name, drawn, tt.want)
+		}
+	}
+}
+
+func TestSceneSetElementLayer(t *testing.T) {
+	var drawn []string
+	s := &Scene{}
+	if err := s.AddElement(&testElement{name: "a", drawn: &drawn}); err != nil {
+		t.Fatalf("add: %v", err)
+	}
+	if err := s.AddElementToLayer(&testElement{name: "a", drawn: &drawn}, LayerHUD); err != common.ErrElementAlreadyExists {
+		t.Errorf("add duplicate: got %v, want %v", err, common.ErrElementAlreadyExists)
+	}
+	if err := s.SetElementLayer("a", LayerPopup); err != nil {
+		t.Fatalf("move: %v", err)
+	}
+	l, err := s.ElementLayer("a")
+	if err != nil {
+		t.Fatalf("element layer: %v", err)
+	}
+	if l.Name() != LayerPopup {
+		t.Errorf("element layer %s, want %s", l.Name(), LayerPopup)
+	}
+	if err := s.SetElementLayer("missing", LayerPopup); err != common.ErrElementNotFound {
+		t.Errorf("move missing: got %v, want %v", err, common.ErrElementNotFound)
+	}
+	if err := s.RemoveElement("a"); err != nil {
+		t.Fatalf("remove: %v", err)
+	}
+	if _, err := s.Element("a"); err != common.ErrElementNotFound {
+		t.Errorf("removed element: got %v, want %v", err, common.ErrElementNotFound)
+	}
+}
+
+func TestLayerSetOpacity(t *testing.T) {
+	tests := []struct {
+		opacity float64
+		want    float64
+	}{
+		{0.5, 0.5},
+		{-1, 0},
+		{2, 1},
+	}
+	for _, tt := range tests {
+		l := newLayer("test", SortRenderIndex, image.Point{})
+		l.SetOpacity(tt.opacity)
+		if l.Opacity() != tt.want {
+			t.Errorf("opacity %v: got %v, want %v", tt.opacity, l.Opacity(), tt.want)
+		}
+	}
+}
+
+func TestLayerResolutionChange(t *testing.T) {
+	tests := []struct {
+		name       string
+		size       image.Point
+		resolution image.Point
+		want       image.Point
+	}{
+		{"grows", image.Pt(100, 100), image.Pt(200, 150), image.Pt(200, 150)},
+		{"keeps larger world", image.Pt(1000, 50), image.Pt(200, 150), image.Pt(1000, 150)},
+		{"never shrinks", image.Pt(300, 300), image.Pt(200, 150), image.Pt(300, 300)},
+	}
+	for _, tt := range tests {
+		l := newLayer("test", SortRenderIndex, tt.size)
+		l.onResolutionChange(tt.resolution)
+		if l.Size() != tt.want {
+			t.Errorf("%s: size %v, want %v", tt.name, l.Size(), tt.want)
+		}
+	}
+}
@@ -0,0 +1,25 @@
+package egui
+
+import (
+	"github.com/xackery/egui/common"
+	"github.com/xackery/egui/element/listview"
+)
+
+// NewListView creates a new virtualized list instance showing items of source.
+// Rows are rowHeight high unless source implements listview.RowHeighter
+func (u *UI) NewListView(name string, scene string, x float64, y float64, width int, height int, source listview.DataSource, rowHeight int) (*listview.Element, error) {
+	s, err := u.Scene(scene)
+	if err != nil {
+		return nil, common.ErrSceneNotFound
+	}
+
+	e, err := listview.New(name, scene, x, y, width, height, source, rowHeight)
+	if err != nil {
+		return nil, err
+	}
+	err = s.AddElement(e)
+	if err != nil {
+		return nil, err
+	}
+	return e, nil
+}
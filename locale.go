@@ -0,0 +1,95 @@
+package egui
+
+import (
+	"io"
+
+	"github.com/xackery/egui/common"
+	"github.com/xackery/egui/element"
+	"golang.org/x/text/language"
+)
+
+// textKey is a catalog key bound to an element's text
+type textKey struct {
+	key  string
+	args []interface{}
+}
+
+// Catalog returns the message catalog, falling back to the default language for missing translations
+func (u *UI) Catalog() *common.Catalog {
+	return u.catalog
+}
+
+// LoadCatalogJSON adds messages of a language from a JSON catalog, see common.Catalog.LoadJSON
+func (u *UI) LoadCatalogJSON(r io.Reader) error {
+	err := u.catalog.LoadJSON(r)
+	if err != nil {
+		return err
+	}
+	u.refreshText()
+	return nil
+}
+
+// LoadCatalogPO adds messages of a language from a gettext PO file
+func (u *UI) LoadCatalogPO(r io.Reader, tag language.Tag) error {
+	err := u.catalog.LoadPO(r, tag)
+	if err != nil {
+		return err
+	}
+	u.refreshText()
+	return nil
+}
+
+// Language returns the language text is shown in
+func (u *UI) Language() language.Tag {
+	return u.language
+}
+
+// SetLanguage changes the language text is shown in, updating every element bound with SetTextKey
+func (u *UI) SetLanguage(tag language.Tag) {
+	u.language = tag
+	u.refreshText()
+}
+
+// IsPseudoLocalized returns true if text is pseudo-localized
+func (u *UI) IsPseudoLocalized() bool {
+	return u.isPseudoLocale
+}
+
+// SetIsPseudoLocalized sets if text is accented and lengthened by about a third,
+// to find strings that are not localized or truncate once translated
+func (u *UI) SetIsPseudoLocalized(isPseudoLocalized bool) {
+	u.isPseudoLocale = isPseudoLocalized
+	u.refreshText()
+}
+
+// Text returns the message for key in the current language, formatted with args
+func (u *UI) Text(key string, args ...interface{}) string {
+	text := u.catalog.Text(u.language, key, args...)
+	if u.isPseudoLocale {
+		text = common.PseudoLocalize(text)
+	}
+	return text
+}
+
+// SetTextKey sets the text of an element to the message for key, formatted with args.
+// The text follows language changes until the element is destroyed or bound to another key
+func (u *UI) SetTextKey(e element.Interfacer, key string, args ...interface{}) {
+	u.textKeys[e] = &textKey{key: key, args: args}
+	e.SetText(u.Text(key, args...))
+}
+
+// RemoveTextKey unbinds an element from its key, leaving its current text
+func (u *UI) RemoveTextKey(e element.Interfacer) {
+	delete(u.textKeys, e)
+}
+
+// refreshText resolves the text of every bound element again, dropping destroyed elements
+func (u *UI) refreshText() {
+	for e, tk := range u.textKeys {
+		if e.IsDestroyed() {
+			delete(u.textKeys, e)
+			continue
+		}
+		e.SetText(u.Text(tk.key, tk.args...))
+	}
+}
@@ -0,0 +1,171 @@
+package egui
+
+import (
+	"time"
+
+	"github.com/hajimehoshi/ebiten"
+)
+
+// Loop runs game logic at a fixed tick rate, while the UI updates and draws once per frame.
+// Pass Loop.Update to ebiten.Run in place of UI.Update
+type Loop struct {
+	ui          *UI
+	tickRate    int
+	tickDelta   float64
+	maxCatchUp  int
+	accumulator float64
+	tick        uint64
+	alpha       float64
+	lastUpdate  time.Time
+	isStarted   bool
+	tickFunc    func(tick uint64, dt float64) error
+	drawFunc    func(screen *ebiten.Image, alpha float64)
+	stats       LoopStats
+	totalTime   time.Duration
+}
+
+// LoopStats contains frame timing statistics of a loop
+type LoopStats struct {
+	// Frames is how many frames have been stepped
+	Frames uint64
+	// Ticks is how many fixed ticks have run
+	Ticks uint64
+	// DroppedTicks is how many ticks were skipped because a frame exceeded max catch up
+	DroppedTicks uint64
+	// TicksLastFrame is how many ticks ran during the last frame
+	TicksLastFrame int
+	// FrameTime is the duration of the last frame
+	FrameTime time.Duration
+	// AverageFrameTime is the mean duration of all frames
+	AverageFrameTime time.Duration
+	// MinFrameTime is the shortest frame seen
+	MinFrameTime time.Duration
+	// MaxFrameTime is the longest frame seen
+	MaxFrameTime time.Duration
+}
+
+// NewLoop creates a loop ticking tickRate times a second.
+// maxCatchUp limits how many ticks run in a single frame after a stall, 0 uses 5
+func (u *UI) NewLoop(tickRate int, maxCatchUp int) *Loop {
+	if tickRate < 1 {
+		tickRate = ebiten.DefaultTPS
+	}
+	if maxCatchUp < 1 {
+		maxCatchUp = 5
+	}
+	return &Loop{
+		ui:         u,
+		tickRate:   tickRate,
+		tickDelta:  1 / float64(tickRate),
+		maxCatchUp: maxCatchUp,
+	}
+}
+
+// SetTickFunc sets the fixed step game logic function. dt is always 1/tickRate
+func (l *Loop) SetTickFunc(f func(tick uint64, dt float64) error) {
+	l.tickFunc = f
+}
+
+// SetDrawFunc sets a function to draw the game world before the UI.
+// alpha is how far between the last and next tick the frame is, 0 to 1, for interpolating positions
+func (l *Loop) SetDrawFunc(f func(screen *ebiten.Image, alpha float64)) {
+	l.drawFunc = f
+}
+
+// Update steps the loop using the wall clock, and is meant to be passed to ebiten.Run
+func (l *Loop) Update(screen *ebiten.Image) error {
+	now := time.Now()
+	if !l.isStarted {
+		l.isStarted = true
+		l.lastUpdate = now
+	}
+	dt := now.Sub(l.lastUpdate).Seconds()
+	l.lastUpdate = now
+	return l.Step(screen, dt)
+}
+
+// Step advances the loop by dt seconds without reading the wall clock. Ticks are scaled and
+// paused with the UI clock. screen may be nil to skip drawing, e.g. in tests
+func (l *Loop) Step(screen *ebiten.Image, dt float64) error {
+	l.recordFrame(dt)
+
+	scaled := dt * l.ui.clock.TimeScale()
+	if l.ui.clock.IsPaused() {
+		scaled = 0
+	}
+	l.accumulator += scaled
+
+	ticks := 0
+	for l.accumulator >= l.tickDelta {
+		if ticks >= l.maxCatchUp {
+			dropped := int(l.accumulator / l.tickDelta)
+			l.stats.DroppedTicks += uint64(dropped)
+			l.accumulator -= float64(dropped) * l.tickDelta
+			break
+		}
+		if l.tickFunc != nil {
+			err := l.tickFunc(l.tick, l.tickDelta)
+			if err != nil {
+				return err
+			}
+		}
+		l.tick++
+		l.stats.Ticks++
+		l.accumulator -= l.tickDelta
+		ticks++
+	}
+	l.stats.TicksLastFrame = ticks
+	l.alpha = l.accumulator / l.tickDelta
+
+	// the UI stays variable step
+	l.ui.Step(dt)
+
+	if screen == nil || ebiten.IsDrawingSkipped() {
+		return nil
+	}
+	if l.drawFunc != nil {
+		l.drawFunc(screen, l.alpha)
+	}
+	l.ui.Draw(screen)
+	return nil
+}
+
+func (l *Loop) recordFrame(dt float64) {
+	frameTime := time.Duration(dt * float64(time.Second))
+	l.stats.Frames++
+	l.stats.FrameTime = frameTime
+	l.totalTime += frameTime
+	l.stats.AverageFrameTime = l.totalTime / time.Duration(l.stats.Frames)
+	if l.stats.Frames == 1 || frameTime < l.stats.MinFrameTime {
+		l.stats.MinFrameTime = frameTime
+	}
+	if frameTime > l.stats.MaxFrameTime {
+		l.stats.MaxFrameTime = frameTime
+	}
+}
+
+// Alpha returns how far between the last and next tick the current frame is, 0 to 1
+func (l *Loop) Alpha() float64 {
+	return l.alpha
+}
+
+// Tick returns how many ticks have run
+func (l *Loop) Tick() uint64 {
+	return l.tick
+}
+
+// TickRate returns how many ticks run per second
+func (l *Loop) TickRate() int {
+	return l.tickRate
+}
+
+// Stats returns frame timing statistics
+func (l *Loop) Stats() LoopStats {
+	return l.stats
+}
+
+// ResetStats clears frame timing statistics
+func (l *Loop) ResetStats() {
+	l.stats = LoopStats{}
+	l.totalTime = 0
+}
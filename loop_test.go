@@ -0,0 +1,127 @@
+package egui
+
+import (
+	"errors"
+	"image"
+	"testing"
+	"time"
+)
+
+func newTestUI(t *testing.T) *UI {
+	u, err := NewUI(image.Pt(320, 240), 1)
+	if err != nil {
+		t.Fatalf("new ui: %v", err)
+	}
+	return u
+}
+
+func TestLoopStep(t *testing.T) {
+	tests := []struct {
+		name           string
+		maxCatchUp     int
+		timeScale      float64
+		isPaused       bool
+		steps          []float64
+		ticks          uint64
+		dropped        uint64
+		ticksLastFrame int
+		alpha          float64
+	}{
+		{"uneven", 3, 1, false, []float64{0.125, 0.5, 0.375}, 4, 0, 2, 0},
+		{"partial tick", 3, 1, false, []float64{0.375}, 1, 0, 1, 0.5},
+		{"catch up clamped", 3, 1, false, []float64{2}, 3, 5, 3, 0},
+		{"catch up keeps remainder", 3, 1, false, []float64{2.125}, 3, 5, 3, 0.5},
+		{"slow motion", 3, 0.5, false, []float64{0.5, 0.25}, 1, 0, 0, 0.5},
+		{"paused", 3, 1, true, []float64{1, 1}, 0, 0, 0, 0},
+	}
+	for _, tt := range tests {
+		u := newTestUI(t)
+		u.Clock().SetTimeScale(tt.timeScale)
+		u.Clock().SetPaused(tt.isPaused)
+		l := u.NewLoop(4, tt.maxCatchUp)
+		var got []uint64
+		l.SetTickFunc(func(tick uint64, dt float64) error {
+			if dt != 0.25 {
+				t.Errorf("%s: tick dt %v, want 0.25", tt.name, dt)
+			}
+			got = append(got, tick)
+			return nil
+		})
+		for _, dt := range tt.steps {
+			if err := l.Step(nil, dt); err != nil {
+				t.Fatalf("%s: step: %v", tt.name, err)
+			}
+		}
+		if l.Tick() != tt.ticks {
+			t.Errorf("%s: tick %d, want %d", tt.name, l.Tick(), tt.ticks)
+		}
+		for i, tick := range got {
+			if tick != uint64(i) {
+				t.Errorf("%s: tick func %d got tick %d", tt.name, i, tick)
+			}
+		}
+		stats := l.Stats()
+		if stats.Ticks != tt.ticks || stats.DroppedTicks != tt.dropped || stats.TicksLastFrame != tt.ticksLastFrame {
+			t.Errorf("%s: stats ticks %d dropped %d last frame %d, want %d %d %d", tt.name,
+				stats.Ticks, stats.DroppedTicks, stats.TicksLastFrame, tt.ticks, tt.dropped, tt.ticksLastFrame)
+		}
+		if l.Alpha() != tt.alpha {
+			t.Errorf("%s: alpha %v, want %v", tt.name, l.Alpha(), tt.alpha)
+		}
+	}
+}
+
+func TestLoopDefaults(t *testing.T) {
+	l := newTestUI(t).NewLoop(0, 0)
+	if l.TickRate() != 60 {
+		t.Errorf("tick rate %d, want 60", l.TickRate())
+	}
+	l.Step(nil, 1)
+	if l.Stats().TicksLastFrame != 5 {
+		t.Errorf("ticks last frame %d, want 5", l.Stats().TicksLastFrame)
+	}
+}
+
+func TestLoopTickError(t *testing.T) {
+	errTick := errors.New("tick")
+	l := newTestUI(t).NewLoop(4, 3)
+	l.SetTickFunc(func(tick uint64, dt float64) error {
+		if tick == 1 {
+			return errTick
+		}
+		return nil
+	})
+	if err := l.Step(nil, 0.75); err != errTick {
+		t.Errorf("step error %v, want %v", err, errTick)
+	}
+	if l.Tick() != 1 {
+		t.Errorf("tick %d, want 1", l.Tick())
+	}
+}
+
+func TestLoopStats(t *testing.T) {
+	l := newTestUI(t).NewLoop(4, 3)
+	for _, dt := range []float64{0.25, 0.125, 0.375} {
+		l.Step(nil, dt)
+	}
+	stats := l.Stats()
+	if stats.Frames != 3 {
+		t.Errorf("frames %d, want 3", stats.Frames)
+	}
+	if stats.FrameTime != 375*time.Millisecond {
+		t.Errorf("frame time %v, want 375ms", stats.FrameTime)
+	}
+	if stats.MinFrameTime != 125*time.Millisecond || stats.MaxFrameTime != 375*time.Millisecond {
+		t.Errorf("min %v max %v, want 125ms 375ms", stats.MinFrameTime, stats.MaxFrameTime)
+	}
+	if stats.AverageFrameTime != 250*time.Millisecond {
+		t.Errorf("average %v, want 250ms", stats.AverageFrameTime)
+	}
+
+	l.ResetStats()
+	l.Step(nil, 0.5)
+	stats = l.Stats()
+	if stats.Frames != 1 || stats.MinFrameTime != 500*time.Millisecond || stats.AverageFrameTime != 500*time.Millisecond {
+		t.Errorf("after reset frames %d min %v average %v, want 1 500ms 500ms", stats.Frames, stats.MinFrameTime, stats.AverageFrameTime)
+	}
+}
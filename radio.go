@@ -0,0 +1,35 @@
+package egui
+
+import (
+	"image/color"
+
+	"github.com/pkg/errors"
+	"github.com/xackery/egui/common"
+	"github.com/xackery/egui/element/radio"
+)
+
+// NewRadioGroup creates a group that radios created with it select one of
+func (u *UI) NewRadioGroup(name string) *radio.Group {
+	return radio.NewGroup(name)
+}
+
+// NewRadio creates a new radio instance in group, drawn with 9slices of the ui image
+func (u *UI) NewRadio(name string, scene string, text string, x float64, y float64, width int, height int, textColor color.Color, group *radio.Group, uncheckedSliceName string, checkedSliceName string) (*radio.Element, error) {
+	imageName := "ui"
+	img, err := u.Image(imageName)
+	if err != nil {
+		return nil, errors.Wrap(err, imageName)
+	}
+
+	s, err := u.Scene(scene)
+	if err != nil {
+		return nil, common.ErrSceneNotFound
+	}
+
+	e, err := radio.New(name, scene, text, x, y, width, height, u.defaultFont, textColor, img, group, uncheckedSliceName, checkedSliceName)
+	err = s.AddElement(e)
+	if err != nil {
+		return nil, err
+	}
+	return e, nil
+}
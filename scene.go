@@ -2,7 +2,7 @@ package egui
 
 import (
 	"image"
-	"sort"
+	"time"
 
 	"github.com/hajimehoshi/ebiten"
 	"github.com/xackery/egui/common"
@@ -11,9 +11,15 @@ import (
 
 // Scene represents a layout of ui
 type Scene struct {
-	isElementsNextUpdateDirty bool
-	elementsNextUpdate        elements
-	elements                  elements
+	layers       []*Layer
+	defaultLayer *Layer
+	sortMode     SortMode
+	sortFunc     func(a, b element.Interfacer) bool
+	resolution   image.Point
+	tweens       *common.TweenManager
+	clock        *common.Clock
+	timers       *common.Scheduler
+	sequences    []*common.Sequence
 }
 
 // NewScene initializes a new scene
@@ -26,84 +32,413 @@ func (ui *UI) NewScene(name string) (*Scene, error) {
 	return s, nil
 }
 
+// init creates the clock and default layers if a scene has none
+func (s *Scene) init() {
+	if s.clock == nil {
+		s.clock = common.NewClock()
+	}
+	if s.timers == nil {
+		s.timers = common.NewScheduler()
+	}
+	if len(s.layers) > 0 {
+		return
+	}
+	for _, name := range defaultLayers {
+		s.layers = append(s.layers, s.newLayer(name))
+	}
+	s.defaultLayer, _ = s.Layer(LayerWorld)
+}
+
+// NewLayer adds a new layer drawn above all existing layers
+func (s *Scene) NewLayer(name string) (*Layer, error) {
+	if name == "" {
+		return nil, common.ErrLayerNameInvalid
+	}
+	s.init()
+	_, err := s.Layer(name)
+	if err == nil {
+		return nil, common.ErrLayerAlreadyExists
+	}
+	l := s.newLayer(name)
+	s.layers = append(s.layers, l)
+	return l, nil
+}
+
+// newLayer creates a layer inheriting the scene sort settings
+func (s *Scene) newLayer(name string) *Layer {
+	l := newLayer(name, s.sortMode, s.resolution)
+	l.sortFunc = s.sortFunc
+	return l
+}
+
+// Layer returns a layer based on name
+func (s *Scene) Layer(name string) (*Layer, error) {
+	if name == "" {
+		return nil, common.ErrLayerNameInvalid
+	}
+	s.init()
+	for _, l := range s.layers {
+		if l.name != name {
+			continue
+		}
+		return l, nil
+	}
+	return nil, common.ErrLayerNotFound
+}
+
+// RemoveLayer removes a layer and all elements inside it
+func (s *Scene) RemoveLayer(name string) error {
+	l, err := s.Layer(name)
+	if err != nil {
+		return err
+	}
+	if l == s.defaultLayer {
+		s.defaultLayer = nil
+	}
+	for i := range s.layers {
+		if s.layers[i] != l {
+			continue
+		}
+		s.layers = append(s.layers[:i], s.layers[i+1:]...)
+		break
+	}
+	l.disposeBuffer()
+	return nil
+}
+
+// SetDefaultLayer sets the layer AddElement places elements in
+func (s *Scene) SetDefaultLayer(name string) error {
+	l, err := s.Layer(name)
+	if err != nil {
+		return err
+	}
+	s.defaultLayer = l
+	return nil
+}
+
+// DefaultLayer returns the layer AddElement places elements in
+func (s *Scene) DefaultLayer() *Layer {
+	s.init()
+	return s.defaultLayer
+}
+
+// SetLayerVisible shows or hides every element on a layer
+func (s *Scene) SetLayerVisible(name string, isVisible bool) error {
+	l, err := s.Layer(name)
+	if err != nil {
+		return err
+	}
+	l.SetVisible(isVisible)
+	return nil
+}
+
 // Element returns an element based on name
 func (s *Scene) Element(name string) (element.Interfacer, error) {
 	if name == "" {
 		return nil, common.ErrElementNameInvalid
 	}
-	for _, se := range s.elementsNextUpdate {
-		if se.Name() != name {
+	for _, l := range s.layers {
+		e, err := l.Element(name)
+		if err != nil {
+			continue
+		}
+		return e, nil
+	}
+	return nil, common.ErrElementNotFound
+}
+
+// ElementLayer returns the layer an element is placed in
+func (s *Scene) ElementLayer(name string) (*Layer, error) {
+	if name == "" {
+		return nil, common.ErrElementNameInvalid
+	}
+	for _, l := range s.layers {
+		_, err := l.Element(name)
+		if err != nil {
 			continue
 		}
-		return se, nil
+		return l, nil
 	}
 	return nil, common.ErrElementNotFound
 }
 
-// AddElement adds an element to the scene list
+// AddElement adds an element to the scene's default layer
 func (s *Scene) AddElement(e element.Interfacer) error {
+	l := s.DefaultLayer()
+	if l == nil {
+		return common.ErrLayerNotFound
+	}
+	return s.addElement(e, l)
+}
+
+// AddElementToLayer adds an element to a named layer
+func (s *Scene) AddElementToLayer(e element.Interfacer, layerName string) error {
+	l, err := s.Layer(layerName)
+	if err != nil {
+		return err
+	}
+	return s.addElement(e, l)
+}
+
+func (s *Scene) addElement(e element.Interfacer, l *Layer) error {
 	if e.Name() == "" {
 		return common.ErrElementNotFound
 	}
-	for _, se := range s.elementsNextUpdate {
-		if se.Name() != e.Name() {
-			continue
-		}
+	_, err := s.Element(e.Name())
+	if err == nil {
 		return common.ErrElementAlreadyExists
 	}
-	s.elementsNextUpdate = append(s.elementsNextUpdate, e)
-	s.isElementsNextUpdateDirty = true
-	sort.Sort(elements(s.elementsNextUpdate))
+	l.addElement(e)
+	return nil
+}
+
+// SetElementLayer moves an element to a named layer
+func (s *Scene) SetElementLayer(name string, layerName string) error {
+	dst, err := s.Layer(layerName)
+	if err != nil {
+		return err
+	}
+	src, err := s.ElementLayer(name)
+	if err != nil {
+		return err
+	}
+	if src == dst {
+		return nil
+	}
+	e, err := src.Element(name)
+	if err != nil {
+		return err
+	}
+	err = src.removeElement(name)
+	if err != nil {
+		return err
+	}
+	dst.addElement(e)
 	return nil
 }
 
 // RemoveElement flags an element to be removed next update
 func (s *Scene) RemoveElement(name string) error {
+	e, err := s.Element(name)
+	if err != nil {
+		return err
+	}
+	err = s.detachElement(name)
+	if err != nil {
+		return err
+	}
+	if r, ok := e.(element.Remover); ok {
+		r.OnRemove()
+	}
+	return nil
+}
+
+// detachElement takes an element out of the scene without telling it, e.g. to move it into a container
+func (s *Scene) detachElement(name string) error {
 	if name == "" {
 		return common.ErrElementNameInvalid
 	}
-	var isFound bool
-	for i := range s.elementsNextUpdate {
-		if s.elementsNextUpdate[i].Name() != name {
-			continue
+	l, err := s.ElementLayer(name)
+	if err != nil {
+		return err
+	}
+	return l.removeElement(name)
+}
+
+// blur unfocuses every element, returning those that were focused
+func (s *Scene) blur() []element.Focuser {
+	var focused []element.Focuser
+	for _, l := range s.layers {
+		for _, e := range l.elementsNextUpdate {
+			f, ok := e.(element.Focuser)
+			if !ok || !f.IsFocused() {
+				continue
+			}
+			f.SetIsFocused(false)
+			focused = append(focused, f)
 		}
-		isFound = true
-		s.elementsNextUpdate[i] = s.elementsNextUpdate[len(s.elementsNextUpdate)-1]
-		s.elementsNextUpdate = s.elementsNextUpdate[:len(s.elementsNextUpdate)-1]
-		break
 	}
-	if !isFound {
-		return common.ErrElementNotFound
+	return focused
+}
+
+// SetSortMode changes how elements are ordered before drawing on every layer.
+// Order is re-evaluated every update
+func (s *Scene) SetSortMode(mode SortMode) {
+	s.init()
+	s.sortMode = mode
+	for _, l := range s.layers {
+		l.SetSortMode(mode)
 	}
-	sort.Sort(elements(s.elementsNextUpdate))
-	s.isElementsNextUpdateDirty = true
-	return nil
 }
 
-// Update is called during a frame update
+// SortMode returns how elements are ordered before drawing on new layers
+func (s *Scene) SortMode() SortMode {
+	return s.sortMode
+}
+
+// SetSortFunc sets a custom less function on every layer and switches them to SortCustom
+func (s *Scene) SetSortFunc(less func(a, b element.Interfacer) bool) {
+	s.init()
+	s.sortMode = SortCustom
+	s.sortFunc = less
+	for _, l := range s.layers {
+		l.SetSortFunc(less)
+	}
+}
+
+// Tween starts playing a tween, advanced with the scene
+func (s *Scene) Tween(t common.Tweener) {
+	if s.tweens == nil {
+		s.tweens = common.NewTweenManager()
+	}
+	s.tweens.Add(t)
+}
+
+// KillTweens stops every tween in the scene animating target
+func (s *Scene) KillTweens(target interface{}) int {
+	if s.tweens == nil {
+		return 0
+	}
+	return s.tweens.Kill(target)
+}
+
+// Clock returns the scene clock. Pausing or scaling it affects every lerp, tween and animation in the scene
+func (s *Scene) Clock() *common.Clock {
+	s.init()
+	return s.clock
+}
+
+// SetPaused pauses or resumes scene time. Elements still receive input while paused
+func (s *Scene) SetPaused(isPaused bool) {
+	s.Clock().SetPaused(isPaused)
+}
+
+// IsPaused returns true if scene time is paused
+func (s *Scene) IsPaused() bool {
+	return s.Clock().IsPaused()
+}
+
+// SetTimeScale sets the speed of scene time, e.g. 0.5 for slow motion
+func (s *Scene) SetTimeScale(timeScale float64) {
+	s.Clock().SetTimeScale(timeScale)
+}
+
+// TimeScale returns the speed of scene time
+func (s *Scene) TimeScale() float64 {
+	return s.Clock().TimeScale()
+}
+
+// After calls fn once after delay of scene time
+func (s *Scene) After(delay time.Duration, fn func()) *common.Timer {
+	s.init()
+	return s.timers.After(delay, fn)
+}
+
+// Every calls fn each interval of scene time until cancelled
+func (s *Scene) Every(interval time.Duration, fn func()) *common.Timer {
+	s.init()
+	return s.timers.Every(interval, fn)
+}
+
+// Until calls fn each interval of scene time until it returns true or is cancelled
+func (s *Scene) Until(interval time.Duration, fn func() bool) *common.Timer {
+	s.init()
+	return s.timers.Until(interval, fn)
+}
+
+// RunSequence starts playing a sequence, advanced with scene time.
+// Sequences are cancelled when the scene exits
+func (s *Scene) RunSequence(seq *common.Sequence) {
+	if seq == nil {
+		return
+	}
+	s.sequences = append(s.sequences, seq)
+}
+
+// CancelSequences cancels every sequence playing in the scene
+func (s *Scene) CancelSequences() {
+	for _, seq := range s.sequences {
+		seq.Cancel()
+	}
+	s.sequences = nil
+}
+
+// SkipSequences completes every sequence playing in the scene immediately
+func (s *Scene) SkipSequences() {
+	for _, seq := range s.sequences {
+		seq.Skip()
+	}
+}
+
+// onExit is called when the UI switches away from a scene
+func (s *Scene) onExit() {
+	s.CancelSequences()
+	for _, l := range s.layers {
+		for _, e := range l.elementsNextUpdate {
+			if se, ok := e.(element.SceneExiter); ok {
+				se.OnSceneExit()
+			}
+		}
+	}
+}
+
+// destroy cancels all timers, tweens and sequences of a scene
+func (s *Scene) destroy() {
+	s.init()
+	s.CancelSequences()
+	s.timers.CancelAll()
+	if s.tweens != nil {
+		s.tweens.KillAll()
+	}
+	for _, l := range s.layers {
+		l.disposeBuffer()
+	}
+}
+
+// Update is called during a frame update. dt is scaled by the scene clock
 func (s *Scene) Update(dt float64) {
-	if s.isElementsNextUpdateDirty {
-		s.elements = s.elementsNextUpdate
-		s.isElementsNextUpdateDirty = false
+	dt = s.Clock().Advance(dt)
+	s.timers.Update(dt)
+	s.updateSequences(dt)
+	if s.tweens != nil {
+		s.tweens.Update(dt)
 	}
+	for _, l := range s.layers {
+		l.update(dt)
+	}
+}
 
-	for _, e := range s.elements {
-		e.Update(dt)
-		if e.IsDestroyed() {
-			s.RemoveElement(e.Name())
+func (s *Scene) updateSequences(dt float64) {
+	// sequences started while updating are first advanced next update
+	count := len(s.sequences)
+	for i := 0; i < count && i < len(s.sequences); i++ {
+		s.sequences[i].Update(dt)
+	}
+	n := 0
+	for _, seq := range s.sequences {
+		if seq.IsFinished() || seq.IsCancelled() {
+			continue
 		}
+		s.sequences[n] = seq
+		n++
 	}
+	for i := n; i < len(s.sequences); i++ {
+		s.sequences[i] = nil
+	}
+	s.sequences = s.sequences[:n]
 }
 
 // Draw renders on a destination image
 func (s *Scene) Draw(screen *ebiten.Image) {
-	for _, e := range s.elements {
-		if !e.IsVisible() {
-			continue
-		}
-		e.Draw(screen)
+	for _, l := range s.layers {
+		l.draw(screen)
 	}
 }
 
 func (s *Scene) onResolutionChange(resolution image.Point) {
+	s.resolution = resolution
+	s.init()
+	for _, l := range s.layers {
+		l.onResolutionChange(resolution)
+	}
 }
@@ -0,0 +1,179 @@
+package egui
+
+import (
+	"image"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/hajimehoshi/ebiten"
+	"github.com/xackery/egui/element"
+)
+
+// testElement records when it is drawn
+type testElement struct {
+	name        string
+	renderIndex int64
+	x           float64
+	y           float64
+	height      int
+	drawn       *[]string
+}
+
+func (e *testElement) IsEnabled() bool                  { return true }
+func (e *testElement) SetEnabled(isEnabled bool)        {}
+func (e *testElement) IsVisible() bool                  { return true }
+func (e *testElement) SetVisible(isVisible bool)        {}
+func (e *testElement) Update(dt float64)                {}
+func (e *testElement) Draw(screen *ebiten.Image)        { *e.drawn = append(*e.drawn, e.name) }
+func (e *testElement) Name() string                     { return e.name }
+func (e *testElement) RenderIndex() int64               { return e.renderIndex }
+func (e *testElement) SetRenderIndex(renderIndex int64) { e.renderIndex = renderIndex }
+func (e *testElement) IsDestroyed() bool                { return false }
+func (e *testElement) SetIsDestroyed(isDestroyed bool)  {}
+func (e *testElement) SetText(text string)              {}
+func (e *testElement) Position() (float64, float64)     { return e.x, e.y }
+func (e *testElement) SetPosition(x float64, y float64) { e.x, e.y = x, y }
+func (e *testElement) Width() int                       { return 10 }
+func (e *testElement) Height() int                      { return e.height }
+func (e *testElement) LerpPosition(endPositionX, endPositionY float64, duration time.Duration, isDestroyed bool, endFunc func()) {
+}
+
+// testPivotElement sorts by an explicit pivot
+type testPivotElement struct {
+	testElement
+	pivotY float64
+}
+
+func (e *testPivotElement) Pivot() (float64, float64) { return e.x, e.pivotY }
+
+// newTestElements returns elements whose render index, top and bottom order all differ
+func newTestElements(drawn *[]string) []element.Interfacer {
+	return []element.Interfacer{
+		// bottom 60
+		&testElement{name: "a", renderIndex: 0, y: 50, height: 10, drawn: drawn},
+		// bottom 100, top 0
+		&testElement{name: "b", renderIndex: 1, y: 0, height: 100, drawn: drawn},
+		// pivot 20, below its top
+		&testPivotElement{testElement: testElement{name: "c", renderIndex: 2, y: 80, height: 10, drawn: drawn}, pivotY: 20},
+		// bottom 60, ties with a
+		&testElement{name: "d", renderIndex: 3, y: 40, height: 20, drawn: drawn},
+	}
+}
+
+func TestLayerSortModes(t *testing.T) {
+	byNameDesc := func(a, b element.Interfacer) bool { return a.Name() > b.Name() }
+	tests := []struct {
+		name string
+		mode SortMode
+		less func(a, b element.Interfacer) bool
+		want []string
+	}{
+		{"render index", SortRenderIndex, nil, []string{"a", "b", "c", "d"}},
+		{"y", SortY, nil, []string{"c", "a", "d", "b"}},
+		{"custom", SortCustom, byNameDesc, []string{"d", "c", "b", "a"}},
+		{"custom without func", SortCustom, nil, []string{"a", "b", "c", "d"}},
+	}
+	for _, tt := range tests {
+		var drawn []string
+		l := newLayer("test", tt.mode, image.Point{})
+		if tt.less != nil {
+			l.SetSortFunc(tt.less)
+		}
+		els := newTestElements(&drawn)
+		for i := len(els) - 1; i >= 0; i-- {
+			l.addElement(els[i])
+		}
+		l.update(0)
+		l.draw(nil)
+		if !reflect.DeepEqual(drawn, tt.want) {
+			t.Errorf("%s: drew %v, want %v", tt.name, drawn, tt.want)
+		}
+	}
+}
+
+func TestSceneSetSortMode(t *testing.T) {
+	var drawn []string
+	s := &Scene{}
+	els := newTestElements(&drawn)
+	for _, e := range els[:2] {
+		if err := s.AddElement(e); err != nil {
+			t.Fatalf("add %s: %v", e.Name(), err)
+		}
+	}
+	for _, e := range els[2:] {
+		if err := s.AddElementToLayer(e, LayerHUD); err != nil {
+			t.Fatalf("add %s: %v", e.Name(), err)
+		}
+	}
+
+	s.Update(0)
+	s.Draw(nil)
+	if want := []string{"a", "b", "c", "d"}; !reflect.DeepEqual(drawn, want) {
+		t.Errorf("render index drew %v, want %v", drawn, want)
+	}
+
+	s.SetSortMode(SortY)
+	drawn = nil
+	s.Update(0)
+	s.Draw(nil)
+	// layers still draw in order, each sorted by its own pivots
+	if want := []string{"a", "b", "c", "d"}; !reflect.DeepEqual(drawn, want) {
+		t.Errorf("y drew %v, want %v", drawn, want)
+	}
+
+	// moving d into the world sorts it by y against a and b
+	if err := s.SetElementLayer("d", LayerWorld); err != nil {
+		t.Fatalf("move d: %v", err)
+	}
+	drawn = nil
+	s.Update(0)
+	s.Draw(nil)
+	if want := []string{"a", "d", "b", "c"}; !reflect.DeepEqual(drawn, want) {
+		t.Errorf("after move drew %v, want %v", drawn, want)
+	}
+
+	l, err := s.NewLayer("effects")
+	if err != nil {
+		t.Fatalf("new layer: %v", err)
+	}
+	if l.SortMode() != SortY {
+		t.Errorf("new layer sort mode %v, want %v", l.SortMode(), SortY)
+	}
+}
+
+func TestSceneSetSortFunc(t *testing.T) {
+	var drawn []string
+	s := &Scene{}
+	for _, e := range newTestElements(&drawn) {
+		if err := s.AddElement(e); err != nil {
+			t.Fatalf("add %s: %v", e.Name(), err)
+		}
+	}
+	s.SetSortFunc(func(a, b element.Interfacer) bool { return a.Name() > b.Name() })
+	if s.SortMode() != SortCustom {
+		t.Errorf("sort mode %v, want %v", s.SortMode(), SortCustom)
+	}
+	s.Update(0)
+	s.Draw(nil)
+	if want := []string{"d", "c", "b", "a"}; !reflect.DeepEqual(drawn, want) {
+		t.Errorf("drew %v, want %v", drawn, want)
+	}
+}
+
+func TestSortModeString(t *testing.T) {
+	tests := []struct {
+		mode SortMode
+		want string
+	}{
+		{SortRenderIndex, "render index"},
+		{SortY, "y"},
+		{SortCustom, "custom"},
+		{SortMode(99), "render index"},
+	}
+	for _, tt := range tests {
+		if got := tt.mode.String(); got != tt.want {
+			t.Errorf("mode %d: got %q, want %q", tt.mode, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,46 @@
+package egui
+
+import (
+	"github.com/pkg/errors"
+	"github.com/xackery/egui/common"
+	"github.com/xackery/egui/element/scrollview"
+)
+
+// NewScrollView creates a new scroll view instance. Scrollbars are drawn with 9slices of the ui image,
+// and are hidden if thumbSliceName is empty
+func (u *UI) NewScrollView(name string, scene string, x float64, y float64, width int, height int, trackSliceName string, thumbSliceName string) (*scrollview.Element, error) {
+	imageName := "ui"
+	img, err := u.Image(imageName)
+	if err != nil {
+		return nil, errors.Wrap(err, imageName)
+	}
+
+	s, err := u.Scene(scene)
+	if err != nil {
+		return nil, common.ErrSceneNotFound
+	}
+
+	e, err := scrollview.New(name, scene, x, y, width, height, img, trackSliceName, thumbSliceName)
+	err = s.AddElement(e)
+	if err != nil {
+		return nil, err
+	}
+	return e, nil
+}
+
+// AddToScrollView moves a named element of a scene into a scroll view. Its position becomes relative to the scrolled content
+func (u *UI) AddToScrollView(scene string, name string, view *scrollview.Element) error {
+	s, err := u.Scene(scene)
+	if err != nil {
+		return common.ErrSceneNotFound
+	}
+	e, err := s.Element(name)
+	if err != nil {
+		return err
+	}
+	err = view.AddElement(e)
+	if err != nil {
+		return err
+	}
+	return s.detachElement(name)
+}
@@ -0,0 +1,28 @@
+package egui
+
+import (
+	"github.com/pkg/errors"
+	"github.com/xackery/egui/common"
+	"github.com/xackery/egui/element/slider"
+)
+
+// NewSlider creates a new slider instance from 0 to 1, drawn with 9slices of the ui image
+func (u *UI) NewSlider(name string, scene string, x float64, y float64, width int, height int, orientation common.Orientation, trackSliceName string, fillSliceName string, thumbSliceName string) (*slider.Element, error) {
+	imageName := "ui"
+	img, err := u.Image(imageName)
+	if err != nil {
+		return nil, errors.Wrap(err, imageName)
+	}
+
+	s, err := u.Scene(scene)
+	if err != nil {
+		return nil, common.ErrSceneNotFound
+	}
+
+	e, err := slider.New(name, scene, x, y, width, height, orientation, img, trackSliceName, fillSliceName, thumbSliceName)
+	err = s.AddElement(e)
+	if err != nil {
+		return nil, err
+	}
+	return e, nil
+}
@@ -0,0 +1,31 @@
+package egui
+
+import (
+	"image/color"
+
+	"github.com/pkg/errors"
+	"github.com/xackery/egui/common"
+	"github.com/xackery/egui/element/textinput"
+)
+
+// NewTextInput creates a new single line text input instance, drawn with 9slices of the ui image.
+// focusedSliceName is drawn while the input has focus, and may be empty
+func (u *UI) NewTextInput(name string, scene string, x float64, y float64, width int, height int, textColor color.Color, sliceName string, focusedSliceName string) (*textinput.Element, error) {
+	imageName := "ui"
+	img, err := u.Image(imageName)
+	if err != nil {
+		return nil, errors.Wrap(err, imageName)
+	}
+
+	s, err := u.Scene(scene)
+	if err != nil {
+		return nil, common.ErrSceneNotFound
+	}
+
+	e, err := textinput.New(name, scene, x, y, width, height, u.defaultFont, textColor, img, sliceName, focusedSliceName, u.clipboard)
+	err = s.AddElement(e)
+	if err != nil {
+		return nil, err
+	}
+	return e, nil
+}
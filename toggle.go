@@ -0,0 +1,30 @@
+package egui
+
+import (
+	"image/color"
+
+	"github.com/pkg/errors"
+	"github.com/xackery/egui/common"
+	"github.com/xackery/egui/element/toggle"
+)
+
+// NewToggle creates a new toggle switch instance, drawn with 9slices of the ui image
+func (u *UI) NewToggle(name string, scene string, text string, x float64, y float64, width int, height int, textColor color.Color, offSliceName string, onSliceName string, knobSliceName string) (*toggle.Element, error) {
+	imageName := "ui"
+	img, err := u.Image(imageName)
+	if err != nil {
+		return nil, errors.Wrap(err, imageName)
+	}
+
+	s, err := u.Scene(scene)
+	if err != nil {
+		return nil, common.ErrSceneNotFound
+	}
+
+	e, err := toggle.New(name, scene, text, x, y, width, height, u.defaultFont, textColor, img, offSliceName, onSliceName, knobSliceName)
+	err = s.AddElement(e)
+	if err != nil {
+		return nil, err
+	}
+	return e, nil
+}
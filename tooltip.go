@@ -0,0 +1,39 @@
+package egui
+
+import (
+	"image/color"
+
+	"github.com/pkg/errors"
+	"github.com/xackery/egui/common"
+	"github.com/xackery/egui/element/tooltip"
+)
+
+// NewTooltip attaches a new tooltip instance to the element named target, shown on the popup layer.
+// The tooltip is named target.tooltip, and is destroyed with its target
+func (u *UI) NewTooltip(scene string, target string, text string, textColor color.Color, sliceName string) (*tooltip.Element, error) {
+	imageName := "ui"
+	img, err := u.Image(imageName)
+	if err != nil {
+		return nil, errors.Wrap(err, imageName)
+	}
+
+	s, err := u.Scene(scene)
+	if err != nil {
+		return nil, common.ErrSceneNotFound
+	}
+
+	te, err := s.Element(target)
+	if err != nil {
+		return nil, errors.Wrap(err, target)
+	}
+
+	e, err := tooltip.New(target+".tooltip", scene, te, text, u.defaultFont, textColor, img, sliceName)
+	if err != nil {
+		return nil, errors.Wrap(err, target)
+	}
+	err = s.AddElementToLayer(e, LayerPopup)
+	if err != nil {
+		return nil, err
+	}
+	return e, nil
+}
@@ -10,6 +10,8 @@ import (
 	"github.com/hajimehoshi/ebiten"
 	"github.com/pkg/errors"
 	"github.com/xackery/egui/common"
+	"github.com/xackery/egui/element"
+	"github.com/xackery/egui/element/modal"
 	"golang.org/x/image/font/gofont/goregular"
 	"golang.org/x/text/language"
 )
@@ -28,9 +30,18 @@ type UI struct {
 	images           map[string]*common.Image
 	fonts            map[string]*common.Font
 	lastUpdate       time.Time
+	clock            *common.Clock
+	timers           *common.Scheduler
 	tileScale        float64
 	textScale        float64
 	defaultLanguage  language.Tag
+	language         language.Tag
+	catalog          *common.Catalog
+	textKeys         map[element.Interfacer]*textKey
+	isPseudoLocale   bool
+	clipboard        common.Clipboard
+	dialogStyle      modal.Style
+	dialogCount      int
 }
 
 // NewUI instantiates a new User Interface
@@ -43,7 +54,14 @@ func NewUI(screenResolution image.Point, scale float64) (*UI, error) {
 		tileScale:        1,
 		textScale:        1,
 		defaultLanguage:  language.AmericanEnglish,
+		language:         language.AmericanEnglish,
+		textKeys:         make(map[element.Interfacer]*textKey),
+		clipboard:        &common.MemoryClipboard{},
+		dialogStyle:      modal.DefaultStyle(),
+		clock:            common.NewClock(),
+		timers:           common.NewScheduler(),
 	}
+	u.catalog = common.NewCatalog(u.defaultLanguage)
 	gs, err := u.NewScene("global")
 	if err != nil {
 		return nil, err
@@ -51,10 +69,14 @@ func NewUI(screenResolution image.Point, scale float64) (*UI, error) {
 	u.globalScene = gs
 	u.currentScene = gs
 	u.lastUpdate = time.Now()
-	u.defaultFont, err = u.NewFontTTF("goregular", goregular.TTF, nil, 'M')
+	u.defaultFont, err = u.NewFont("goregular", goregular.TTF, nil)
 	if err != nil {
 		return nil, errors.Wrap(err, "goregular font")
 	}
+	err = u.SetScale(scale)
+	if err != nil {
+		return nil, errors.Wrap(err, "scale")
+	}
 	return u, nil
 }
 
@@ -71,6 +93,19 @@ func (u *UI) Resolution() image.Point {
 	return u.screenResolution
 }
 
+// Clipboard returns the clipboard text inputs cut, copy and paste with
+func (u *UI) Clipboard() common.Clipboard {
+	return u.clipboard
+}
+
+// SetClipboard sets the clipboard used by text inputs created afterwards, e.g. one backed by the system clipboard
+func (u *UI) SetClipboard(clipboard common.Clipboard) {
+	if clipboard == nil {
+		clipboard = &common.MemoryClipboard{}
+	}
+	u.clipboard = clipboard
+}
+
 // AddImage adds an image image to ui
 func (u *UI) AddImage(img *common.Image) error {
 	if img.Name == "" {
@@ -95,9 +130,11 @@ func (u *UI) Image(name string) (*common.Image, error) {
 
 // Update updates all UI elements
 func (u *UI) Update(image *ebiten.Image) error {
-	dt := time.Since(u.lastUpdate).Seconds()
+	now := time.Now()
+	dt := now.Sub(u.lastUpdate).Seconds()
+	u.lastUpdate = now
 
-	u.onUpdate(dt)
+	u.Step(dt)
 
 	//graphical elements
 	if ebiten.IsDrawingSkipped() {
@@ -108,18 +145,61 @@ func (u *UI) Update(image *ebiten.Image) error {
 	return nil
 }
 
+// Step advances the UI clock by dt seconds and updates all scenes without reading the wall clock.
+// Tests can call Step directly to play frames deterministically
+func (u *UI) Step(dt float64) {
+	dt = u.clock.Advance(dt)
+	u.timers.Update(dt)
+	u.onUpdate(dt)
+}
+
+// After calls fn once after delay of UI time. UI timers keep running across scene changes
+func (u *UI) After(delay time.Duration, fn func()) *common.Timer {
+	return u.timers.After(delay, fn)
+}
+
+// Every calls fn each interval of UI time until cancelled
+func (u *UI) Every(interval time.Duration, fn func()) *common.Timer {
+	return u.timers.Every(interval, fn)
+}
+
+// Until calls fn each interval of UI time until it returns true or is cancelled
+func (u *UI) Until(interval time.Duration, fn func() bool) *common.Timer {
+	return u.timers.Until(interval, fn)
+}
+
+// Clock returns the UI clock. Pausing or scaling it affects every scene
+func (u *UI) Clock() *common.Clock {
+	return u.clock
+}
+
 func (u *UI) onUpdate(dt float64) {
 	if u.globalScene != nil {
 		u.globalScene.Update(dt)
 	}
-	if u.currentScene != nil {
+	// the global scene is also the current scene until another is set
+	if u.currentScene != nil && u.currentScene != u.globalScene {
 		u.currentScene.Update(dt)
 	}
 }
 
+// Tween starts playing a tween on the global scene
+func (u *UI) Tween(t common.Tweener) {
+	u.globalScene.Tween(t)
+}
+
+// KillTweens stops every tween animating target in all scenes
+func (u *UI) KillTweens(target interface{}) int {
+	count := 0
+	for _, s := range u.scenes {
+		count += s.KillTweens(target)
+	}
+	return count
+}
+
 // Draw renders all UI elements
 func (u *UI) Draw(screen *ebiten.Image) {
-	if u.currentScene != nil {
+	if u.currentScene != nil && u.currentScene != u.globalScene {
 		u.currentScene.Draw(screen)
 	}
 	if u.globalScene != nil {
@@ -133,6 +213,9 @@ func (u *UI) SetCurrentScene(name string) error {
 	if !ok {
 		return common.ErrSceneNotFound
 	}
+	if u.currentScene != s && u.currentScene != u.globalScene {
+		u.currentScene.onExit()
+	}
 	u.currentScene = s
 	return nil
 }
@@ -153,6 +236,25 @@ func (u *UI) AddScene(name string, scene *Scene) error {
 	return nil
 }
 
+// RemoveScene destroys a scene, cancelling its timers and tweens.
+// Removing the current scene switches back to the global scene
+func (u *UI) RemoveScene(name string) error {
+	scene, ok := u.scenes[name]
+	if !ok {
+		return common.ErrSceneNotFound
+	}
+	if scene == u.globalScene {
+		return common.ErrSceneCannotRemoveGlobal
+	}
+	if scene == u.currentScene {
+		scene.onExit()
+		u.currentScene = u.globalScene
+	}
+	scene.destroy()
+	delete(u.scenes, name)
+	return nil
+}
+
 // Scene gets a scene
 func (u *UI) Scene(name string) (*Scene, error) {
 	scene, ok := u.scenes[name]
@@ -0,0 +1,59 @@
+package egui
+
+import (
+	"image/color"
+
+	"github.com/pkg/errors"
+	"github.com/xackery/egui/common"
+	"github.com/xackery/egui/element/window"
+)
+
+// NewWindowStack creates a stack that windows created with it are ordered in. Its layout can be saved and loaded,
+// keeping restored windows on the current resolution
+func (u *UI) NewWindowStack(name string) *window.Stack {
+	s := window.NewStack(name)
+	s.SetScreenSize(u.screenResolution.X, u.screenResolution.Y)
+	return s
+}
+
+// NewWindow creates a new window instance at the front of stack, drawn with 9slices of the ui image
+func (u *UI) NewWindow(name string, scene string, title string, x float64, y float64, width int, height int, textColor color.Color, stack *window.Stack, sliceName string, titleSliceName string) (*window.Element, error) {
+	imageName := "ui"
+	img, err := u.Image(imageName)
+	if err != nil {
+		return nil, errors.Wrap(err, imageName)
+	}
+
+	s, err := u.Scene(scene)
+	if err != nil {
+		return nil, common.ErrSceneNotFound
+	}
+
+	e, err := window.New(name, scene, title, x, y, width, height, u.defaultFont, textColor, img, stack, sliceName, titleSliceName)
+	if err != nil {
+		return nil, err
+	}
+	err = s.AddElement(e)
+	if err != nil {
+		e.SetIsDestroyed(true)
+		return nil, err
+	}
+	return e, nil
+}
+
+// AddToWindow moves a named element of a scene into a window. Its position becomes relative to the window content
+func (u *UI) AddToWindow(scene string, name string, w *window.Element) error {
+	s, err := u.Scene(scene)
+	if err != nil {
+		return common.ErrSceneNotFound
+	}
+	e, err := s.Element(name)
+	if err != nil {
+		return err
+	}
+	err = w.AddElement(e)
+	if err != nil {
+		return err
+	}
+	return s.detachElement(name)
+}